@@ -15,6 +15,10 @@
 package handler
 
 import (
+	"io"
+	"net/http"
+	"time"
+
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
@@ -44,6 +48,80 @@ func GetCourse(c *gin.Context) {
 	PostProcess(c, &req, resp, err)
 }
 
+// GetCourseStats godoc
+// @Summary 获取课程详情统计
+// @Description 返回课程评论数、点赞总数、全部标签分布、评分汇总、评论量时间线和点赞最多的评论
+// @Tags course
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Param granularity query string false "时间线粒度：month（默认）或 semester"
+// @Success 200 {object} Response[dto.GetCourseStatsResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/stats [get]
+func GetCourseStats(c *gin.Context) {
+	var req dto.GetCourseStatsReq
+	var resp *dto.GetCourseStatsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.GetCourseStats(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// GetCourseRecommendations godoc
+// @Summary 获取个性化课程推荐
+// @Description 根据搜索历史、评价过课程的院系与类别和相似用户的点赞推荐课程，附带推荐理由；新用户返回热门课程
+// @Tags course
+// @Produce json
+// @Param limit query int false "推荐数量，默认 10，最多 50"
+// @Success 200 {object} Response[dto.GetCourseRecommendationsResp]
+// @Security Bearer
+// @Router /api/course/recommend [get]
+func GetCourseRecommendations(c *gin.Context) {
+	var req dto.GetCourseRecommendationsReq
+	var resp *dto.GetCourseRecommendationsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.GetRecommendations(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// CompareCourses godoc
+// @Summary 对比课程
+// @Description 并排对比 2~4 门课程的教师、校区、类别、标签分布、评分、评论数和点赞最多的评论，标签分布按全部课程的标签对齐
+// @Tags course
+// @Produce json
+// @Param ids query []string true "课程ID，2~4 个，可重复传参或以逗号分隔" collectionFormat(multi)
+// @Success 200 {object} Response[dto.CompareCoursesResp]
+// @Security Bearer
+// @Router /api/course/compare [get]
+func CompareCourses(c *gin.Context) {
+	var req dto.CompareCoursesReq
+	var resp *dto.CompareCoursesResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.CompareCourses(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
 // GetCourseDepartments godoc
 // @Summary 获取课程开课院系
 // @Description 根据课程名字获取课程开课院系
@@ -140,7 +218,7 @@ func ListCourses(c *gin.Context) {
 	if req.Keyword != "" {
 		go func() {
 			cCopy := c.Copy()
-			if errCopy := provider.Get().SearchHistoryService.LogSearch(cCopy, req.Keyword); errCopy != nil {
+			if errCopy := provider.Get().SearchHistoryService.LogSearch(cCopy, req.Keyword, ""); errCopy != nil {
 				logs.CtxErrorf(cCopy, "[SearchHistoryService] [LogSearch] error: %v", errCopy)
 			}
 		}()
@@ -149,3 +227,255 @@ func ListCourses(c *gin.Context) {
 	resp, err = provider.Get().CourseService.ListCourses(c, &req)
 	PostProcess(c, &req, resp, err)
 }
+
+// CreateCourse godoc
+// @Summary 创建课程
+// @Description 管理员直接创建课程，并记录变更日志
+// @Tags course
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateCourseReq true "CreateCourseReq"
+// @Success 200 {object} Response[dto.CreateCourseResp]
+// @Security Bearer
+// @Router /api/course/add [post]
+func CreateCourse(c *gin.Context) {
+	var req dto.CreateCourseReq
+	var resp *dto.CreateCourseResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.CreateCourse(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// UpdateCourse godoc
+// @Summary 修改课程
+// @Description 管理员修改课程信息，并记录修改前后的快照
+// @Tags course
+// @Accept json
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Param body body dto.UpdateCourseReq true "UpdateCourseReq"
+// @Success 200 {object} Response[dto.UpdateCourseResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/update [post]
+func UpdateCourse(c *gin.Context) {
+	var req dto.UpdateCourseReq
+	var resp *dto.UpdateCourseResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.UpdateCourse(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// DeleteCourse godoc
+// @Summary 删除课程
+// @Description 管理员软删除课程，可通过恢复接口撤销
+// @Tags course
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.DeleteCourseResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/delete [post]
+func DeleteCourse(c *gin.Context) {
+	var req dto.DeleteCourseReq
+	var resp *dto.DeleteCourseResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.DeleteCourse(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RestoreCourse godoc
+// @Summary 恢复课程
+// @Description 管理员恢复已删除的课程
+// @Tags course
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.RestoreCourseResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/restore [post]
+func RestoreCourse(c *gin.Context) {
+	var req dto.RestoreCourseReq
+	var resp *dto.RestoreCourseResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.RestoreCourse(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// MergeCourses godoc
+// @Summary 合并重复课程
+// @Description 管理员将重复课程合并到保留课程，迁移评论与来源提案，dryRun 时仅返回影响预览
+// @Tags course
+// @Accept json
+// @Produce json
+// @Param body body dto.MergeCoursesReq true "MergeCoursesReq"
+// @Success 200 {object} Response[dto.MergeCoursesResp]
+// @Security Bearer
+// @Router /api/course/merge [post]
+func MergeCourses(c *gin.Context) {
+	var req dto.MergeCoursesReq
+	var resp *dto.MergeCoursesResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.MergeCourses(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ImportCourses godoc
+// @Summary 批量导入课程目录
+// @Description 管理员上传 CSV/XLSX 课程目录（列：name, code, teachers, department, category, campuses），dryRun 时只返回差异预览
+// @Tags course
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "课程目录文件"
+// @Param dryRun formData bool false "是否只预览差异"
+// @Success 200 {object} Response[dto.ImportCoursesResp]
+// @Security Bearer
+// @Router /api/course/import [post]
+func ImportCourses(c *gin.Context) {
+	var req dto.ImportCoursesReq
+	var resp *dto.ImportCoursesResp
+	var err error
+
+	if err = c.ShouldBind(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	file, err := c.FormFile(consts.CourseImportFormFile)
+	if err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	defer f.Close()
+	req.FileName = file.Filename
+	if req.Data, err = io.ReadAll(io.LimitReader(f, consts.CourseImportMaxSize+1)); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.ImportCourses(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ExportCourses godoc
+// @Summary 导出课程目录
+// @Description 管理员按 ListCourses 的筛选条件流式导出课程，包含解析后的院系、类别、校区、教师名称以及评论数与标签分布
+// @Tags course
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param keyword query string false "筛选关键词"
+// @Param type query string false "筛选类型：course/teacher/category/department，为空时导出全部"
+// @Param format query string false "导出格式：csv（默认）或 ndjson"
+// @Success 200 {file} file
+// @Security Bearer
+// @Router /api/course/export [get]
+func ExportCourses(c *gin.Context) {
+	var req dto.ExportCoursesReq
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	contentType, ext := "text/csv; charset=utf-8", consts.CourseExportFormatCSV
+	if req.Format == consts.CourseExportFormatNDJSON {
+		contentType, ext = "application/x-ndjson; charset=utf-8", consts.CourseExportFormatNDJSON
+	}
+	w := &exportWriter{c: c, contentType: contentType,
+		filename: "courses-" + time.Now().Format("20060102") + "." + ext}
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	if err = provider.Get().CourseService.ExportCourses(c, &req, w); err != nil {
+		// 已开始写出时无法再返回错误响应，只记录日志
+		if w.started {
+			logs.CtxErrorf(c, "[ExportCourses] stream interrupted: %v", err)
+			return
+		}
+		PostProcess(c, &req, nil, err)
+	}
+}
+
+// exportWriter 在第一次写入时才设置下载响应头，写入前的错误仍可按普通 JSON 响应返回
+type exportWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// SearchCourses godoc
+// @Summary 组合条件搜索课程
+// @Description 按名称关键词、教师、院系、类别、校区和标签组合搜索课程，支持按相关度、评论数、评分和创建时间排序
+// @Tags course
+// @Accept json
+// @Produce json
+// @Param body body dto.SearchCoursesReq true "SearchCoursesReq"
+// @Success 200 {object} Response[dto.SearchCoursesResp]
+// @Security Bearer
+// @Router /api/course/search [post]
+func SearchCourses(c *gin.Context) {
+	var req dto.SearchCoursesReq
+	var resp *dto.SearchCoursesResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	if req.Keyword != "" {
+		go func() {
+			cCopy := c.Copy()
+			if errCopy := provider.Get().SearchHistoryService.LogSearch(cCopy, req.Keyword, req.Department); errCopy != nil {
+				logs.CtxErrorf(cCopy, "[SearchHistoryService] [LogSearch] error: %v", errCopy)
+			}
+		}()
+	}
+
+	resp, err = provider.Get().CourseService.SearchCourses(c, &req)
+	PostProcess(c, &req, resp, err)
+}
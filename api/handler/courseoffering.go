@@ -0,0 +1,123 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCourseOfferings godoc
+// @Summary 获取课程开设
+// @Description 获取课程按学期倒序分组的开设（学期、教师、校区、容量、上课时间）
+// @Tags offering
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Param semester query string false "学期，如 2024-2025-1，为空时返回全部学期"
+// @Success 200 {object} Response[dto.ListCourseOfferingsResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/offerings [get]
+func ListCourseOfferings(c *gin.Context) {
+	var req dto.ListCourseOfferingsReq
+	var resp *dto.ListCourseOfferingsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.ListCourseOfferings(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// CreateCourseOffering godoc
+// @Summary 新增课程开设
+// @Description 管理员为课程新增一个学期的开设，同一学期同一校区只能有一个开设
+// @Tags offering
+// @Accept json
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Param body body dto.CreateCourseOfferingReq true "CreateCourseOfferingReq"
+// @Success 200 {object} Response[dto.CreateCourseOfferingResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/offerings/add [post]
+func CreateCourseOffering(c *gin.Context) {
+	var req dto.CreateCourseOfferingReq
+	var resp *dto.CreateCourseOfferingResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.CreateCourseOffering(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// UpdateCourseOffering godoc
+// @Summary 修改课程开设
+// @Description 管理员修改开设的学期、教师、校区、容量和上课时间，并记录修改前后的快照
+// @Tags offering
+// @Accept json
+// @Produce json
+// @Param offeringId path string true "开设ID"
+// @Param body body dto.UpdateCourseOfferingReq true "UpdateCourseOfferingReq"
+// @Success 200 {object} Response[dto.UpdateCourseOfferingResp]
+// @Security Bearer
+// @Router /api/offering/{offeringId}/update [post]
+func UpdateCourseOffering(c *gin.Context) {
+	var req dto.UpdateCourseOfferingReq
+	var resp *dto.UpdateCourseOfferingResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	req.OfferingID = c.Param(consts.CtxOfferingID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.UpdateCourseOffering(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// DeleteCourseOffering godoc
+// @Summary 删除课程开设
+// @Description 管理员软删除开设，已关联该开设的评论不受影响
+// @Tags offering
+// @Produce json
+// @Param offeringId path string true "开设ID"
+// @Success 200 {object} Response[dto.DeleteCourseOfferingResp]
+// @Security Bearer
+// @Router /api/offering/{offeringId}/delete [post]
+func DeleteCourseOffering(c *gin.Context) {
+	var req dto.DeleteCourseOfferingReq
+	var resp *dto.DeleteCourseOfferingResp
+	var err error
+
+	req.OfferingID = c.Param(consts.CtxOfferingID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	resp, err = provider.Get().CourseService.DeleteCourseOffering(c, &req)
+	PostProcess(c, &req, resp, err)
+}
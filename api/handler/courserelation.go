@@ -0,0 +1,165 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCourseRelations godoc
+// @Summary 获取课程关系
+// @Description 获取课程已生效的先修、后续和等价课程，以及待审核的关系提议（管理员可见全部，普通用户只能看到自己提议的）
+// @Tags relation
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.ListCourseRelationsResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/relations [get]
+func ListCourseRelations(c *gin.Context) {
+	var req dto.ListCourseRelationsReq
+	var resp *dto.ListCourseRelationsResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.ListCourseRelations(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// CreateCourseRelation godoc
+// @Summary 添加课程关系
+// @Description 为课程添加先修、等价或后续关系，管理员添加直接生效，普通用户添加需管理员审核；先修关系不能成环
+// @Tags relation
+// @Accept json
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Param body body dto.CreateCourseRelationReq true "CreateCourseRelationReq"
+// @Success 200 {object} Response[dto.CreateCourseRelationResp]
+// @Security Bearer
+// @Router /api/course/{courseId}/relations/add [post]
+func CreateCourseRelation(c *gin.Context) {
+	var req dto.CreateCourseRelationReq
+	var resp *dto.CreateCourseRelationResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.CreateCourseRelation(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ListPendingCourseRelations godoc
+// @Summary 获取待审核的课程关系
+// @Description 管理员分页获取待审核的课程关系提议，按提议时间倒序
+// @Tags relation
+// @Produce json
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListPendingCourseRelationsResp]
+// @Security Bearer
+// @Router /api/relation/pending [get]
+func ListPendingCourseRelations(c *gin.Context) {
+	var req dto.ListPendingCourseRelationsReq
+	var resp *dto.ListPendingCourseRelationsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.ListPendingCourseRelations(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ApproveCourseRelation godoc
+// @Summary 通过课程关系提议
+// @Description 管理员通过待审核的课程关系提议，通过前会重新检查先修环
+// @Tags relation
+// @Produce json
+// @Param relationId path string true "关系ID"
+// @Success 200 {object} Response[dto.ApproveCourseRelationResp]
+// @Security Bearer
+// @Router /api/relation/{relationId}/approve [post]
+func ApproveCourseRelation(c *gin.Context) {
+	var req dto.ApproveCourseRelationReq
+	var resp *dto.ApproveCourseRelationResp
+	var err error
+
+	req.RelationID = c.Param(consts.CtxRelationID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.ApproveCourseRelation(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RejectCourseRelation godoc
+// @Summary 拒绝课程关系提议
+// @Description 管理员拒绝待审核的课程关系提议
+// @Tags relation
+// @Accept json
+// @Produce json
+// @Param relationId path string true "关系ID"
+// @Param body body dto.RejectCourseRelationReq true "RejectCourseRelationReq"
+// @Success 200 {object} Response[dto.RejectCourseRelationResp]
+// @Security Bearer
+// @Router /api/relation/{relationId}/reject [post]
+func RejectCourseRelation(c *gin.Context) {
+	var req dto.RejectCourseRelationReq
+	var resp *dto.RejectCourseRelationResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.RelationID = c.Param(consts.CtxRelationID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.RejectCourseRelation(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// DeleteCourseRelation godoc
+// @Summary 删除课程关系
+// @Description 管理员删除任意课程关系，普通用户只能撤回自己待审核的提议
+// @Tags relation
+// @Produce json
+// @Param relationId path string true "关系ID"
+// @Success 200 {object} Response[dto.DeleteCourseRelationResp]
+// @Security Bearer
+// @Router /api/relation/{relationId}/delete [post]
+func DeleteCourseRelation(c *gin.Context) {
+	var req dto.DeleteCourseRelationReq
+	var resp *dto.DeleteCourseRelationResp
+	var err error
+
+	req.RelationID = c.Param(consts.CtxRelationID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().CourseService.DeleteCourseRelation(c, &req)
+	PostProcess(c, &req, resp, err)
+}
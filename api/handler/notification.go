@@ -0,0 +1,133 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// ListNotifications godoc
+// @Summary 获取我的通知
+// @Description 分页获取我的站内通知，返回未读通知总数
+// @Tags notification
+// @Produce json
+// @Param unreadOnly query bool false "只看未读"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListNotificationsResp]
+// @Security Bearer
+// @Router /api/notification/list [get]
+func ListNotifications(c *gin.Context) {
+	var req dto.ListNotificationsReq
+	var resp *dto.ListNotificationsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().NotificationService.ListNotifications(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// MarkNotificationRead godoc
+// @Summary 标记通知已读
+// @Description 将一条通知标记为已读，返回最新未读数
+// @Tags notification
+// @Produce json
+// @Param notificationId path string true "通知ID"
+// @Success 200 {object} Response[dto.MarkNotificationReadResp]
+// @Security Bearer
+// @Router /api/notification/{notificationId}/read [post]
+func MarkNotificationRead(c *gin.Context) {
+	var req dto.MarkNotificationReadReq
+	var resp *dto.MarkNotificationReadResp
+	var err error
+
+	req.NotificationID = c.Param(consts.CtxNotificationID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().NotificationService.MarkNotificationRead(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary 全部标记已读
+// @Description 将我的所有未读通知标记为已读
+// @Tags notification
+// @Produce json
+// @Success 200 {object} Response[dto.MarkAllNotificationsReadResp]
+// @Security Bearer
+// @Router /api/notification/read-all [post]
+func MarkAllNotificationsRead(c *gin.Context) {
+	var req dto.MarkAllNotificationsReadReq
+	var resp *dto.MarkAllNotificationsReadResp
+	var err error
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().NotificationService.MarkAllNotificationsRead(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// GetNotificationSetting godoc
+// @Summary 获取通知偏好
+// @Description 获取我屏蔽的通知类型
+// @Tags notification
+// @Produce json
+// @Success 200 {object} Response[dto.GetNotificationSettingResp]
+// @Security Bearer
+// @Router /api/notification/setting [get]
+func GetNotificationSetting(c *gin.Context) {
+	var req dto.GetNotificationSettingReq
+	var resp *dto.GetNotificationSettingResp
+	var err error
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().NotificationService.GetNotificationSetting(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// UpdateNotificationSetting godoc
+// @Summary 更新通知偏好
+// @Description 设置需要屏蔽的通知类型，覆盖原有设置
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateNotificationSettingReq true "屏蔽的通知类型"
+// @Success 200 {object} Response[dto.UpdateNotificationSettingResp]
+// @Security Bearer
+// @Router /api/notification/setting [post]
+func UpdateNotificationSetting(c *gin.Context) {
+	var req dto.UpdateNotificationSettingReq
+	var resp *dto.UpdateNotificationSettingResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().NotificationService.UpdateNotificationSetting(c, &req)
+	PostProcess(c, &req, resp, err)
+}
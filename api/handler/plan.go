@@ -0,0 +1,133 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPlanTimetable godoc
+// @Summary 获取选课计划课表
+// @Description 获取当前用户某学期选课计划的开设、按星期和节次排列的周课表以及时间冲突
+// @Tags plan
+// @Produce json
+// @Param semester query string false "学期，如 2024-2025-1，为空时为当前学期"
+// @Success 200 {object} Response[dto.GetPlanTimetableResp]
+// @Security Bearer
+// @Router /api/plan/timetable [get]
+func GetPlanTimetable(c *gin.Context) {
+	var req dto.GetPlanTimetableReq
+	var resp *dto.GetPlanTimetableResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().PlanService.GetPlanTimetable(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// AddPlanOffering godoc
+// @Summary 加入选课计划
+// @Description 将开设加入其所在学期的选课计划，返回与计划中其他开设的时间冲突，冲突不阻止加入
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param body body dto.AddPlanOfferingReq true "AddPlanOfferingReq"
+// @Success 200 {object} Response[dto.AddPlanOfferingResp]
+// @Security Bearer
+// @Router /api/plan/add [post]
+func AddPlanOffering(c *gin.Context) {
+	var req dto.AddPlanOfferingReq
+	var resp *dto.AddPlanOfferingResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().PlanService.AddPlanOffering(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RemovePlanOffering godoc
+// @Summary 移出选课计划
+// @Description 将开设从其所在学期的选课计划中移除
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param body body dto.RemovePlanOfferingReq true "RemovePlanOfferingReq"
+// @Success 200 {object} Response[dto.RemovePlanOfferingResp]
+// @Security Bearer
+// @Router /api/plan/remove [post]
+func RemovePlanOffering(c *gin.Context) {
+	var req dto.RemovePlanOfferingReq
+	var resp *dto.RemovePlanOfferingResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().PlanService.RemovePlanOffering(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ExportPlanICS godoc
+// @Summary 导出选课计划日历
+// @Description 将选课计划展开为每次上课的日历事件，导出为 iCalendar 文件，可导入手机或电脑日历
+// @Tags plan
+// @Produce text/calendar
+// @Param semester query string false "学期，如 2024-2025-1，为空时为当前学期"
+// @Success 200 {file} file
+// @Security Bearer
+// @Router /api/plan/ics [get]
+func ExportPlanICS(c *gin.Context) {
+	var req dto.ExportPlanICSReq
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+
+	filename := "plan.ics"
+	if req.Semester != "" {
+		filename = "plan-" + req.Semester + ".ics"
+	}
+	w := &exportWriter{c: c, contentType: "text/calendar; charset=utf-8", filename: filename}
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+	if err = provider.Get().PlanService.ExportPlanICS(c, &req, w); err != nil {
+		// 已开始写出时无法再返回错误响应，只记录日志
+		if w.started {
+			logs.CtxErrorf(c, "[ExportPlanICS] stream interrupted: %v", err)
+			return
+		}
+		PostProcess(c, &req, nil, err)
+	}
+}
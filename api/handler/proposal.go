@@ -27,7 +27,8 @@ import (
 
 // CreateProposal godoc
 // @Summary 新增提案
-// @Description 创建一个新的提案
+// @Description 创建一个新的提案；传入 courseId 与 change 时为修改现有课程的提案（字段级修改，retired 表示课程不再开设）
+// @Description 传入 teacherId 与 teacherChange 时为更正教师姓名、职称或院系的提案
 // @Tags proposal
 // @Accept json
 // @Param req body dto.CreateProposalReq true "创建提案的请求参数"
@@ -105,7 +106,8 @@ func FilterProposals(c *gin.Context) {
 
 // GetProposal 获取提案详情
 // @Summary 获取提案详情
-// @Description 根据提案ID查询提案完整信息
+// @Description 根据提案ID查询提案完整信息，comments 为讨论列表（回复挂在顶层评论的 replies 下）
+// @Description 内部备注仅非提案作者的管理员可见
 // @Tags proposal
 // @Produce json
 // @Param proposalId path string true "提案ID"
@@ -127,6 +129,8 @@ func GetProposal(c *gin.Context) {
 // ApproveProposal godoc
 // @Summary 审批提案
 // @Description 管理员通过提案并创建正式课程，可传入管理员最终确认的课程信息 finalCourse（不传则用提案原始课程），并按业务规则结算提案创建者的贡献值
+// @Description 修改提案通过时将修改应用到现有课程，可传入管理员最终确认的修改 finalChange（不传则用提案原始修改）
+// @Description 教师更正提案通过时将更正应用到教师，可传入管理员最终确认的修改 finalTeacherChange
 // @Tags proposal
 // @Accept json
 // @Produce json
@@ -154,7 +158,7 @@ func ApproveProposal(c *gin.Context) {
 
 // RevokeProposal godoc
 // @Summary 撤回提案操作
-// @Description 管理员撤回提案的通过/拒绝操作；撤回审批通过时同步删除关联课程并扣回已结算的贡献值
+// @Description 管理员撤回提案的通过/拒绝操作；撤回审批通过时同步删除关联课程（修改提案与教师更正提案则恢复被修改的字段）并扣回已结算的贡献值
 // @Tags proposal
 // @Accept json
 // @Param proposalId path string true "提案ID"
@@ -181,11 +185,11 @@ func RevokeProposal(c *gin.Context) {
 
 // RejectProposal godoc
 // @Summary 拒绝提案
-// @Description 管理员操作：将状态为 pending（待审核）的提案变更为 rejected（已拒绝）
+// @Description 管理员操作：将状态为 pending（待审核）或 needsInfo（待补充信息）的提案变更为 rejected（已拒绝）
 // @Description 使用场景：课程提案审核流程中，管理员认为提案不符合要求，驳回该提案
 // @Description 注意事项：
 // @Description - 仅管理员可操作（需先调用 /api/auth/is_admin 确认权限）
-// @Description - 仅状态为 pending 的提案可以拒绝，已 approved/rejected 的提案无法再次操作
+// @Description - 仅状态为 pending/needsInfo 的提案可以拒绝，已 approved/rejected 的提案无法再次操作
 // @Description - 拒绝后不会创建课程记录，仅更新提案状态
 // @Tags proposal
 // @Accept json
@@ -344,3 +348,81 @@ func GetMyProposals(c *gin.Context) {
 	resp, err = provider.Get().ProposalService.GetMyProposals(c, &req)
 	PostProcess(c, &req, resp, err)
 }
+
+// CreateProposalComment godoc
+// @Summary 发表提案讨论
+// @Description 在提案下发表讨论或回复（parentId 为回复的评论ID），回复统一挂在顶层评论下
+// @Description 管理员可发表 internal 内部备注，提案作者不可见；内部备注下的回复同样为内部备注
+// @Description 提案处于 needsInfo 状态时，作者发表讨论后提案重新进入待审核
+// @Tags proposal
+// @Accept json
+// @Produce json
+// @Param proposalId path string true "提案ID"
+// @Param body body dto.CreateProposalCommentReq true "CreateProposalCommentReq"
+// @Success 200 {object} Response[dto.CreateProposalCommentResp]
+// @Security Bearer
+// @Router /api/proposal/{proposalId}/comment [post]
+func CreateProposalComment(c *gin.Context) {
+	var req dto.CreateProposalCommentReq
+	var resp *dto.CreateProposalCommentResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.ProposalID = c.Param(consts.CtxProposalID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().ProposalService.CreateProposalComment(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// DeleteProposalComment godoc
+// @Summary 删除提案讨论
+// @Description 发表者或管理员删除提案讨论，删除顶层评论时其回复一并隐藏
+// @Tags proposal
+// @Produce json
+// @Param commentId path string true "讨论ID"
+// @Success 200 {object} Response[dto.DeleteProposalCommentResp]
+// @Security Bearer
+// @Router /api/proposal/comment/{commentId}/delete [post]
+func DeleteProposalComment(c *gin.Context) {
+	var req dto.DeleteProposalCommentReq
+	var resp *dto.DeleteProposalCommentResp
+	var err error
+
+	req.CommentID = c.Param(consts.CtxCommentID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().ProposalService.DeleteProposalComment(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RequestProposalInfo godoc
+// @Summary 要求补充提案信息
+// @Description 管理员操作：将 pending 的提案变更为 needsInfo（待补充信息），问题以讨论形式发布并通知作者
+// @Description 作者更新提案或发表讨论后，提案重新进入待审核；needsInfo 的提案仍可直接通过或拒绝
+// @Tags proposal
+// @Accept json
+// @Produce json
+// @Param proposalId path string true "提案ID"
+// @Param body body dto.RequestProposalInfoReq true "RequestProposalInfoReq"
+// @Success 200 {object} Response[dto.RequestProposalInfoResp]
+// @Security Bearer
+// @Router /api/proposal/{proposalId}/needinfo [post]
+func RequestProposalInfo(c *gin.Context) {
+	var req dto.RequestProposalInfoReq
+	var resp *dto.RequestProposalInfoResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.ProposalID = c.Param(consts.CtxProposalID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().ProposalService.RequestProposalInfo(c, &req)
+	PostProcess(c, &req, resp, err)
+}
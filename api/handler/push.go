@@ -0,0 +1,67 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSubscribeSetting godoc
+// @Summary 获取订阅消息设置
+// @Description 获取可订阅的消息类型、模板ID及我的剩余接收次数，供小程序调用 wx.requestSubscribeMessage
+// @Tags push
+// @Produce json
+// @Success 200 {object} Response[dto.GetSubscribeSettingResp]
+// @Security Bearer
+// @Router /api/push/subscribe [get]
+func GetSubscribeSetting(c *gin.Context) {
+	var req dto.GetSubscribeSettingReq
+	var resp *dto.GetSubscribeSettingResp
+	var err error
+
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().PushService.GetSubscribeSetting(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RecordSubscribe godoc
+// @Summary 上报订阅消息授权
+// @Description 上报用户在 wx.requestSubscribeMessage 中同意的消息类型，每次同意可接收一条对应消息
+// @Tags push
+// @Accept json
+// @Produce json
+// @Param body body dto.RecordSubscribeReq true "同意的消息类型"
+// @Success 200 {object} Response[dto.RecordSubscribeResp]
+// @Security Bearer
+// @Router /api/push/subscribe [post]
+func RecordSubscribe(c *gin.Context) {
+	var req dto.RecordSubscribeReq
+	var resp *dto.RecordSubscribeResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().PushService.RecordSubscribe(c, &req)
+	PostProcess(c, &req, resp, err)
+}
@@ -24,7 +24,7 @@ import (
 
 // CreateTeacher godoc
 // @Summary 新建教师
-// @Description 新建教师
+// @Description 管理员新建教师，同名教师的院系（院系未知时为职称）不能重复
 // @Tags teacher
 // @Accept json
 // @Produce json
@@ -33,18 +33,70 @@ import (
 // @Security Bearer
 // @Router /api/teacher/add [post]
 func CreateTeacher(c *gin.Context) {
-	var req *dto.CreateTeacherReq
+	var req dto.CreateTeacherReq
 	var resp *dto.CreateTeacherResp
 	var err error
 
-	if err = c.ShouldBind(&req); err != nil {
-		PostProcess(c, req, resp, err)
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
 		return
 	}
 	c.Set(consts.CtxUserID, token.GetUserID(c))
 
-	resp, err = provider.Get().TeacherService.CreateTeacher(c, req)
-	PostProcess(c, req, resp, err)
+	resp, err = provider.Get().TeacherService.CreateTeacher(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// UpdateTeacher godoc
+// @Summary 修改教师
+// @Description 管理员修改教师的姓名、职称与院系，并记录修改前后的快照
+// @Tags teacher
+// @Accept json
+// @Produce json
+// @Param teacherId path string true "教师ID"
+// @Param body body dto.UpdateTeacherReq true "UpdateTeacherReq"
+// @Success 200 {object} Response[dto.UpdateTeacherResp]
+// @Security Bearer
+// @Router /api/teacher/{teacherId}/update [post]
+func UpdateTeacher(c *gin.Context) {
+	var req dto.UpdateTeacherReq
+	var resp *dto.UpdateTeacherResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.TeacherID = c.Param(consts.CtxTeacherID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().TeacherService.UpdateTeacher(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// MergeTeachers godoc
+// @Summary 合并重复教师
+// @Description 管理员将重复的教师合并到保留教师，课程与开设改由保留教师授课，dryRun 为 true 时只返回影响预览
+// @Tags teacher
+// @Accept json
+// @Produce json
+// @Param body body dto.MergeTeachersReq true "MergeTeachersReq"
+// @Success 200 {object} Response[dto.MergeTeachersResp]
+// @Security Bearer
+// @Router /api/teacher/merge [post]
+func MergeTeachers(c *gin.Context) {
+	var req dto.MergeTeachersReq
+	var resp *dto.MergeTeachersResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().TeacherService.MergeTeachers(c, &req)
+	PostProcess(c, &req, resp, err)
 }
 
 // GetTeacherSuggestions godoc
@@ -70,3 +122,30 @@ func GetTeacherSuggestions(c *gin.Context) {
 	resp, err = provider.Get().TeacherService.GetTeacherSuggestions(c, req)
 	PostProcess(c, req, resp, err)
 }
+
+// GetTeacher godoc
+// @Summary 获取教师主页
+// @Description 返回教师信息、授课的全部课程，以及这些课程评论的数量、标签分布、评分汇总和分页的最近评论
+// @Tags teacher
+// @Produce json
+// @Param teacherId path string true "教师ID"
+// @Param page query int false "最近评论页码"
+// @Param pageSize query int false "最近评论每页数量"
+// @Success 200 {object} Response[dto.GetTeacherResp]
+// @Security Bearer
+// @Router /api/teacher/{teacherId} [get]
+func GetTeacher(c *gin.Context) {
+	var req dto.GetTeacherReq
+	var resp *dto.GetTeacherResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.TeacherID = c.Param(consts.CtxTeacherID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().TeacherService.GetTeacher(c, &req)
+	PostProcess(c, &req, resp, err)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrendingCourses godoc
+// @Summary 获取热门课程
+// @Description 按近三天吐槽、点赞和浏览的时间衰减热度返回热门课程，可按院系筛选
+// @Tags trending
+// @Produce json
+// @Param department query string false "院系名称，为空时不区分院系"
+// @Param limit query int false "数量，默认 10，最多 50"
+// @Success 200 {object} Response[dto.GetTrendingCoursesResp]
+// @Security Bearer
+// @Router /api/trending/courses [get]
+func GetTrendingCourses(c *gin.Context) {
+	var req dto.GetTrendingCoursesReq
+	var resp *dto.GetTrendingCoursesResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().TrendingService.GetTrendingCourses(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// GetTrendingSearches godoc
+// @Summary 获取热门搜索
+// @Description 按近三天全站搜索的时间衰减热度返回热门关键词，可按搜索时筛选的院系过滤
+// @Tags trending
+// @Produce json
+// @Param department query string false "院系名称，为空时不区分院系"
+// @Param limit query int false "数量，默认 10，最多 50"
+// @Success 200 {object} Response[dto.GetTrendingSearchesResp]
+// @Security Bearer
+// @Router /api/trending/searches [get]
+func GetTrendingSearches(c *gin.Context) {
+	var req dto.GetTrendingSearchesReq
+	var resp *dto.GetTrendingSearchesResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().TrendingService.GetTrendingSearches(c, &req)
+	PostProcess(c, &req, resp, err)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// AddWatch godoc
+// @Summary 关注课程
+// @Description 将课程加入我的关注列表，已关注时不做改动
+// @Tags watchlist
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.AddWatchResp]
+// @Security Bearer
+// @Router /api/watchlist/{courseId}/add [post]
+func AddWatch(c *gin.Context) {
+	var req dto.AddWatchReq
+	var resp *dto.AddWatchResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WatchlistService.AddWatch(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RemoveWatch godoc
+// @Summary 取消关注课程
+// @Description 将课程移出我的关注列表
+// @Tags watchlist
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.RemoveWatchResp]
+// @Security Bearer
+// @Router /api/watchlist/{courseId}/remove [post]
+func RemoveWatch(c *gin.Context) {
+	var req dto.RemoveWatchReq
+	var resp *dto.RemoveWatchResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WatchlistService.RemoveWatch(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// MarkWatchSeen godoc
+// @Summary 标记关注课程已查看
+// @Description 更新关注课程的最后查看时间，此后该课程的未读评论数重新计数
+// @Tags watchlist
+// @Produce json
+// @Param courseId path string true "课程ID"
+// @Success 200 {object} Response[dto.MarkWatchSeenResp]
+// @Security Bearer
+// @Router /api/watchlist/{courseId}/seen [post]
+func MarkWatchSeen(c *gin.Context) {
+	var req dto.MarkWatchSeenReq
+	var resp *dto.MarkWatchSeenResp
+	var err error
+
+	req.CourseID = c.Param(consts.CtxCourseID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WatchlistService.MarkWatchSeen(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ListWatchlist godoc
+// @Summary 获取我的关注课程
+// @Description 分页获取我关注的课程及其当前信息，并返回每门课程自上次查看以来的新增评论数
+// @Tags watchlist
+// @Produce json
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListWatchlistResp]
+// @Security Bearer
+// @Router /api/watchlist/list [get]
+func ListWatchlist(c *gin.Context) {
+	var req dto.ListWatchlistReq
+	var resp *dto.ListWatchlistResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WatchlistService.ListWatchlist(c, &req)
+	PostProcess(c, &req, resp, err)
+}
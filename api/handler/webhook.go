@@ -0,0 +1,196 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/token"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhook godoc
+// @Summary 创建webhook
+// @Description 管理员创建事件订阅，签名密钥仅在本次响应中返回
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateWebhookReq true "webhook信息"
+// @Success 200 {object} Response[dto.CreateWebhookResp]
+// @Security Bearer
+// @Router /api/webhook/add [post]
+func CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookReq
+	var resp *dto.CreateWebhookResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.CreateWebhook(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ListWebhooks godoc
+// @Summary 获取webhook列表
+// @Description 管理员分页获取事件订阅
+// @Tags webhook
+// @Produce json
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListWebhooksResp]
+// @Security Bearer
+// @Router /api/webhook/list [get]
+func ListWebhooks(c *gin.Context) {
+	var req dto.ListWebhooksReq
+	var resp *dto.ListWebhooksResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.ListWebhooks(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// UpdateWebhook godoc
+// @Summary 修改webhook
+// @Description 管理员修改事件订阅的地址、事件、描述或启用状态，未传字段保持不变
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "webhook ID"
+// @Param body body dto.UpdateWebhookReq true "修改内容"
+// @Success 200 {object} Response[dto.UpdateWebhookResp]
+// @Security Bearer
+// @Router /api/webhook/{webhookId}/update [post]
+func UpdateWebhook(c *gin.Context) {
+	var req dto.UpdateWebhookReq
+	var resp *dto.UpdateWebhookResp
+	var err error
+
+	if err = c.ShouldBindJSON(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	req.WebhookID = c.Param(consts.CtxWebhookID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.UpdateWebhook(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// DeleteWebhook godoc
+// @Summary 删除webhook
+// @Description 管理员删除事件订阅，未完成的投递将进入死信列表
+// @Tags webhook
+// @Produce json
+// @Param webhookId path string true "webhook ID"
+// @Success 200 {object} Response[dto.DeleteWebhookResp]
+// @Security Bearer
+// @Router /api/webhook/{webhookId}/delete [post]
+func DeleteWebhook(c *gin.Context) {
+	var req dto.DeleteWebhookReq
+	var resp *dto.DeleteWebhookResp
+	var err error
+
+	req.WebhookID = c.Param(consts.CtxWebhookID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.DeleteWebhook(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary 获取事件投递记录
+// @Description 管理员分页获取事件投递记录，status=dead 为死信列表
+// @Tags webhook
+// @Produce json
+// @Param webhookId query string false "webhook ID"
+// @Param status query string false "投递状态 pending/succeeded/dead"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListWebhookDeliveriesResp]
+// @Security Bearer
+// @Router /api/webhook/delivery/list [get]
+func ListWebhookDeliveries(c *gin.Context) {
+	var req dto.ListWebhookDeliveriesReq
+	var resp *dto.ListWebhookDeliveriesResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.ListWebhookDeliveries(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// RedeliverWebhook godoc
+// @Summary 重新投递
+// @Description 管理员将死信列表中的投递重新放回队列
+// @Tags webhook
+// @Produce json
+// @Param deliveryId path string true "投递ID"
+// @Success 200 {object} Response[dto.RedeliverWebhookResp]
+// @Security Bearer
+// @Router /api/webhook/delivery/{deliveryId}/redeliver [post]
+func RedeliverWebhook(c *gin.Context) {
+	var req dto.RedeliverWebhookReq
+	var resp *dto.RedeliverWebhookResp
+	var err error
+
+	req.DeliveryID = c.Param(consts.CtxDeliveryID)
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.RedeliverWebhook(c, &req)
+	PostProcess(c, &req, resp, err)
+}
+
+// ListWebhookAttempts godoc
+// @Summary 获取投递尝试日志
+// @Description 管理员分页获取每次投递尝试的状态码、耗时与错误信息
+// @Tags webhook
+// @Produce json
+// @Param webhookId query string false "webhook ID"
+// @Param deliveryId query string false "投递ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} Response[dto.ListWebhookAttemptsResp]
+// @Security Bearer
+// @Router /api/webhook/attempt/list [get]
+func ListWebhookAttempts(c *gin.Context) {
+	var req dto.ListWebhookAttemptsReq
+	var resp *dto.ListWebhookAttemptsResp
+	var err error
+
+	if err = c.ShouldBindQuery(&req); err != nil {
+		PostProcess(c, &req, nil, err)
+		return
+	}
+	c.Set(consts.CtxUserID, token.GetUserID(c))
+
+	resp, err = provider.Get().WebhookService.ListWebhookAttempts(c, &req)
+	PostProcess(c, &req, resp, err)
+}
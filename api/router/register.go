@@ -56,16 +56,35 @@ func SetupRoutes() *gin.Engine {
 	// CourseApi
 	courseGroup := router.Group("/api/course")
 	{
-		courseGroup.GET("/:courseId", handler.GetCourse)              // 精确搜索某个课程
-		courseGroup.GET("/departments", handler.GetCourseDepartments) // 获得某课程的“所属部门”信息
-		courseGroup.GET("/categories", handler.GetCourseCategories)   // 获得某课程的“课程类型”信息
-		courseGroup.GET("/campuses", handler.GetCourseCampuses)       // 获得某课程的“开设校区”信息
+		courseGroup.GET("/:courseId", handler.GetCourse)                           // 精确搜索某个课程
+		courseGroup.GET("/:courseId/stats", handler.GetCourseStats)                // 课程详情统计
+		courseGroup.GET("/recommend", handler.GetCourseRecommendations)            // 个性化课程推荐
+		courseGroup.GET("/compare", handler.CompareCourses)                        // 课程对比
+		courseGroup.GET("/:courseId/offerings", handler.ListCourseOfferings)       // 按学期分组的课程开设
+		courseGroup.POST("/:courseId/offerings/add", handler.CreateCourseOffering) // 管理员新增课程开设
+		courseGroup.GET("/:courseId/relations", handler.ListCourseRelations)       // 先修、后续和等价课程
+		courseGroup.POST("/:courseId/relations/add", handler.CreateCourseRelation) // 添加或提议课程关系
+		courseGroup.GET("/departments", handler.GetCourseDepartments)              // 获得某课程的“所属部门”信息
+		courseGroup.GET("/categories", handler.GetCourseCategories)                // 获得某课程的“课程类型”信息
+		courseGroup.GET("/campuses", handler.GetCourseCampuses)                    // 获得某课程的“开设校区”信息
+		courseGroup.POST("/add", handler.CreateCourse)                             // 管理员创建课程
+		courseGroup.POST("/:courseId/update", handler.UpdateCourse)                // 管理员修改课程
+		courseGroup.POST("/:courseId/delete", handler.DeleteCourse)                // 管理员删除课程
+		courseGroup.POST("/:courseId/restore", handler.RestoreCourse)              // 管理员恢复课程
+		courseGroup.POST("/merge", handler.MergeCourses)                           // 管理员合并重复课程
+		courseGroup.POST("/import", handler.ImportCourses)                         // 管理员批量导入课程目录
+		courseGroup.GET("/export", handler.ExportCourses)                          // 管理员导出课程目录
+		courseGroup.POST("/search", handler.SearchCourses)                         // 组合条件搜索课程
 	}
 
 	// TeacherApi
 	teacherGroup := router.Group("/api/teacher")
 	{
-		teacherGroup.GET("/suggest", handler.GetTeacherSuggestions) // 获取教师搜索建议
+		teacherGroup.GET("/suggest", handler.GetTeacherSuggestions)    // 获取教师搜索建议
+		teacherGroup.POST("/add", handler.CreateTeacher)               // 管理员新建教师
+		teacherGroup.POST("/merge", handler.MergeTeachers)             // 管理员合并重复教师
+		teacherGroup.GET("/:teacherId", handler.GetTeacher)            // 教师主页
+		teacherGroup.POST("/:teacherId/update", handler.UpdateTeacher) // 管理员修改教师
 	}
 
 	// ProposalApi
@@ -83,6 +102,9 @@ func SetupRoutes() *gin.Engine {
 		proposalGroup.POST("/:proposalId/approve", handler.ApproveProposal)
 		proposalGroup.POST("/:proposalId/revoke", handler.RevokeProposal)
 		proposalGroup.POST("/:proposalId/reject", handler.RejectProposal)
+		proposalGroup.POST("/:proposalId/needinfo", handler.RequestProposalInfo)        // 要求补充信息
+		proposalGroup.POST("/:proposalId/comment", handler.CreateProposalComment)       // 发表讨论或回复
+		proposalGroup.POST("/comment/:commentId/delete", handler.DeleteProposalComment) // 删除讨论
 	}
 
 	// ChangeLogApi
@@ -93,5 +115,75 @@ func SetupRoutes() *gin.Engine {
 		changeLogGroup.GET("/list", handler.ListChangeLogs)
 	}
 
+	// OfferingApi
+	offeringGroup := router.Group("/api/offering")
+	{
+		offeringGroup.POST("/:offeringId/update", handler.UpdateCourseOffering) // 管理员修改课程开设
+		offeringGroup.POST("/:offeringId/delete", handler.DeleteCourseOffering) // 管理员删除课程开设
+	}
+
+	// RelationApi
+	relationGroup := router.Group("/api/relation")
+	{
+		relationGroup.GET("/pending", handler.ListPendingCourseRelations)         // 管理员查看待审核的关系提议
+		relationGroup.POST("/:relationId/approve", handler.ApproveCourseRelation) // 管理员通过关系提议
+		relationGroup.POST("/:relationId/reject", handler.RejectCourseRelation)   // 管理员拒绝关系提议
+		relationGroup.POST("/:relationId/delete", handler.DeleteCourseRelation)   // 删除关系或撤回提议
+	}
+
+	// PlanApi
+	planGroup := router.Group("/api/plan")
+	{
+		planGroup.GET("/timetable", handler.GetPlanTimetable) // 选课计划的周课表与时间冲突
+		planGroup.POST("/add", handler.AddPlanOffering)       // 加入选课计划
+		planGroup.POST("/remove", handler.RemovePlanOffering) // 移出选课计划
+		planGroup.GET("/ics", handler.ExportPlanICS)          // 导出选课计划日历
+	}
+
+	// TrendingApi
+	trendingGroup := router.Group("/api/trending")
+	{
+		trendingGroup.GET("/courses", handler.GetTrendingCourses)   // 热门课程
+		trendingGroup.GET("/searches", handler.GetTrendingSearches) // 热门搜索
+	}
+
+	// WatchlistApi
+	watchlistGroup := router.Group("/api/watchlist")
+	{
+		watchlistGroup.GET("/list", handler.ListWatchlist)            // 我的关注课程及未读评论数
+		watchlistGroup.POST("/:courseId/add", handler.AddWatch)       // 关注课程
+		watchlistGroup.POST("/:courseId/remove", handler.RemoveWatch) // 取消关注课程
+		watchlistGroup.POST("/:courseId/seen", handler.MarkWatchSeen) // 标记已查看
+	}
+
+	// NotificationApi
+	notificationGroup := router.Group("/api/notification")
+	{
+		notificationGroup.GET("/list", handler.ListNotifications)                     // 我的通知及未读数
+		notificationGroup.POST("/:notificationId/read", handler.MarkNotificationRead) // 标记单条已读
+		notificationGroup.POST("/read-all", handler.MarkAllNotificationsRead)         // 全部标记已读
+		notificationGroup.GET("/setting", handler.GetNotificationSetting)             // 获取屏蔽的通知类型
+		notificationGroup.POST("/setting", handler.UpdateNotificationSetting)         // 设置屏蔽的通知类型
+	}
+
+	// PushApi
+	pushGroup := router.Group("/api/push")
+	{
+		pushGroup.GET("/subscribe", handler.GetSubscribeSetting) // 可订阅的消息模板及剩余接收次数
+		pushGroup.POST("/subscribe", handler.RecordSubscribe)    // 上报订阅消息授权
+	}
+
+	// WebhookApi
+	webhookGroup := router.Group("/api/webhook")
+	{
+		webhookGroup.POST("/add", handler.CreateWebhook)                               // 创建webhook
+		webhookGroup.GET("/list", handler.ListWebhooks)                                // webhook列表
+		webhookGroup.POST("/:webhookId/update", handler.UpdateWebhook)                 // 修改webhook
+		webhookGroup.POST("/:webhookId/delete", handler.DeleteWebhook)                 // 删除webhook
+		webhookGroup.GET("/delivery/list", handler.ListWebhookDeliveries)              // 投递记录，status=dead 为死信列表
+		webhookGroup.POST("/delivery/:deliveryId/redeliver", handler.RedeliverWebhook) // 死信重新投递
+		webhookGroup.GET("/attempt/list", handler.ListWebhookAttempts)                 // 投递尝试日志
+	}
+
 	return router
 }
@@ -46,6 +46,8 @@ func (a *ChangeLogAssembler) ToChangeLogDB(ctx context.Context, vo *dto.ChangeLo
 		UpdateSource: vo.UpdateSource,
 		ProposalID:   vo.ProposalID,
 		UserID:       vo.UserID,
+		Before:       vo.Before,
+		After:        vo.After,
 		UpdatedAt:    vo.UpdatedAt,
 	}, nil
 }
@@ -69,11 +69,12 @@ func (a *CommentAssembler) ToCommentVO(ctx context.Context, db *model.Comment, u
 	}
 
 	return &dto.CommentVO{
-		ID:       db.ID,
-		Content:  db.Content,
-		Tags:     db.Tags,
-		UserID:   db.UserID,
-		CourseID: db.CourseID,
+		ID:         db.ID,
+		Content:    db.Content,
+		Tags:       db.Tags,
+		UserID:     db.UserID,
+		CourseID:   db.CourseID,
+		OfferingID: db.OfferingID,
 		LikeVO: &dto.LikeVO{
 			Like:    active,
 			LikeCnt: likeCnt,
@@ -90,14 +91,15 @@ func (a *CommentAssembler) ToCommentDB(ctx context.Context, vo *dto.CommentVO) (
 	}
 
 	return &model.Comment{
-		ID:        vo.ID,
-		Content:   vo.Content,
-		Tags:      vo.Tags,
-		UserID:    vo.UserID,
-		CourseID:  vo.CourseID,
-		CreatedAt: vo.CreatedAt,
-		UpdatedAt: vo.UpdatedAt,
-		Deleted:   false, // 默认为未删除
+		ID:         vo.ID,
+		Content:    vo.Content,
+		Tags:       vo.Tags,
+		UserID:     vo.UserID,
+		CourseID:   vo.CourseID,
+		OfferingID: vo.OfferingID,
+		CreatedAt:  vo.CreatedAt,
+		UpdatedAt:  vo.UpdatedAt,
+		Deleted:    false, // 默认为未删除
 	}, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/homonym"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
 	"github.com/Boyuan-IT-Club/go-kit/logs"
 	"github.com/google/wire"
@@ -45,9 +46,11 @@ type ICourseAssembler interface {
 }
 
 type CourseAssembler struct {
-	CommentRepo *repo.CommentRepo
-	TeacherRepo *repo.TeacherRepo
-	CourseRepo  *repo.CourseRepo
+	CommentRepo             *repo.CommentRepo
+	TeacherRepo             *repo.TeacherRepo
+	CourseRepo              *repo.CourseRepo
+	CourseOfferingRepo      *repo.CourseOfferingRepo
+	CourseOfferingAssembler *CourseOfferingAssembler
 }
 
 var CourseAssemblerSet = wire.NewSet(
@@ -57,6 +60,17 @@ var CourseAssemblerSet = wire.NewSet(
 
 // ToCourseVO 单个CourseDB转CourseVO (DB to VO)
 func (a *CourseAssembler) ToCourseVO(ctx context.Context, db *model.Course) (*dto.CourseVO, error) {
+	// 按学期分组的开设
+	offerings, err := a.CourseOfferingRepo.FindManyByCourseID(ctx, db.ID, "")
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindManyByCourseID] error: %v", err)
+		return a.toCourseVO(ctx, db, nil), nil
+	}
+	return a.toCourseVO(ctx, db, a.CourseOfferingAssembler.ToSemesterOfferingsVOArray(ctx, offerings)), nil
+}
+
+// toCourseVO 用已加载的开设组装CourseVO
+func (a *CourseAssembler) toCourseVO(ctx context.Context, db *model.Course, offerings []*dto.SemesterOfferingsVO) *dto.CourseVO {
 	// 获得课程前三多的tag
 	tagCountChan := make(chan map[string]int64, 1)
 	go func() {
@@ -119,7 +133,8 @@ func (a *CourseAssembler) ToCourseVO(ctx context.Context, db *model.Course) (*dt
 		Department: mapping.Data.GetDepartmentNameByID(db.Department),
 		Teachers:   teacherVOs,
 		TagCount:   tagCount,
-	}, nil
+		Offerings:  offerings,
+	}
 }
 
 // ToCourseDB 单个CourseVO转CourseDB (VO to DB)(会执行自动注册)
@@ -148,39 +163,16 @@ func (a *CourseAssembler) ToCourseDB(ctx context.Context, vo *dto.CourseVO) (*mo
 	if categoryID == 0 {
 		categoryID = mapping.Data.AutoRegisterCategory(vo.Category)
 	}
-	// 处理教师 - 自动创建不存在的教师
+	// 处理教师 - 按姓名与院系、职称匹配已有教师，自动创建不存在的教师
 	var teacherIDs []string
 	for _, teacher := range vo.Teachers {
-		// 检查教师是否已存在
-		existingTeacherID, err := a.TeacherRepo.GetIDByName(ctx, teacher.Name)
+		teacherID, err := a.resolveTeacherID(ctx, teacher)
 		if err != nil {
-			logs.CtxErrorf(ctx, "[TeacherRepo] [GetIDByName] error finding teacher %s: %v", teacher.Name, err)
+			return nil, err
 		}
-
-		var teacherID string
-		if existingTeacherID != "" {
-			// 教师已存在，使用现有ID
-			teacherID = existingTeacherID
-		} else {
-			// 教师不存在，创建新教师
-			now := primitive.NewDateTimeFromTime(time.Now())
-			newTeacher := &model.Teacher{
-				ID:         primitive.NewObjectID().Hex(),
-				Name:       teacher.Name,
-				Title:      teacher.Title,
-				Department: mapping.Data.AutoRegisterDepartment(teacher.Department),
-				CreatedAt:  time.Unix(0, int64(now)),
-				UpdatedAt:  time.Unix(0, int64(now)),
-			}
-
-			if err := a.TeacherRepo.Insert(ctx, newTeacher); err != nil {
-				logs.CtxErrorf(ctx, "[TeacherRepo] [Insert] error inserting teacher %s: %v", teacher.Name, err)
-				continue // 跳过这个教师
-			}
-			teacherID = newTeacher.ID
+		if teacherID != "" {
+			teacherIDs = append(teacherIDs, teacherID)
 		}
-
-		teacherIDs = append(teacherIDs, teacherID)
 	}
 
 	return &model.Course{
@@ -194,6 +186,49 @@ func (a *CourseAssembler) ToCourseDB(ctx context.Context, vo *dto.CourseVO) (*mo
 	}, nil
 }
 
+// resolveTeacherID 确定教师的正式ID：已有ID直接复用（已合并的教师重定向到合并目标），否则在同名教师中按院系、职称匹配，
+// 多位同名教师无法区分时返回 *homonym.AmbiguousError，没有匹配的教师时自动创建，创建失败时跳过该教师
+func (a *CourseAssembler) resolveTeacherID(ctx context.Context, teacher *dto.TeacherVO) (string, error) {
+	if teacher.ID != "" {
+		existing, err := a.TeacherRepo.FindByID(ctx, teacher.ID)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, teacher.ID)
+			return "", err
+		}
+		if existing != nil && existing.MergedInto != "" {
+			return existing.MergedInto, nil
+		}
+		return teacher.ID, nil
+	}
+	candidates, err := a.TeacherRepo.FindManyByName(ctx, teacher.Name)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error finding teacher %s: %v", teacher.Name, err)
+		return "", err
+	}
+	matched, err := homonym.Match(candidates, mapping.Data.GetDepartmentIDByName(teacher.Department), teacher.Title)
+	if err != nil {
+		return "", err
+	}
+	if matched != nil {
+		return matched.ID, nil
+	}
+
+	now := time.Now()
+	newTeacher := &model.Teacher{
+		ID:         primitive.NewObjectID().Hex(),
+		Name:       teacher.Name,
+		Title:      teacher.Title,
+		Department: mapping.Data.AutoRegisterDepartment(teacher.Department),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err = a.TeacherRepo.Insert(ctx, newTeacher); err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [Insert] error inserting teacher %s: %v", teacher.Name, err)
+		return "", nil
+	}
+	return newTeacher.ID, nil
+}
+
 // ToCourseDBDryRun CourseVO转CourseDB (VO to DB) - 不执行自动注册
 func (a *CourseAssembler) ToCourseDBDryRun(ctx context.Context, vo *dto.CourseVO) (*model.Course, error) {
 	// 将校区名称转换为ID
@@ -298,31 +333,16 @@ func (a *CourseAssembler) ToCourseDBFromProposalCourse(ctx context.Context, vo *
 		categoryID = mapping.Data.AutoRegisterCategory(vo.Category)
 	}
 
-	// 处理教师 - 已有ID直接复用；无ID视为用户手动输入的新教师，直接创建
+	// 处理教师 - 已有ID直接复用；无ID时按姓名与院系、职称匹配已有教师，没有匹配的才创建
 	var teacherIDs []string
 	for _, teacher := range vo.Teachers {
-		// 用户从建议列表选择的已有教师，直接复用其ID
-		if teacher.ID != "" {
-			teacherIDs = append(teacherIDs, teacher.ID)
-			continue
-		}
-
-		// 无ID：视为新教师，直接创建
-		now := primitive.NewDateTimeFromTime(time.Now())
-		newTeacher := &model.Teacher{
-			ID:         primitive.NewObjectID().Hex(),
-			Name:       teacher.Name,
-			Title:      teacher.Title,
-			Department: mapping.Data.AutoRegisterDepartment(teacher.Department),
-			CreatedAt:  time.Unix(0, int64(now)),
-			UpdatedAt:  time.Unix(0, int64(now)),
+		teacherID, err := a.resolveTeacherID(ctx, teacher)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := a.TeacherRepo.Insert(ctx, newTeacher); err != nil {
-			logs.CtxErrorf(ctx, "[TeacherRepo] [Insert] error inserting teacher %s: %v", teacher.Name, err)
-			continue // 跳过这个教师
+		if teacherID != "" {
+			teacherIDs = append(teacherIDs, teacherID)
 		}
-		teacherIDs = append(teacherIDs, newTeacher.ID)
 	}
 
 	return &model.Course{
@@ -441,39 +461,34 @@ func (a *CourseAssembler) ToCourseVOArray(ctx context.Context, dbs []*model.Cour
 		return []*dto.CourseVO{}, nil
 	}
 
-	courseVOs := make([]*dto.CourseVO, len(dbs))
-
-	type result struct {
-		index int
-		vo    *dto.CourseVO
-		err   error
+	// 一次查询所有课程的开设，按课程分组
+	courseIds := make([]string, 0, len(dbs))
+	for _, c := range dbs {
+		courseIds = append(courseIds, c.ID)
+	}
+	offeringsByCourse := make(map[string][]*model.CourseOffering, len(dbs))
+	offerings, err := a.CourseOfferingRepo.FindManyByCourseIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindManyByCourseIDs] error: %v", err)
+	}
+	for _, o := range offerings {
+		offeringsByCourse[o.CourseID] = append(offeringsByCourse[o.CourseID], o)
 	}
 
-	resultChan := make(chan result, len(dbs))
+	courseVOs := make([]*dto.CourseVO, len(dbs))
 	var wg sync.WaitGroup
-
 	for i, c := range dbs {
 		wg.Add(1)
 		go func(index int, dbCourse *model.Course) {
 			defer wg.Done()
-			vo, err := a.ToCourseVO(ctx, dbCourse)
-			resultChan <- result{index: index, vo: vo, err: err}
+			var semesters []*dto.SemesterOfferingsVO
+			if err == nil {
+				semesters = a.CourseOfferingAssembler.ToSemesterOfferingsVOArray(ctx, offeringsByCourse[dbCourse.ID])
+			}
+			courseVOs[index] = a.toCourseVO(ctx, dbCourse, semesters)
 		}(i, c)
 	}
-
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// 收集结果，保持顺序
-	for r := range resultChan {
-		if r.err != nil {
-			logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVO] error: %v", r.err)
-			return nil, r.err
-		}
-		courseVOs[r.index] = r.vo
-	}
+	wg.Wait()
 
 	return courseVOs, nil
 }
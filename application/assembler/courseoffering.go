@@ -0,0 +1,144 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assembler
+
+import (
+	"context"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/timetable"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+var _ ICourseOfferingAssembler = (*CourseOfferingAssembler)(nil)
+
+type ICourseOfferingAssembler interface {
+	ToCourseOfferingVO(ctx context.Context, db *model.CourseOffering) *dto.CourseOfferingVO
+	ToSemesterOfferingsVOArray(ctx context.Context, dbs []*model.CourseOffering) []*dto.SemesterOfferingsVO
+	ToTimeSlotVOArray(ctx context.Context, dbs []*model.TimeSlot) []*dto.TimeSlotVO
+	ToTimeSlotDBArray(ctx context.Context, vos []*dto.TimeSlotVO) []*model.TimeSlot
+}
+
+type CourseOfferingAssembler struct {
+	TeacherRepo *repo.TeacherRepo
+}
+
+var CourseOfferingAssemblerSet = wire.NewSet(
+	wire.Struct(new(CourseOfferingAssembler), "*"),
+	wire.Bind(new(ICourseOfferingAssembler), new(*CourseOfferingAssembler)),
+)
+
+// ToCourseOfferingVO 单个CourseOfferingDB转CourseOfferingVO (DB to VO)，查询不到的教师被跳过
+func (a *CourseOfferingAssembler) ToCourseOfferingVO(ctx context.Context, db *model.CourseOffering) *dto.CourseOfferingVO {
+	teachers := make([]*dto.TeacherVO, 0, len(db.TeacherIDs))
+	for _, tid := range db.TeacherIDs {
+		teacher, err := a.TeacherRepo.FindByID(ctx, tid)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] find teacher %s error: %v", tid, err)
+			continue
+		}
+		if teacher == nil {
+			continue
+		}
+		teachers = append(teachers, &dto.TeacherVO{
+			ID:         teacher.ID,
+			Name:       teacher.Name,
+			Title:      teacher.Title,
+			Department: mapping.Data.GetDepartmentNameByID(teacher.Department),
+		})
+	}
+	return &dto.CourseOfferingVO{
+		ID:       db.ID,
+		CourseID: db.CourseID,
+		Semester: db.Semester,
+		Teachers: teachers,
+		Campus:   mapping.Data.GetCampusNameByID(db.Campus),
+		Capacity: db.Capacity,
+		Slots:    a.ToTimeSlotVOArray(ctx, db.Slots),
+	}
+}
+
+// ToSemesterOfferingsVOArray 将开设按学期分组，保持输入中学期首次出现的顺序
+func (a *CourseOfferingAssembler) ToSemesterOfferingsVOArray(ctx context.Context, dbs []*model.CourseOffering) []*dto.SemesterOfferingsVO {
+	groups := []*dto.SemesterOfferingsVO{}
+	bySemester := make(map[string]*dto.SemesterOfferingsVO)
+	for _, db := range dbs {
+		group, ok := bySemester[db.Semester]
+		if !ok {
+			group = &dto.SemesterOfferingsVO{Semester: db.Semester}
+			bySemester[db.Semester] = group
+			groups = append(groups, group)
+		}
+		group.Offerings = append(group.Offerings, a.ToCourseOfferingVO(ctx, db))
+	}
+	return groups
+}
+
+// ToTimeSlotVOArray TimeSlotDB数组转TimeSlotVO数组 (DB Array to VO Array)
+func (a *CourseOfferingAssembler) ToTimeSlotVOArray(ctx context.Context, dbs []*model.TimeSlot) []*dto.TimeSlotVO {
+	if len(dbs) == 0 {
+		return nil
+	}
+	vos := make([]*dto.TimeSlotVO, 0, len(dbs))
+	for _, db := range dbs {
+		weeks := make([]*dto.WeekRangeVO, 0, len(db.Weeks))
+		for _, w := range db.Weeks {
+			weeks = append(weeks, &dto.WeekRangeVO{From: w.From, To: w.To, Parity: w.Parity})
+		}
+		vos = append(vos, &dto.TimeSlotVO{
+			Day:         db.Day,
+			StartPeriod: db.StartPeriod,
+			EndPeriod:   db.EndPeriod,
+			Weeks:       weeks,
+			Location:    db.Location,
+			WeeksText:   timetable.FormatWeeks(db.Weeks),
+		})
+	}
+	return vos
+}
+
+// ToTimeSlotDBArray TimeSlotVO数组转TimeSlotDB数组 (VO Array to DB Array)，不做合法性校验，空元素原样保留交由校验处理
+func (a *CourseOfferingAssembler) ToTimeSlotDBArray(ctx context.Context, vos []*dto.TimeSlotVO) []*model.TimeSlot {
+	if len(vos) == 0 {
+		return nil
+	}
+	dbs := make([]*model.TimeSlot, 0, len(vos))
+	for _, vo := range vos {
+		if vo == nil {
+			dbs = append(dbs, nil)
+			continue
+		}
+		weeks := make([]*model.WeekRange, 0, len(vo.Weeks))
+		for _, w := range vo.Weeks {
+			if w == nil {
+				weeks = append(weeks, nil)
+				continue
+			}
+			weeks = append(weeks, &model.WeekRange{From: w.From, To: w.To, Parity: w.Parity})
+		}
+		dbs = append(dbs, &model.TimeSlot{
+			Day:         vo.Day,
+			StartPeriod: vo.StartPeriod,
+			EndPeriod:   vo.EndPeriod,
+			Weeks:       weeks,
+			Location:    vo.Location,
+		})
+	}
+	return dbs
+}
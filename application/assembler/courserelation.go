@@ -0,0 +1,165 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assembler
+
+import (
+	"context"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/google/wire"
+)
+
+var _ ICourseRelationAssembler = (*CourseRelationAssembler)(nil)
+
+type ICourseRelationAssembler interface {
+	ToCourseRelationVOArray(ctx context.Context, dbs []*model.CourseRelation) ([]*dto.CourseRelationVO, error)
+	ToCourseRelationsVO(ctx context.Context, courseId string, dbs []*model.CourseRelation, equivalentIds []string) (*dto.CourseRelationsVO, error)
+}
+
+type CourseRelationAssembler struct {
+	CourseRepo *repo.CourseRepo
+}
+
+var CourseRelationAssemblerSet = wire.NewSet(
+	wire.Struct(new(CourseRelationAssembler), "*"),
+	wire.Bind(new(ICourseRelationAssembler), new(*CourseRelationAssembler)),
+)
+
+// ToCourseRelationVOArray CourseRelationDB数组转CourseRelationVO数组 (DB Array to VO Array)
+// 已删除的课程只保留ID
+func (a *CourseRelationAssembler) ToCourseRelationVOArray(ctx context.Context, dbs []*model.CourseRelation) ([]*dto.CourseRelationVO, error) {
+	ids := make([]string, 0, len(dbs)*2)
+	for _, db := range dbs {
+		ids = append(ids, db.FromID, db.ToID)
+	}
+	courses, err := a.findCourses(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	vos := make([]*dto.CourseRelationVO, 0, len(dbs))
+	for _, db := range dbs {
+		vos = append(vos, &dto.CourseRelationVO{
+			ID:           db.ID,
+			Type:         db.Type,
+			From:         relatedCourse(db.FromID, courses[db.FromID], ""),
+			To:           relatedCourse(db.ToID, courses[db.ToID], ""),
+			Status:       relationStatusName(db.Status),
+			Note:         db.Note,
+			RejectReason: db.RejectReason,
+			CreatedAt:    db.CreatedAt,
+		})
+	}
+	return vos, nil
+}
+
+// ToCourseRelationsVO 将课程已生效的关系按另一端分类，equivalentIds 为间接等价的课程ID
+// 两端相同的关系和另一端已删除的关系被跳过
+func (a *CourseRelationAssembler) ToCourseRelationsVO(ctx context.Context, courseId string, dbs []*model.CourseRelation, equivalentIds []string) (*dto.CourseRelationsVO, error) {
+	ids := append([]string{}, equivalentIds...)
+	for _, db := range dbs {
+		ids = append(ids, db.FromID, db.ToID)
+	}
+	courses, err := a.findCourses(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	vo := &dto.CourseRelationsVO{
+		Prerequisites: []*dto.RelatedCourseVO{},
+		RequiredBy:    []*dto.RelatedCourseVO{},
+		FollowUps:     []*dto.RelatedCourseVO{},
+		FollowedFrom:  []*dto.RelatedCourseVO{},
+		Equivalents:   []*dto.RelatedCourseVO{},
+	}
+	equivalents := map[string]bool{}
+	for _, db := range dbs {
+		if db.Status != consts.CourseRelationStatusApproved || db.FromID == db.ToID {
+			continue
+		}
+		otherId, outgoing := db.ToID, true
+		if db.ToID == courseId {
+			otherId, outgoing = db.FromID, false
+		}
+		other, ok := courses[otherId]
+		if !ok {
+			continue
+		}
+		related := relatedCourse(otherId, other, db.ID)
+		switch {
+		case db.Type == consts.CourseRelationPrerequisite && outgoing:
+			vo.RequiredBy = append(vo.RequiredBy, related)
+		case db.Type == consts.CourseRelationPrerequisite:
+			vo.Prerequisites = append(vo.Prerequisites, related)
+		case db.Type == consts.CourseRelationFollowUp && outgoing:
+			vo.FollowUps = append(vo.FollowUps, related)
+		case db.Type == consts.CourseRelationFollowUp:
+			vo.FollowedFrom = append(vo.FollowedFrom, related)
+		case db.Type == consts.CourseRelationEquivalent && !equivalents[otherId]:
+			equivalents[otherId] = true
+			vo.Equivalents = append(vo.Equivalents, related)
+		}
+	}
+	for _, id := range equivalentIds {
+		if other, ok := courses[id]; ok && id != courseId && !equivalents[id] {
+			equivalents[id] = true
+			vo.Equivalents = append(vo.Equivalents, relatedCourse(id, other, ""))
+		}
+	}
+	return vo, nil
+}
+
+// findCourses 批量查询未删除的课程
+func (a *CourseRelationAssembler) findCourses(ctx context.Context, ids []string) (map[string]*model.Course, error) {
+	byID := make(map[string]*model.Course, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+	courses, err := a.CourseRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, course := range courses {
+		byID[course.ID] = course
+	}
+	return byID, nil
+}
+
+// relatedCourse 课程不存在时只保留ID
+func relatedCourse(id string, course *model.Course, relationId string) *dto.RelatedCourseVO {
+	vo := &dto.RelatedCourseVO{RelationID: relationId, ID: id}
+	if course != nil {
+		vo.Name = course.Name
+		vo.Code = course.Code
+		vo.Department = mapping.Data.GetDepartmentNameByID(course.Department)
+	}
+	return vo
+}
+
+// relationStatusName 关系状态的名称，与提案状态名称一致
+func relationStatusName(status int32) string {
+	switch status {
+	case consts.CourseRelationStatusApproved:
+		return consts.ProposalStatusApproved
+	case consts.CourseRelationStatusRejected:
+		return consts.ProposalStatusRejected
+	default:
+		return consts.ProposalStatusPending
+	}
+}
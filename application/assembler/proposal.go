@@ -16,6 +16,8 @@ package assembler
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
@@ -33,6 +35,14 @@ type IProposalAssembler interface {
 	ToProposalVOArray(ctx context.Context, dbs []*model.Proposal, userId string) ([]*dto.ProposalVO, error)
 	ToProposalDB(ctx context.Context, vo *dto.ProposalVO) (*model.Proposal, error)
 	ToProposalDBArray(ctx context.Context, vos []*dto.ProposalVO) ([]*model.Proposal, error)
+	ToCourseChangeVO(db *model.ProposalCourseChange) *dto.CourseChangeVO
+	ToCourseChangeDB(vo *dto.CourseChangeVO) *model.ProposalCourseChange
+	ToTeacherChangeVO(db *model.ProposalTeacherChange) *dto.TeacherChangeVO
+	ToTeacherChangeDB(vo *dto.TeacherChangeVO) *model.ProposalTeacherChange
+	ToProposalTeacherVO(db *model.ProposalTeacher) *dto.TeacherVO
+	ToProposalTeacherDB(vo *dto.TeacherVO) *model.ProposalTeacher
+	ToProposalCommentVO(db *model.ProposalComment, authorId string) *dto.ProposalCommentVO
+	ToProposalCommentVOTree(dbs []*model.ProposalComment, authorId string) []*dto.ProposalCommentVO
 }
 
 type ProposalAssembler struct {
@@ -78,7 +88,7 @@ func (a *ProposalAssembler) ToProposalVO(ctx context.Context, db *model.Proposal
 		return nil, err
 	}
 
-	return &dto.ProposalVO{
+	vo := &dto.ProposalVO{
 		ID:           db.ID,
 		UserID:       db.UserID,
 		Title:        db.Title,
@@ -95,7 +105,11 @@ func (a *ProposalAssembler) ToProposalVO(ctx context.Context, db *model.Proposal
 		},
 		CreatedAt: db.CreatedAt,
 		UpdatedAt: db.UpdatedAt,
-	}, nil
+	}
+	if err = a.fillChange(ctx, vo, db); err != nil {
+		return nil, err
+	}
+	return vo, nil
 }
 
 // ToProposalVOArray ProposalDB数组转ProposalVO数组 (DB Array to VO Array)
@@ -175,6 +189,9 @@ func (a *ProposalAssembler) ToProposalVOArray(ctx context.Context, dbs []*model.
 			CreatedAt: db.CreatedAt,
 			UpdatedAt: db.UpdatedAt,
 		}
+		if err = a.fillChange(ctx, proposalVO, db); err != nil {
+			return nil, err
+		}
 		vos = append(vos, proposalVO)
 	}
 
@@ -197,19 +214,41 @@ func (a *ProposalAssembler) ToProposalDB(ctx context.Context, vo *dto.ProposalVO
 		vo.Contribution = 0
 	}
 
+	var beforeDB *model.ProposalCourse
+	if vo.Before != nil {
+		var err error
+		beforeDB, err = a.CourseAssembler.ToProposalCourseDB(ctx, vo.Before)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseDB] error: %v", err)
+			return nil, err
+		}
+	}
+	// 修改提案中课程不再开设时，修改后的课程标记为删除
+	if courseDB != nil && vo.Change != nil && vo.Change.Retired {
+		courseDB.Deleted = true
+	}
+
 	return &model.Proposal{
-		ID:           vo.ID,
-		UserID:       vo.UserID,
-		Title:        vo.Title,
-		Content:      vo.Content,
-		Course:       courseDB,
-		Status:       mapping.Data.GetProposalStatusIDByName(vo.Status),
-		Deleted:      vo.Deleted,
-		RejectReason: vo.RejectReason,
-		ShowUsername: vo.ShowUsername,
-		Contribution: vo.Contribution,
-		CreatedAt:    vo.CreatedAt,
-		UpdatedAt:    vo.UpdatedAt,
+		ID:            vo.ID,
+		UserID:        vo.UserID,
+		Title:         vo.Title,
+		Content:       vo.Content,
+		Course:        courseDB,
+		Status:        mapping.Data.GetProposalStatusIDByName(vo.Status),
+		Deleted:       vo.Deleted,
+		RejectReason:  vo.RejectReason,
+		ShowUsername:  vo.ShowUsername,
+		Contribution:  vo.Contribution,
+		CreatedAt:     vo.CreatedAt,
+		UpdatedAt:     vo.UpdatedAt,
+		Type:          vo.Type,
+		CourseID:      vo.CourseID,
+		Change:        a.ToCourseChangeDB(vo.Change),
+		Before:        beforeDB,
+		TeacherID:     vo.TeacherID,
+		Teacher:       a.ToProposalTeacherDB(vo.Teacher),
+		TeacherChange: a.ToTeacherChangeDB(vo.TeacherChange),
+		TeacherBefore: a.ToProposalTeacherDB(vo.TeacherBefore),
 	}, nil
 }
 
@@ -234,3 +273,241 @@ func (a *ProposalAssembler) ToProposalDBArray(ctx context.Context, vos []*dto.Pr
 
 	return dbs, nil
 }
+
+// ToCourseChangeVO 修改提案的字段级修改转VO (DB to VO)
+func (a *ProposalAssembler) ToCourseChangeVO(db *model.ProposalCourseChange) *dto.CourseChangeVO {
+	if db == nil {
+		return nil
+	}
+	var teachers []*dto.TeacherVO
+	for _, t := range db.Teachers {
+		teachers = append(teachers, &dto.TeacherVO{
+			ID:         t.TeacherID,
+			Name:       t.Name,
+			Department: t.Department,
+			Title:      t.Title,
+		})
+	}
+	return &dto.CourseChangeVO{
+		Name:       db.Name,
+		Code:       db.Code,
+		Department: db.Department,
+		Category:   db.Category,
+		Campuses:   db.Campuses,
+		Teachers:   teachers,
+		Retired:    db.Retired,
+	}
+}
+
+// ToCourseChangeDB 修改提案的字段级修改转DB (VO to DB)
+func (a *ProposalAssembler) ToCourseChangeDB(vo *dto.CourseChangeVO) *model.ProposalCourseChange {
+	if vo == nil {
+		return nil
+	}
+	var teachers []*model.ProposalTeacher
+	for _, t := range vo.Teachers {
+		teachers = append(teachers, &model.ProposalTeacher{
+			TeacherID:  t.ID,
+			Name:       t.Name,
+			Department: t.Department,
+			Title:      t.Title,
+		})
+	}
+	return &model.ProposalCourseChange{
+		Name:       vo.Name,
+		Code:       vo.Code,
+		Department: vo.Department,
+		Category:   vo.Category,
+		Campuses:   vo.Campuses,
+		Teachers:   teachers,
+		Retired:    vo.Retired,
+	}
+}
+
+// ToTeacherChangeVO 教师更正提案的字段级修改转VO (DB to VO)
+func (a *ProposalAssembler) ToTeacherChangeVO(db *model.ProposalTeacherChange) *dto.TeacherChangeVO {
+	if db == nil {
+		return nil
+	}
+	return &dto.TeacherChangeVO{
+		Name:       db.Name,
+		Title:      db.Title,
+		Department: db.Department,
+	}
+}
+
+// ToTeacherChangeDB 教师更正提案的字段级修改转DB (VO to DB)
+func (a *ProposalAssembler) ToTeacherChangeDB(vo *dto.TeacherChangeVO) *model.ProposalTeacherChange {
+	if vo == nil {
+		return nil
+	}
+	return &model.ProposalTeacherChange{
+		Name:       vo.Name,
+		Title:      vo.Title,
+		Department: vo.Department,
+	}
+}
+
+// ToProposalTeacherVO 提案中的教师转TeacherVO (DB to VO)
+func (a *ProposalAssembler) ToProposalTeacherVO(db *model.ProposalTeacher) *dto.TeacherVO {
+	if db == nil {
+		return nil
+	}
+	return &dto.TeacherVO{
+		ID:         db.TeacherID,
+		Name:       db.Name,
+		Title:      db.Title,
+		Department: db.Department,
+	}
+}
+
+// ToProposalTeacherDB TeacherVO转提案中的教师 (VO to DB)
+func (a *ProposalAssembler) ToProposalTeacherDB(vo *dto.TeacherVO) *model.ProposalTeacher {
+	if vo == nil {
+		return nil
+	}
+	return &model.ProposalTeacher{
+		TeacherID:  vo.ID,
+		Name:       vo.Name,
+		Title:      vo.Title,
+		Department: vo.Department,
+	}
+}
+
+// fillChange 填充提案类型，修改提案与教师更正提案额外填充修改内容、修改前的快照与字段对比
+func (a *ProposalAssembler) fillChange(ctx context.Context, vo *dto.ProposalVO, db *model.Proposal) error {
+	vo.Type = db.Type
+	if vo.Type == "" {
+		vo.Type = consts.ProposalTypeAdd
+	}
+	if vo.Type == consts.ProposalTypeTeacher {
+		vo.TeacherID = db.TeacherID
+		vo.Teacher = a.ToProposalTeacherVO(db.Teacher)
+		vo.TeacherChange = a.ToTeacherChangeVO(db.TeacherChange)
+		vo.TeacherBefore = a.ToProposalTeacherVO(db.TeacherBefore)
+		vo.Diff = teacherFieldChanges(vo.TeacherBefore, vo.Teacher)
+		return nil
+	}
+	if vo.Type != consts.ProposalTypeChange {
+		return nil
+	}
+
+	before, err := a.CourseAssembler.ToProposalCourseVO(ctx, db.Before)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v", err)
+		return err
+	}
+	if before != nil {
+		before.ID = db.CourseID
+	}
+	if vo.Course != nil {
+		vo.Course.ID = db.CourseID
+	}
+	vo.CourseID = db.CourseID
+	vo.Change = a.ToCourseChangeVO(db.Change)
+	vo.Before = before
+	vo.Diff = courseFieldChanges(before, vo.Course, db.Course != nil && db.Course.Deleted)
+	return nil
+}
+
+// courseFieldChanges 逐字段对比修改前后的课程，只返回有变化的字段
+func courseFieldChanges(before, after *dto.ProposalCourseVO, retired bool) []*dto.CourseFieldChangeVO {
+	if before == nil || after == nil {
+		return nil
+	}
+	if retired {
+		return []*dto.CourseFieldChangeVO{{Field: consts.ProposalChangeFieldRetired, Before: "false", After: "true"}}
+	}
+
+	fields := []*dto.CourseFieldChangeVO{
+		{Field: consts.ProposalChangeFieldName, Before: before.Name, After: after.Name},
+		{Field: consts.ProposalChangeFieldCode, Before: before.Code, After: after.Code},
+		{Field: consts.ProposalChangeFieldDepartment, Before: before.Department, After: after.Department},
+		{Field: consts.ProposalChangeFieldCategory, Before: before.Category, After: after.Category},
+		{Field: consts.ProposalChangeFieldCampuses, Before: joinSorted(before.Campuses), After: joinSorted(after.Campuses)},
+		{Field: consts.ProposalChangeFieldTeachers, Before: teacherLabels(before.Teachers), After: teacherLabels(after.Teachers)},
+	}
+	changes := make([]*dto.CourseFieldChangeVO, 0, len(fields))
+	for _, f := range fields {
+		if f.Before != f.After {
+			changes = append(changes, f)
+		}
+	}
+	return changes
+}
+
+// teacherFieldChanges 逐字段对比更正前后的教师，只返回有变化的字段
+func teacherFieldChanges(before, after *dto.TeacherVO) []*dto.CourseFieldChangeVO {
+	if before == nil || after == nil {
+		return nil
+	}
+	fields := []*dto.CourseFieldChangeVO{
+		{Field: consts.ProposalChangeFieldName, Before: before.Name, After: after.Name},
+		{Field: consts.ProposalChangeFieldTitle, Before: before.Title, After: after.Title},
+		{Field: consts.ProposalChangeFieldDepartment, Before: before.Department, After: after.Department},
+	}
+	changes := make([]*dto.CourseFieldChangeVO, 0, len(fields))
+	for _, f := range fields {
+		if f.Before != f.After {
+			changes = append(changes, f)
+		}
+	}
+	return changes
+}
+
+// teacherLabels 教师列表的展示文本，带院系以区分同名教师
+func teacherLabels(teachers []*dto.TeacherVO) string {
+	labels := make([]string, 0, len(teachers))
+	for _, t := range teachers {
+		label := t.Name
+		if t.Department != "" {
+			label += "(" + t.Department + ")"
+		}
+		labels = append(labels, label)
+	}
+	return joinSorted(labels)
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "、")
+}
+
+// ToProposalCommentVO 单个ProposalCommentDB转ProposalCommentVO (DB to VO)，authorId 为提案作者ID
+func (a *ProposalAssembler) ToProposalCommentVO(db *model.ProposalComment, authorId string) *dto.ProposalCommentVO {
+	return &dto.ProposalCommentVO{
+		ID:            db.ID,
+		ProposalID:    db.ProposalID,
+		UserID:        db.UserID,
+		ParentID:      db.ParentID,
+		ReplyToUserID: db.ReplyToUserID,
+		Content:       db.Content,
+		Internal:      db.Internal,
+		IsAuthor:      db.UserID == authorId,
+		CreatedAt:     db.CreatedAt,
+	}
+}
+
+// ToProposalCommentVOTree 将按时间升序的讨论组装为顶层评论及其回复，顶层评论已删除或不可见的回复被跳过
+func (a *ProposalAssembler) ToProposalCommentVOTree(dbs []*model.ProposalComment, authorId string) []*dto.ProposalCommentVO {
+	roots := make([]*dto.ProposalCommentVO, 0, len(dbs))
+	byID := make(map[string]*dto.ProposalCommentVO, len(dbs))
+	for _, db := range dbs {
+		if db.ParentID != "" {
+			continue
+		}
+		vo := a.ToProposalCommentVO(db, authorId)
+		roots = append(roots, vo)
+		byID[db.ID] = vo
+	}
+	for _, db := range dbs {
+		if db.ParentID == "" {
+			continue
+		}
+		if parent, ok := byID[db.ParentID]; ok {
+			parent.Replies = append(parent.Replies, a.ToProposalCommentVO(db, authorId))
+		}
+	}
+	return roots
+}
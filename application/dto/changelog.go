@@ -33,6 +33,8 @@ type CreateChangeLogReq struct {
 	ProposalID   string `json:"proposalId"`
 	IP           string `json:"ip"`
 	UserAgent    string `json:"userAgent"`
+	Before       string `json:"before"` // 变更前的快照(JSON)
+	After        string `json:"after"`  // 变更后的快照(JSON)
 }
 
 // CreateChangeLogResp 新增变更日志响应
@@ -50,6 +52,8 @@ type ChangeLogVO struct {
 	UpdateSource int32     `json:"updateSource"`
 	ProposalID   string    `json:"proposalId,omitempty"`
 	UserID       string    `json:"userId"`
+	Before       string    `json:"before,omitempty"`
+	After        string    `json:"after,omitempty"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 }
 
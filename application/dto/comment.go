@@ -17,11 +17,12 @@ package dto
 import "time"
 
 type CommentVO struct {
-	ID       string   `json:"id"`
-	CourseID string   `json:"courseId"`
-	Content  string   `json:"content"`
-	UserID   string   `json:"userId"`
-	Tags     []string `json:"tags"`
+	ID         string   `json:"id"`
+	CourseID   string   `json:"courseId"`
+	Content    string   `json:"content"`
+	UserID     string   `json:"userId"`
+	Tags       []string `json:"tags"`
+	OfferingID string   `json:"offeringId,omitempty"` // 评论针对的课程开设，可为空
 	*LikeVO
 	ExtraInfo
 	CreatedAt time.Time `json:"createdAt"`
@@ -37,9 +38,10 @@ type ExtraInfo struct {
 
 // CreateCommentReq 对应 /api/comment/add 的请求体
 type CreateCommentReq struct {
-	CourseID string   `json:"courseId" binding:"required"`
-	Content  string   `json:"content" binding:"required"`
-	Tags     []string `json:"tags"`
+	CourseID   string   `json:"courseId" binding:"required"`
+	Content    string   `json:"content" binding:"required"`
+	Tags       []string `json:"tags"`
+	OfferingID string   `json:"offeringId"` // 可选，关联到课程的某次开设，须属于 CourseID 对应的课程
 }
 
 // CreateCommentResp 对应 /api/comment/add 的响应体
@@ -16,25 +16,29 @@ package dto
 
 // CourseVO 传递给前端的课程类型 模糊搜索和精确搜索结果都可用此类型
 type CourseVO struct {
-	ID         string           `json:"id"`
-	Name       string           `json:"name"`
-	Code       string           `json:"code"`
-	Category   string           `json:"category"`
-	Campuses   []string         `json:"campuses"`
-	Department string           `json:"department"`
-	Teachers   []*TeacherVO     `json:"teachers"`
-	TagCount   map[string]int64 `json:"tagCount"`
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Code       string                 `json:"code"`
+	Category   string                 `json:"category"`
+	Campuses   []string               `json:"campuses"`
+	Department string                 `json:"department"`
+	Teachers   []*TeacherVO           `json:"teachers"`
+	TagCount   map[string]int64       `json:"tagCount"`
+	Offerings  []*SemesterOfferingsVO `json:"offerings,omitempty"` // 按学期倒序分组的开设
+	Relations  *CourseRelationsVO     `json:"relations,omitempty"` // 先修、后续和等价课程，仅课程详情返回
 }
 
 type ListCoursesReq struct {
-	Keyword string `form:"keyword"`
-	Type    string `form:"type"` // teacher or course
+	Keyword   string `form:"keyword"`
+	Type      string `form:"type"`      // teacher or course
+	TeacherID string `form:"teacherId"` // 按教师搜索时指定同名教师中的一位
 	*PageParam
 }
 
 type ListCoursesResp struct {
 	*Resp
 	*PaginatedCourses
+	Teachers []*TeacherVO `json:"teachers,omitempty"` // 按教师搜索时命中的教师（可能有多位同名教师），用于跳转教师主页
 }
 
 type GetCourseReq struct {
@@ -78,3 +82,212 @@ type PaginatedCourses struct {
 	Total   int64       `json:"total"`   // 符合条件的总记录数
 	*PageParam
 }
+
+// CourseInfo 管理员直接创建或修改课程时提交的课程信息
+type CourseInfo struct {
+	Name       string       `json:"name" binding:"required"`
+	Code       string       `json:"code"`
+	Category   string       `json:"category" binding:"required"`
+	Department string       `json:"department" binding:"required"`
+	Campuses   []string     `json:"campuses"`
+	Teachers   []*TeacherVO `json:"teachers"`
+}
+
+type CreateCourseReq struct {
+	CourseInfo
+}
+
+type CreateCourseResp struct {
+	*Resp
+	Course *CourseVO `json:"course"`
+}
+
+type UpdateCourseReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	CourseInfo
+}
+
+type UpdateCourseResp struct {
+	*Resp
+	Course *CourseVO `json:"course"`
+}
+
+type DeleteCourseReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type DeleteCourseResp struct {
+	*Resp
+	Deleted bool `json:"deleted"`
+}
+
+type RestoreCourseReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type RestoreCourseResp struct {
+	*Resp
+	Course *CourseVO `json:"course"`
+}
+
+// MergeCoursesReq 将重复课程合并到保留课程，DryRun 为 true 时只返回影响预览
+type MergeCoursesReq struct {
+	TargetID  string   `json:"targetId" binding:"required"`        // 保留的课程ID
+	SourceIDs []string `json:"sourceIds" binding:"required,min=1"` // 被合并的重复课程ID
+	DryRun    bool     `json:"dryRun"`
+}
+
+type MergeCoursesResp struct {
+	*Resp
+	Preview *CourseMergePreviewVO `json:"preview"`
+	Course  *CourseVO             `json:"course,omitempty"` // 合并后的保留课程，预览时为空
+}
+
+// CourseMergePreviewVO 课程合并的影响预览
+type CourseMergePreviewVO struct {
+	TargetID     string   `json:"targetId"`
+	SourceIDs    []string `json:"sourceIds"`
+	CommentCount int64    `json:"commentCount"`          // 将迁移的评论数
+	LikeCount    int64    `json:"likeCount"`             // 随评论迁移的点赞数
+	ProposalIDs  []string `json:"proposalIds,omitempty"` // 将迁移到保留课程的来源提案ID
+}
+
+// ImportCoursesReq 批量导入课程目录，文件通过 multipart 表单的 file 字段上传，支持 CSV 与 XLSX
+type ImportCoursesReq struct {
+	DryRun   bool   `form:"dryRun"`                          // 为 true 时只返回差异预览
+	FileName string `form:"-" json:"fileName"`               // 由 handler 从上传文件中获取
+	Data     []byte `form:"-" json:"-" swaggerignore:"true"` // 文件内容
+}
+
+type ImportCoursesResp struct {
+	*Resp
+	Diff *CourseImportDiffVO `json:"diff"`
+}
+
+// CourseImportDiffVO 课程导入的差异，行号从 1 开始并包含表头
+type CourseImportDiffVO struct {
+	Total     int                  `json:"total"`     // 有效数据行数
+	Unchanged int                  `json:"unchanged"` // 与现有课程一致的行数
+	Created   []*CourseImportRowVO `json:"created"`   // 将新建的课程
+	Changed   []*CourseImportRowVO `json:"changed"`   // 字段有变化的课程
+	Conflicts []*CourseImportRowVO `json:"conflicts"` // 无法导入的行，不会被应用
+	Failed    []*CourseImportRowVO `json:"failed"`    // 应用时失败的行
+}
+
+type CourseImportRowVO struct {
+	Line        int                    `json:"line"`
+	Name        string                 `json:"name"`
+	Code        string                 `json:"code"`
+	CourseID    string                 `json:"courseId,omitempty"`    // 匹配到的现有课程或新建课程的ID
+	Changes     []*CourseFieldChangeVO `json:"changes,omitempty"`     // 变化的字段
+	NewTeachers []string               `json:"newTeachers,omitempty"` // 将自动创建的教师
+	Reason      string                 `json:"reason,omitempty"`      // 冲突或失败原因
+}
+
+type CourseFieldChangeVO struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ExportCoursesReq 导出课程目录，筛选条件与 ListCoursesReq 相同，Type 为空时导出全部课程
+type ExportCoursesReq struct {
+	Keyword string `form:"keyword"`
+	Type    string `form:"type"`
+	Format  string `form:"format" binding:"omitempty,oneof=csv ndjson"` // 默认 csv
+}
+
+// CourseExportVO 导出的单门课程，映射与教师均已解析为名称
+type CourseExportVO struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Code         string           `json:"code"`
+	Department   string           `json:"department"`
+	Category     string           `json:"category"`
+	Campuses     []string         `json:"campuses"`
+	Teachers     []string         `json:"teachers"`
+	CommentCount int64            `json:"commentCount"`
+	TagCount     map[string]int64 `json:"tagCount"` // 全部标签分布
+}
+
+// SearchCoursesReq 组合条件搜索课程，各条件之间为“且”，零值条件不参与筛选
+type SearchCoursesReq struct {
+	Keyword    string   `json:"keyword"`   // 匹配课程名称或代码
+	Teacher    string   `json:"teacher"`   // 教师姓名
+	TeacherID  string   `json:"teacherId"` // 指定同名教师中的一位，优先于 Teacher
+	Department string   `json:"department"`
+	Category   string   `json:"category"`
+	Campuses   []string `json:"campuses"` // 开设在其中任一校区
+	Tags       []string `json:"tags"`     // 课程评论需覆盖全部标签
+	Sort       string   `json:"sort" binding:"omitempty,oneof=relevance comments rating newest"`
+	*PageParam
+}
+
+type SearchCoursesResp struct {
+	*Resp
+	*PaginatedCourses
+	Teachers []*TeacherVO `json:"teachers,omitempty"` // 按教师筛选时命中的教师（可能有多位同名教师），用于跳转教师主页
+}
+
+// GetCourseStatsReq 获取课程详情统计，Granularity 为评论量时间线的粒度，默认按月
+type GetCourseStatsReq struct {
+	CourseID    string `form:"courseId"`
+	Granularity string `form:"granularity" binding:"omitempty,oneof=month semester"`
+}
+
+type GetCourseStatsResp struct {
+	*Resp
+	Stats *CourseStatsVO `json:"stats"`
+}
+
+// CourseStatsVO 课程详情统计
+type CourseStatsVO struct {
+	CourseID     string             `json:"courseId"`
+	CommentCount int64              `json:"commentCount"`
+	LikeCount    int64              `json:"likeCount"` // 课程下全部评论获得的点赞数
+	TagCount     map[string]int64   `json:"tagCount"`  // 全部标签分布
+	Timeline     []*CommentVolumeVO `json:"timeline"`  // 按时间升序
+	TopComment   *CommentVO         `json:"topComment,omitempty"`
+}
+
+// CommentVolumeVO 一个时间段内的评论数
+type CommentVolumeVO struct {
+	Period string `json:"period"` // "2025-03" 或 "2024-2025-2"
+	Count  int64  `json:"count"`
+}
+
+// CompareCoursesReq 对比 2~4 门课程，IDs 可重复传参或以逗号分隔
+type CompareCoursesReq struct {
+	IDs []string `form:"ids" binding:"required"`
+}
+
+type CompareCoursesResp struct {
+	*Resp
+	Tags    []string              `json:"tags"`    // 全部课程出现过的标签，按总数降序，用于对齐各课程的标签分布
+	Courses []*CourseComparisonVO `json:"courses"` // 与请求顺序一致
+}
+
+// CourseComparisonVO 单门课程的对比数据
+type CourseComparisonVO struct {
+	Course       *CourseVO        `json:"course"`
+	CommentCount int64            `json:"commentCount"`
+	TagCount     map[string]int64 `json:"tagCount"`             // 覆盖 Tags 中的全部标签，未出现的为 0
+	TopComment   *CommentVO       `json:"topComment,omitempty"` // 点赞最多的评论，没有点赞时不返回
+}
+
+// GetCourseRecommendationsReq 获取个性化课程推荐，Limit 默认 10
+type GetCourseRecommendationsReq struct {
+	Limit int64 `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type GetCourseRecommendationsResp struct {
+	*Resp
+	Courses []*RecommendedCourseVO `json:"courses"` // 按得分降序
+}
+
+// RecommendedCourseVO 推荐课程及推荐理由
+type RecommendedCourseVO struct {
+	Course  *CourseVO `json:"course"`
+	Score   float64   `json:"score"`   // 保留两位小数
+	Reasons []string  `json:"reasons"` // 按贡献从大到小排列，如“因为你搜索过「Python」”
+}
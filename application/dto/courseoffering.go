@@ -0,0 +1,98 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+// CourseOfferingVO 课程在某学期的一次开设
+type CourseOfferingVO struct {
+	ID       string        `json:"id"`
+	CourseID string        `json:"courseId"`
+	Semester string        `json:"semester"`
+	Teachers []*TeacherVO  `json:"teachers"`
+	Campus   string        `json:"campus"`
+	Capacity int32         `json:"capacity,omitempty"` // 0 表示未知，不返回
+	Slots    []*TimeSlotVO `json:"slots,omitempty"`
+}
+
+// WeekRangeVO 上课周次范围，Parity 0 每周、1 单周、2 双周
+type WeekRangeVO struct {
+	From   int32 `json:"from" binding:"min=1"`
+	To     int32 `json:"to" binding:"min=1"`
+	Parity int32 `json:"parity" binding:"min=0,max=2"`
+}
+
+// TimeSlotVO 每周的一次上课时间，Day 1-7 为周一到周日，节次从 1 开始
+type TimeSlotVO struct {
+	Day         int32          `json:"day" binding:"min=1,max=7"`
+	StartPeriod int32          `json:"startPeriod" binding:"min=1"`
+	EndPeriod   int32          `json:"endPeriod" binding:"min=1"`
+	Weeks       []*WeekRangeVO `json:"weeks" binding:"required,dive"`
+	Location    string         `json:"location"`
+	WeeksText   string         `json:"weeksText,omitempty"` // 仅返回，如 "1-8,10-16周"
+}
+
+// SemesterOfferingsVO 课程在同一学期的全部开设
+type SemesterOfferingsVO struct {
+	Semester  string              `json:"semester"`
+	Offerings []*CourseOfferingVO `json:"offerings"`
+}
+
+// CourseOfferingInfo 管理员提交的开设信息，Semester 形如 "2024-2025-1"
+type CourseOfferingInfo struct {
+	Semester   string        `json:"semester" binding:"required"`
+	TeacherIDs []string      `json:"teacherIds"`
+	Campus     string        `json:"campus" binding:"required"`
+	Capacity   int32         `json:"capacity" binding:"omitempty,min=0"`
+	Slots      []*TimeSlotVO `json:"slots" binding:"omitempty,dive"`
+}
+
+// ListCourseOfferingsReq 查询课程的开设，Semester 为空时返回全部学期
+type ListCourseOfferingsReq struct {
+	CourseID string `form:"-" swaggerignore:"true"` // 从 URL path 获取
+	Semester string `form:"semester"`
+}
+
+type ListCourseOfferingsResp struct {
+	*Resp
+	Offerings []*SemesterOfferingsVO `json:"offerings"` // 按学期倒序
+}
+
+type CreateCourseOfferingReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	CourseOfferingInfo
+}
+
+type CreateCourseOfferingResp struct {
+	*Resp
+	Offering *CourseOfferingVO `json:"offering"`
+}
+
+type UpdateCourseOfferingReq struct {
+	OfferingID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	CourseOfferingInfo
+}
+
+type UpdateCourseOfferingResp struct {
+	*Resp
+	Offering *CourseOfferingVO `json:"offering"`
+}
+
+type DeleteCourseOfferingReq struct {
+	OfferingID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type DeleteCourseOfferingResp struct {
+	*Resp
+	Deleted bool `json:"deleted"`
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import "time"
+
+// RelatedCourseVO 关系另一端的课程
+type RelatedCourseVO struct {
+	RelationID string `json:"relationId,omitempty"` // 间接等价的课程没有直接关系
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Code       string `json:"code"`
+	Department string `json:"department"`
+}
+
+// CourseRelationsVO 课程已生效的关系，按另一端课程分类
+type CourseRelationsVO struct {
+	Prerequisites []*RelatedCourseVO `json:"prerequisites"` // 本课程的先修课
+	RequiredBy    []*RelatedCourseVO `json:"requiredBy"`    // 以本课程为先修课的课程
+	FollowUps     []*RelatedCourseVO `json:"followUps"`     // 学完本课程后推荐学习的课程
+	FollowedFrom  []*RelatedCourseVO `json:"followedFrom"`  // 推荐在其后学习本课程的课程
+	Equivalents   []*RelatedCourseVO `json:"equivalents"`   // 等价课程，包括经其他课程间接等价的
+}
+
+// CourseRelationVO 一条课程关系
+type CourseRelationVO struct {
+	ID           string           `json:"id"`
+	Type         string           `json:"type"` // prerequisite: from 是 to 的先修课；equivalent: 等价；followup: 学完 from 后推荐学习 to
+	From         *RelatedCourseVO `json:"from"`
+	To           *RelatedCourseVO `json:"to"`
+	Status       string           `json:"status"` // pending / approved / rejected
+	Note         string           `json:"note,omitempty"`
+	RejectReason string           `json:"rejectReason,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt"`
+}
+
+type ListCourseRelationsReq struct {
+	CourseID string `form:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type ListCourseRelationsResp struct {
+	*Resp
+	Relations *CourseRelationsVO  `json:"relations"`
+	Pending   []*CourseRelationVO `json:"pending"` // 待审核的关系，管理员可见全部，普通用户只能看到自己提议的
+}
+
+// CreateCourseRelationReq 为课程添加关系，管理员添加直接生效，普通用户添加需管理员审核
+// Type 为 prerequisite 时 TargetID 是本课程的先修课，为 followup 时 TargetID 是本课程的后续课程
+type CreateCourseRelationReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	TargetID string `json:"targetId" binding:"required"`
+	Type     string `json:"type" binding:"required,oneof=prerequisite equivalent followup"`
+	Note     string `json:"note" binding:"max=200"`
+}
+
+type CreateCourseRelationResp struct {
+	*Resp
+	Relation *CourseRelationVO `json:"relation"`
+}
+
+type ListPendingCourseRelationsReq struct {
+	*PageParam
+}
+
+type ListPendingCourseRelationsResp struct {
+	*Resp
+	Total     int64               `json:"total"`
+	Relations []*CourseRelationVO `json:"relations"`
+}
+
+type ApproveCourseRelationReq struct {
+	RelationID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type ApproveCourseRelationResp struct {
+	*Resp
+	Relation *CourseRelationVO `json:"relation"`
+}
+
+type RejectCourseRelationReq struct {
+	RelationID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	Reason     string `json:"reason" binding:"max=200"`
+}
+
+type RejectCourseRelationResp struct {
+	*Resp
+	Relation *CourseRelationVO `json:"relation"`
+}
+
+// DeleteCourseRelationReq 删除关系，管理员可删除任意关系，普通用户只能撤回自己待审核的提议
+type DeleteCourseRelationReq struct {
+	RelationID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type DeleteCourseRelationResp struct {
+	*Resp
+	Deleted bool `json:"deleted"`
+}
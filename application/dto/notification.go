@@ -0,0 +1,80 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import "time"
+
+type NotificationVO struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	ActorID   string    `json:"actorId"`
+	TargetID  string    `json:"targetId"`
+	Content   string    `json:"content"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateNotificationReq 其他服务发送通知时使用的内部请求参数
+type CreateNotificationReq struct {
+	UserID   string // 接收者
+	Type     string
+	TargetID string
+	Content  string
+	EventKey string // 去重键，同一接收者同一去重键只通知一次；为空时使用事件的分发键
+}
+
+type ListNotificationsReq struct {
+	UnreadOnly bool `form:"unreadOnly"`
+	*PageParam
+}
+
+type ListNotificationsResp struct {
+	*Resp
+	Total         int64             `json:"total"`
+	UnreadCnt     int64             `json:"unreadCnt"`
+	Notifications []*NotificationVO `json:"notifications"`
+}
+
+type MarkNotificationReadReq struct {
+	NotificationID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type MarkNotificationReadResp struct {
+	*Resp
+	UnreadCnt int64 `json:"unreadCnt"`
+}
+
+type MarkAllNotificationsReadReq struct{}
+
+type MarkAllNotificationsReadResp struct {
+	*Resp
+	Marked int64 `json:"marked"`
+}
+
+type GetNotificationSettingReq struct{}
+
+type GetNotificationSettingResp struct {
+	*Resp
+	MutedTypes []string `json:"mutedTypes"`
+}
+
+type UpdateNotificationSettingReq struct {
+	MutedTypes []string `json:"mutedTypes"` // 需要屏蔽的通知类型，覆盖原有设置
+}
+
+type UpdateNotificationSettingResp struct {
+	*Resp
+	MutedTypes []string `json:"mutedTypes"`
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+// PlanOfferingVO 选课计划中的一个开设及其所属课程
+type PlanOfferingVO struct {
+	CourseName string            `json:"courseName"`
+	CourseCode string            `json:"courseCode"`
+	Offering   *CourseOfferingVO `json:"offering"`
+}
+
+// PlanConflictVO 两个开设的上课时间冲突，Weeks 为同时上课的周次
+type PlanConflictVO struct {
+	OfferingIDs []string `json:"offeringIds"`
+	Day         int32    `json:"day"`
+	StartPeriod int32    `json:"startPeriod"`
+	EndPeriod   int32    `json:"endPeriod"`
+	Weeks       []int32  `json:"weeks"`
+}
+
+// GetPlanTimetableReq 查询选课计划的课表，Semester 为空时为当前学期
+type GetPlanTimetableReq struct {
+	Semester string `form:"semester"`
+}
+
+type GetPlanTimetableResp struct {
+	*Resp
+	Semester  string            `json:"semester"`
+	Periods   []string          `json:"periods"` // 每节课的起止时间
+	Offerings []*PlanOfferingVO `json:"offerings"`
+	Grid      [][][]string      `json:"grid"` // grid[星期-1][节次-1] 为该时间上课的开设ID
+	Conflicts []*PlanConflictVO `json:"conflicts"`
+}
+
+type AddPlanOfferingReq struct {
+	OfferingID string `json:"offeringId" binding:"required"`
+}
+
+type AddPlanOfferingResp struct {
+	*Resp
+	Semester  string            `json:"semester"`
+	Conflicts []*PlanConflictVO `json:"conflicts"` // 新加入的开设与计划中其他开设的冲突，不阻止加入
+}
+
+type RemovePlanOfferingReq struct {
+	OfferingID string `json:"offeringId" binding:"required"`
+}
+
+type RemovePlanOfferingResp struct {
+	*Resp
+	Removed bool `json:"removed"`
+}
+
+// ExportPlanICSReq 导出选课计划的日历文件，Semester 为空时为当前学期
+type ExportPlanICSReq struct {
+	Semester string `form:"semester"`
+}
@@ -27,11 +27,33 @@ type ProposalCourseVO struct {
 	Teachers   []*TeacherVO  `json:"teachers"`
 }
 
-// CreateProposalReq 新增投票请求参数
+// CourseChangeVO 修改提案对现有课程的字段级修改，不传（列表为空）的字段表示不变
+type CourseChangeVO struct {
+	Name       *string      `json:"name,omitempty"`
+	Code       *string      `json:"code,omitempty"`
+	Department *string      `json:"department,omitempty"`
+	Category   *string      `json:"category,omitempty"`
+	Campuses   []string     `json:"campuses,omitempty"`
+	Teachers   []*TeacherVO `json:"teachers,omitempty"`
+	Retired    bool         `json:"retired,omitempty"` // 课程不再开设，为 true 时忽略其他字段
+}
+
+// TeacherChangeVO 教师更正提案的字段级修改，不传的字段表示不变
+type TeacherChangeVO struct {
+	Name       *string `json:"name,omitempty"`
+	Title      *string `json:"title,omitempty"`
+	Department *string `json:"department,omitempty"`
+}
+
+// CreateProposalReq 新增投票请求参数，传 courseId 时为修改现有课程的提案，传 teacherId 时为更正教师信息的提案
 type CreateProposalReq struct {
-	Title   string            `json:"title" binding:"required"`
-	Content string            `json:"content" binding:"required"`
-	Course  *ProposalCourseVO `json:"course" binding:"required"`
+	Title         string            `json:"title" binding:"required"`
+	Content       string            `json:"content" binding:"required"`
+	Course        *ProposalCourseVO `json:"course"`        // 新增课程提案必传
+	CourseID      string            `json:"courseId"`      // 修改提案针对的课程ID
+	Change        *CourseChangeVO   `json:"change"`        // 修改提案必传
+	TeacherID     string            `json:"teacherId"`     // 教师更正提案针对的教师ID
+	TeacherChange *TeacherChangeVO  `json:"teacherChange"` // 教师更正提案必传
 }
 
 // CreateProposalResp 新增投票响应
@@ -46,7 +68,7 @@ type ProposalVO struct {
 	UserID       string            `json:"userId"`
 	Title        string            `json:"title"`
 	Content      string            `json:"content"`
-	Status       string            `json:"status"` // pending / approved / rejected
+	Status       string            `json:"status"` // pending / approved / rejected / needsInfo
 	Deleted      bool              `json:"deleted"`
 	RejectReason string            `json:"rejectReason"` // 拒绝理由
 	*LikeVO                        // 前端暂时忽略
@@ -56,11 +78,24 @@ type ProposalVO struct {
 	Contribution int64             `json:"contribution,omitempty"` // 贡献值信息（新增）
 	CreatedAt    time.Time         `json:"createdAt"`
 	UpdatedAt    time.Time         `json:"updatedAt"`
+
+	Type     string                 `json:"type"`               // add / change / teacher
+	CourseID string                 `json:"courseId,omitempty"` // 修改提案针对的课程ID
+	Change   *CourseChangeVO        `json:"change,omitempty"`
+	Before   *ProposalCourseVO      `json:"before,omitempty"` // 修改前的课程
+	Diff     []*CourseFieldChangeVO `json:"diff,omitempty"`   // 修改或更正前后的字段对比
+
+	TeacherID     string           `json:"teacherId,omitempty"` // 教师更正提案针对的教师ID
+	Teacher       *TeacherVO       `json:"teacher,omitempty"`   // 更正后的教师
+	TeacherChange *TeacherChangeVO `json:"teacherChange,omitempty"`
+	TeacherBefore *TeacherVO       `json:"teacherBefore,omitempty"` // 更正前的教师
+
+	Comments []*ProposalCommentVO `json:"comments,omitempty"` // 讨论，仅详情接口返回
 }
 
 // ListProposalReq 对应 /api/proposal/list 的请求体（分页）
 type ListProposalReq struct {
-	Status string `json:"status"` // pending / approved / rejected / 空则为全部
+	Status string `json:"status"` // pending / approved / rejected / needsInfo / 空则为全部
 	*PageParam
 }
 
@@ -103,6 +138,9 @@ type RejectProposalResp struct {
 type ToggleProposalReq struct {
 	ProposalID  string            `json:"proposalID"`
 	FinalCourse *ProposalCourseVO `json:"finalCourse"` // 管理员最终确认的课程信息，不传则用提案原始课程
+	FinalChange *CourseChangeVO   `json:"finalChange"` // 修改提案中管理员最终确认的修改，不传则用提案原始修改
+
+	FinalTeacherChange *TeacherChangeVO `json:"finalTeacherChange"` // 教师更正提案中管理员最终确认的修改，不传则用提案原始修改
 }
 
 type ToggleProposalResp struct {
@@ -138,7 +176,10 @@ type UpdateProposalReq struct {
 	ProposalID string            `json:"-"`
 	Title      string            `json:"title" binding:"required"`
 	Content    string            `json:"content" binding:"required"`
-	Course     *ProposalCourseVO `json:"course" binding:"required"`
+	Course     *ProposalCourseVO `json:"course"`
+	Change     *CourseChangeVO   `json:"change"` // 修改提案更新修改内容，不能改变针对的课程
+
+	TeacherChange *TeacherChangeVO `json:"teacherChange"` // 教师更正提案更新修改内容，不能改变针对的教师
 }
 
 // UpdateProposalResp 更新提案响应参数
@@ -161,8 +202,9 @@ type GetProposalSuggestionsResp struct {
 
 // ProposalSuggestionsVO 提案搜索建议视图对象
 type ProposalSuggestionsVO struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Highlight string `json:"highlight,omitempty"` // 使用内存索引时返回，命中部分以 <em></em> 包裹
 }
 
 // GetProposalFieldSuggestionsReq 获取提案字段建议请求
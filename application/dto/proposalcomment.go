@@ -0,0 +1,66 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import "time"
+
+// ProposalCommentVO 提案讨论，顶层评论的回复放在 Replies 中
+type ProposalCommentVO struct {
+	ID            string               `json:"id"`
+	ProposalID    string               `json:"proposalId"`
+	UserID        string               `json:"userId"`
+	ParentID      string               `json:"parentId,omitempty"`
+	ReplyToUserID string               `json:"replyToUserId,omitempty"`
+	Content       string               `json:"content"`
+	Internal      bool                 `json:"internal"` // 管理员内部备注
+	IsAuthor      bool                 `json:"isAuthor"` // 是否为提案作者发表
+	CreatedAt     time.Time            `json:"createdAt"`
+	Replies       []*ProposalCommentVO `json:"replies,omitempty"`
+}
+
+// CreateProposalCommentReq 对应 /api/proposal/:proposalId/comment 的请求体
+type CreateProposalCommentReq struct {
+	ProposalID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	Content    string `json:"content" binding:"required,max=500"`
+	ParentID   string `json:"parentId"` // 回复的评论ID，为空表示发表顶层评论
+	Internal   bool   `json:"internal"` // 是否为内部备注，仅管理员可用
+}
+
+type CreateProposalCommentResp struct {
+	*Resp
+	Comment *ProposalCommentVO `json:"comment"`
+}
+
+type DeleteProposalCommentReq struct {
+	CommentID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type DeleteProposalCommentResp struct {
+	*Resp
+	CommentID string `json:"commentId"`
+}
+
+// RequestProposalInfoReq 对应 /api/proposal/:proposalId/needinfo 的请求体
+type RequestProposalInfoReq struct {
+	ProposalID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	Question   string `json:"question" binding:"required,max=500"`
+}
+
+type RequestProposalInfoResp struct {
+	*Resp
+	ProposalID string             `json:"proposalId"`
+	Status     string             `json:"status"`
+	Comment    *ProposalCommentVO `json:"comment"` // 以讨论形式发布的问题
+}
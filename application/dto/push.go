@@ -0,0 +1,39 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+// SubscribeTemplateVO 可订阅的消息类型及其模板ID，Quota 为剩余可接收次数
+type SubscribeTemplateVO struct {
+	Type       string `json:"type"`
+	TemplateID string `json:"templateId"`
+	Quota      int64  `json:"quota"`
+}
+
+type GetSubscribeSettingReq struct{}
+
+type GetSubscribeSettingResp struct {
+	*Resp
+	Templates []*SubscribeTemplateVO `json:"templates"`
+}
+
+// RecordSubscribeReq 小程序调用 wx.requestSubscribeMessage 后上报用户同意的消息类型
+type RecordSubscribeReq struct {
+	Types []string `json:"types" binding:"required"`
+}
+
+type RecordSubscribeResp struct {
+	*Resp
+	Templates []*SubscribeTemplateVO `json:"templates"`
+}
@@ -33,8 +33,9 @@ type GetSearchSuggestionsResp struct {
 }
 
 type SearchSuggestionsVO struct {
-	Type string `json:"type"`
-	Name string `json:"name"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Highlight string `json:"highlight,omitempty"` // 使用内存索引时返回，命中部分以 <em></em> 包裹
 }
 
 // SearchHistoryVO 是返回给前端的、单条搜索历史的“视图对象”。
@@ -35,6 +35,38 @@ type CreateTeacherResp struct {
 	*TeacherVO
 }
 
+type UpdateTeacherReq struct {
+	TeacherID  string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	Name       string `json:"name" binding:"required"`
+	Title      string `json:"title" binding:"required"`
+	Department string `json:"department" binding:"required"`
+}
+
+type UpdateTeacherResp struct {
+	*Resp
+	Teacher *TeacherVO `json:"teacher"`
+}
+
+type MergeTeachersReq struct {
+	TargetID  string   `json:"targetId" binding:"required"`        // 保留的教师ID
+	SourceIDs []string `json:"sourceIds" binding:"required,min=1"` // 被合并的重复教师ID
+	DryRun    bool     `json:"dryRun"`
+}
+
+type MergeTeachersResp struct {
+	*Resp
+	Preview *TeacherMergePreviewVO `json:"preview"`
+	Teacher *TeacherVO             `json:"teacher,omitempty"` // 合并后的保留教师，预览时为空
+}
+
+// TeacherMergePreviewVO 教师合并的影响预览
+type TeacherMergePreviewVO struct {
+	TargetID      string   `json:"targetId"`
+	SourceIDs     []string `json:"sourceIds"`
+	CourseCount   int64    `json:"courseCount"`   // 将改为由保留教师授课的课程数
+	OfferingCount int64    `json:"offeringCount"` // 将改为由保留教师授课的开设数
+}
+
 type TeacherVO struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
@@ -51,3 +83,20 @@ type GetTeacherSuggestionsResp struct {
 	*Resp
 	Teachers []*TeacherVO `json:"teachers"`
 }
+
+// GetTeacherReq 获取教师主页，PageParam 用于分页最近评论
+type GetTeacherReq struct {
+	TeacherID string `form:"teacherId"`
+	*PageParam
+}
+
+// GetTeacherResp 教师主页：基本信息、授课课程和这些课程评论的汇总
+type GetTeacherResp struct {
+	*Resp
+	Teacher        *TeacherVO       `json:"teacher"`
+	Courses        []*CourseVO      `json:"courses"`        // 按创建时间倒序，最多 TeacherProfileCourseLimit 门
+	CourseCount    int64            `json:"courseCount"`    // 授课课程总数
+	CommentCount   int64            `json:"commentCount"`   // 全部授课课程的评论数，也是 RecentComments 的分页总数
+	TagCount       map[string]int64 `json:"tagCount"`       // 全部授课课程评论的标签分布
+	RecentComments []*CommentVO     `json:"recentComments"` // 按发布时间倒序，附带所属课程信息
+}
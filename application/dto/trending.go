@@ -0,0 +1,49 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+// GetTrendingCoursesReq 获取热门课程，Department 为院系名称，为空时不区分院系；Limit 默认 10
+type GetTrendingCoursesReq struct {
+	Department string `form:"department"`
+	Limit      int64  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type GetTrendingCoursesResp struct {
+	*Resp
+	Courses []*TrendingCourseVO `json:"courses"` // 按热度降序
+}
+
+// TrendingCourseVO 热门课程及其衰减后的热度
+type TrendingCourseVO struct {
+	Course *CourseVO `json:"course"`
+	Score  float64   `json:"score"` // 保留两位小数
+}
+
+// GetTrendingSearchesReq 获取热门搜索，Department 为搜索时筛选的院系名称，为空时不区分院系；Limit 默认 10
+type GetTrendingSearchesReq struct {
+	Department string `form:"department"`
+	Limit      int64  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type GetTrendingSearchesResp struct {
+	*Resp
+	Searches []*TrendingSearchVO `json:"searches"` // 按热度降序
+}
+
+// TrendingSearchVO 热门搜索关键词，关键词已转为小写并合并空白
+type TrendingSearchVO struct {
+	Keyword string  `json:"keyword"`
+	Score   float64 `json:"score"` // 保留两位小数
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import "time"
+
+// WatchedCourseVO 关注列表中的课程，UnreadCnt 为上次查看之后新增的评论数
+type WatchedCourseVO struct {
+	Course     *CourseVO `json:"course"`
+	UnreadCnt  int64     `json:"unreadCnt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	WatchedAt  time.Time `json:"watchedAt"`
+}
+
+type AddWatchReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type AddWatchResp struct {
+	*Resp
+	Watched bool `json:"watched"`
+}
+
+type RemoveWatchReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type RemoveWatchResp struct {
+	*Resp
+	Watched bool `json:"watched"`
+}
+
+type MarkWatchSeenReq struct {
+	CourseID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type MarkWatchSeenResp struct {
+	*Resp
+}
+
+type ListWatchlistReq struct {
+	*PageParam
+}
+
+type ListWatchlistResp struct {
+	*Resp
+	Total   int64              `json:"total"`
+	Courses []*WatchedCourseVO `json:"courses"`
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dto
+
+import "time"
+
+type WebhookVO struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type WebhookDeliveryVO struct {
+	ID             string    `json:"id"`
+	WebhookID      string    `json:"webhookId"`
+	Event          string    `json:"event"`
+	Payload        string    `json:"payload"`
+	Status         string    `json:"status"` // pending/succeeded/dead
+	Attempts       int32     `json:"attempts"`
+	NextRetryAt    time.Time `json:"nextRetryAt"`
+	LastStatusCode int       `json:"lastStatusCode"`
+	LastError      string    `json:"lastError"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type WebhookAttemptVO struct {
+	ID         string    `json:"id"`
+	DeliveryID string    `json:"deliveryId"`
+	WebhookID  string    `json:"webhookId"`
+	Event      string    `json:"event"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type CreateWebhookReq struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Events      []string `json:"events" binding:"required,min=1"` // 订阅的事件类型，"*" 表示全部
+	Description string   `json:"description"`
+}
+
+type CreateWebhookResp struct {
+	*Resp
+	Webhook *WebhookVO `json:"webhook"`
+	Secret  string     `json:"secret"` // 签名密钥，仅在创建时返回
+}
+
+type ListWebhooksReq struct {
+	*PageParam
+}
+
+type ListWebhooksResp struct {
+	*Resp
+	Total    int64        `json:"total"`
+	Webhooks []*WebhookVO `json:"webhooks"`
+}
+
+type UpdateWebhookReq struct {
+	WebhookID   string   `json:"-" swaggerignore:"true"` // 从 URL path 获取
+	URL         string   `json:"url" binding:"omitempty,url"`
+	Events      []string `json:"events"`
+	Description *string  `json:"description"`
+	Active      *bool    `json:"active"`
+}
+
+type UpdateWebhookResp struct {
+	*Resp
+	Webhook *WebhookVO `json:"webhook"`
+}
+
+type DeleteWebhookReq struct {
+	WebhookID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type DeleteWebhookResp struct {
+	*Resp
+	Deleted bool `json:"deleted"`
+}
+
+type ListWebhookDeliveriesReq struct {
+	WebhookID string `form:"webhookId"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending succeeded dead"` // dead 即死信列表
+	*PageParam
+}
+
+type ListWebhookDeliveriesResp struct {
+	*Resp
+	Total      int64                `json:"total"`
+	Deliveries []*WebhookDeliveryVO `json:"deliveries"`
+}
+
+type RedeliverWebhookReq struct {
+	DeliveryID string `json:"-" swaggerignore:"true"` // 从 URL path 获取
+}
+
+type RedeliverWebhookResp struct {
+	*Resp
+	Requeued bool `json:"requeued"`
+}
+
+type ListWebhookAttemptsReq struct {
+	WebhookID  string `form:"webhookId"`
+	DeliveryID string `form:"deliveryId"`
+	*PageParam
+}
+
+type ListWebhookAttemptsResp struct {
+	*Resp
+	Total    int64               `json:"total"`
+	Attempts []*WebhookAttemptVO `json:"attempts"`
+}
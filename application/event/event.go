@@ -0,0 +1,125 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package event 定义服务发布到事件总线的领域事件
+// 事件会在开启 outbox 时序列化存储，字段需可 JSON 序列化
+package event
+
+import (
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+// 事件名称
+const (
+	NameProposalCreated   = "proposal.created"
+	NameProposalApproved  = "proposal.approved"
+	NameProposalRejected  = "proposal.rejected"
+	NameProposalRevoked   = "proposal.revoked"
+	NameProposalNeedsInfo = "proposal.needs_info"
+	NameCourseCreated     = "course.created"
+	NameCommentCreated    = "comment.created"
+	NameLikeToggled       = "like.toggled"
+	NameCourseViewed      = "course.viewed"
+	NameSearchLogged      = "search.logged"
+)
+
+// ProposalCreated 用户创建提案
+type ProposalCreated struct {
+	Proposal *model.Proposal `json:"proposal"`
+}
+
+func (ProposalCreated) EventName() string { return NameProposalCreated }
+
+// ProposalApproved 管理员审批通过提案，FinalCourse 为审批确认的最终课程信息
+// 修改提案的 FinalChange 与教师更正提案的 FinalTeacherChange 为实际生效的修改
+type ProposalApproved struct {
+	Proposal    *model.Proposal       `json:"proposal"`
+	FinalCourse *dto.ProposalCourseVO `json:"finalCourse"`
+	FinalChange *dto.CourseChangeVO   `json:"finalChange,omitempty"`
+	ReviewerID  string                `json:"reviewerId"`
+
+	FinalTeacherChange *dto.TeacherChangeVO `json:"finalTeacherChange,omitempty"`
+}
+
+func (ProposalApproved) EventName() string { return NameProposalApproved }
+
+// ProposalRejected 管理员驳回提案
+type ProposalRejected struct {
+	Proposal   *model.Proposal `json:"proposal"`
+	ReviewerID string          `json:"reviewerId"`
+	Reason     string          `json:"reason"`
+}
+
+func (ProposalRejected) EventName() string { return NameProposalRejected }
+
+// ProposalRevoked 管理员撤回提案的审批结果，提案回到待审核，ActionType 为撤回的审批操作
+type ProposalRevoked struct {
+	Proposal   *model.Proposal `json:"proposal"`
+	ReviewerID string          `json:"reviewerId"`
+	ActionType string          `json:"actionType"`
+}
+
+func (ProposalRevoked) EventName() string { return NameProposalRevoked }
+
+// ProposalNeedsInfo 管理员要求作者补充信息，Question 为发布到讨论中的问题
+type ProposalNeedsInfo struct {
+	Proposal   *model.Proposal `json:"proposal"`
+	ReviewerID string          `json:"reviewerId"`
+	Question   string          `json:"question"`
+}
+
+func (ProposalNeedsInfo) EventName() string { return NameProposalNeedsInfo }
+
+// CourseCreated 通过提案审批新建了课程
+type CourseCreated struct {
+	Course     *model.Course `json:"course"`
+	ProposalID string        `json:"proposalId"`
+}
+
+func (CourseCreated) EventName() string { return NameCourseCreated }
+
+// CommentCreated 用户发布吐槽
+type CommentCreated struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+func (CommentCreated) EventName() string { return NameCommentCreated }
+
+// LikeToggled 用户点赞或取消点赞
+type LikeToggled struct {
+	UserID     string `json:"userId"`
+	TargetID   string `json:"targetId"`
+	TargetType string `json:"targetType"`
+	Active     bool   `json:"active"` // true 为点赞，false 为取消点赞
+}
+
+func (LikeToggled) EventName() string { return NameLikeToggled }
+
+// CourseViewed 用户查看课程详情，CourseID 为合并重定向后的课程
+type CourseViewed struct {
+	UserID   string `json:"userId"`
+	CourseID string `json:"courseId"`
+}
+
+func (CourseViewed) EventName() string { return NameCourseViewed }
+
+// SearchLogged 用户搜索关键词，Department 为搜索时筛选的院系名称，未筛选时为空
+type SearchLogged struct {
+	UserID     string `json:"userId"`
+	Query      string `json:"query"`
+	Department string `json:"department"`
+}
+
+func (SearchLogged) EventName() string { return NameSearchLogged }
@@ -244,3 +244,20 @@ func (s *AuthService) GrantAdmin(ctx context.Context, req *dto.GrantAdminReq) (r
 		IsAdmin: newAdminStatus, // 返回操作后的状态
 	}, nil
 }
+
+// requireAdmin 校验当前登录用户为管理员，返回用户ID
+func requireAdmin(ctx context.Context, userRepo *repo.UserRepo) (string, error) {
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return "", errorx.New(errno.ErrUserNotLogin)
+	}
+	isAdmin, err := userRepo.IsAdminByID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+		return "", errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", userId))
+	}
+	if !isAdmin {
+		return "", errorx.New(errno.ErrUserNotAdmin, errorx.KV("userId", userId))
+	}
+	return userId, nil
+}
@@ -105,6 +105,8 @@ func (s *ChangeLogService) ListChangeLogs(ctx context.Context, req *dto.ListChan
 			UserID:       cl.UserID,
 			UpdateSource: cl.UpdateSource,
 			ProposalID:   cl.ProposalID,
+			Before:       cl.Before,
+			After:        cl.After,
 			UpdatedAt:    cl.UpdatedAt,
 		}
 	}
@@ -136,6 +138,8 @@ func (s *ChangeLogService) CreateChangeLog(ctx context.Context, req *dto.CreateC
 		UpdateSource: req.UpdateSource,
 		ProposalID:   req.ProposalID,
 		UserID:       userId,
+		Before:       req.Before,
+		After:        req.After,
 		UpdatedAt:    now,
 	}
 
@@ -469,6 +473,8 @@ func (s *ChangeLogService) getActionTypeName(action int32) string {
 		return "approve"
 	case consts.ActionTypeRejectProposal:
 		return "reject"
+	case consts.ActionTypeRequestProposalInfo:
+		return "needsInfo"
 	default:
 		return "unknown"
 	}
@@ -489,6 +495,8 @@ func (s *ChangeLogService) getTimelineActionType(action int32) string {
 		return "APPROVE"
 	case consts.ActionTypeRejectProposal:
 		return "REJECT"
+	case consts.ActionTypeRequestProposalInfo:
+		return "NEEDS_INFO"
 	case consts.ActionTypeCreateProposal:
 		return "CREATE"
 	default:
@@ -20,9 +20,11 @@ import (
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
 	"github.com/Boyuan-IT-Club/go-kit/errorx"
@@ -41,9 +43,11 @@ type ICommentService interface {
 }
 
 type CommentService struct {
-	CommentRepo      *repo.CommentRepo
-	CommentCache     *cache.CommentCache
-	CommentAssembler *assembler.CommentAssembler
+	CommentRepo        *repo.CommentRepo
+	CourseOfferingRepo *repo.CourseOfferingRepo
+	CommentCache       *cache.CommentCache
+	CommentAssembler   *assembler.CommentAssembler
+	EventBus           *eventbus.Bus
 }
 
 var CommentServiceSet = wire.NewSet(
@@ -59,16 +63,35 @@ func (s *CommentService) CreateComment(ctx context.Context, req *dto.CreateComme
 		return nil, errorx.New(errno.ErrUserNotLogin)
 	}
 
+	// 关联的开设必须属于该课程
+	if req.OfferingID != "" {
+		offering, err := s.CourseOfferingRepo.FindByID(ctx, req.OfferingID)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindByID] error: %v, offeringId: %s", err, req.OfferingID)
+			return nil, errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+				errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+		}
+		if offering == nil || offering.Deleted {
+			return nil, errorx.New(errno.ErrOfferingNotFound,
+				errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+		}
+		if offering.CourseID != req.CourseID {
+			return nil, errorx.New(errno.ErrOfferingCourseMismatch,
+				errorx.KV("offeringId", req.OfferingID), errorx.KV("courseId", req.CourseID))
+		}
+	}
+
 	// 构建Comment模型
 	now := time.Now()
 	comment := &model.Comment{
-		UserID:    userId,
-		CourseID:  req.CourseID,
-		Content:   req.Content,
-		Tags:      req.Tags,
-		CreatedAt: now,
-		UpdatedAt: now,
-		Deleted:   false,
+		UserID:     userId,
+		CourseID:   req.CourseID,
+		Content:    req.Content,
+		Tags:       req.Tags,
+		OfferingID: req.OfferingID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Deleted:    false,
 	}
 
 	// 插入数据库
@@ -77,6 +100,10 @@ func (s *CommentService) CreateComment(ctx context.Context, req *dto.CreateComme
 		return nil, errorx.WrapByCode(err, errno.ErrCommentInsertFailed, errorx.KV("content", req.Content))
 	}
 
+	if err := s.EventBus.Publish(ctx, event.CommentCreated{Comment: comment}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, courseId: %s", err, req.CourseID)
+	}
+
 	// 转换为VO
 	vo, err := s.CommentAssembler.ToCommentVO(ctx, comment, userId)
 	if err != nil {
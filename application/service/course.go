@@ -16,17 +16,27 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/homonym"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
 	"github.com/Boyuan-IT-Club/go-kit/errorx"
 	"github.com/Boyuan-IT-Club/go-kit/logs"
 	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var _ ICourseService = (*CourseService)(nil)
@@ -38,12 +48,50 @@ type ICourseService interface {
 	GetDepartments(ctx context.Context, req *dto.GetCourseDepartmentsReq) (*dto.GetCourseDepartmentsResp, error)
 	GetCategories(ctx context.Context, req *dto.GetCourseCategoriesReq) (*dto.GetCourseCategoriesResp, error)
 	GetCampuses(ctx context.Context, req *dto.GetCourseCampusesReq) (*dto.GetCourseCampusesResp, error)
+	GetCourseStats(ctx context.Context, req *dto.GetCourseStatsReq) (*dto.GetCourseStatsResp, error)
+	GetRecommendations(ctx context.Context, req *dto.GetCourseRecommendationsReq) (*dto.GetCourseRecommendationsResp, error)
+	CompareCourses(ctx context.Context, req *dto.CompareCoursesReq) (*dto.CompareCoursesResp, error)
+	ListCourseOfferings(ctx context.Context, req *dto.ListCourseOfferingsReq) (*dto.ListCourseOfferingsResp, error)
+	ListCourseRelations(ctx context.Context, req *dto.ListCourseRelationsReq) (*dto.ListCourseRelationsResp, error)
+
+	CreateCourse(ctx context.Context, req *dto.CreateCourseReq) (*dto.CreateCourseResp, error)
+	UpdateCourse(ctx context.Context, req *dto.UpdateCourseReq) (*dto.UpdateCourseResp, error)
+	DeleteCourse(ctx context.Context, req *dto.DeleteCourseReq) (*dto.DeleteCourseResp, error)
+	RestoreCourse(ctx context.Context, req *dto.RestoreCourseReq) (*dto.RestoreCourseResp, error)
+	MergeCourses(ctx context.Context, req *dto.MergeCoursesReq) (*dto.MergeCoursesResp, error)
+	ImportCourses(ctx context.Context, req *dto.ImportCoursesReq) (*dto.ImportCoursesResp, error)
+	ExportCourses(ctx context.Context, req *dto.ExportCoursesReq, w io.Writer) error
+	SearchCourses(ctx context.Context, req *dto.SearchCoursesReq) (*dto.SearchCoursesResp, error)
+	CreateCourseOffering(ctx context.Context, req *dto.CreateCourseOfferingReq) (*dto.CreateCourseOfferingResp, error)
+	UpdateCourseOffering(ctx context.Context, req *dto.UpdateCourseOfferingReq) (*dto.UpdateCourseOfferingResp, error)
+	DeleteCourseOffering(ctx context.Context, req *dto.DeleteCourseOfferingReq) (*dto.DeleteCourseOfferingResp, error)
+	CreateCourseRelation(ctx context.Context, req *dto.CreateCourseRelationReq) (*dto.CreateCourseRelationResp, error)
+	ListPendingCourseRelations(ctx context.Context, req *dto.ListPendingCourseRelationsReq) (*dto.ListPendingCourseRelationsResp, error)
+	ApproveCourseRelation(ctx context.Context, req *dto.ApproveCourseRelationReq) (*dto.ApproveCourseRelationResp, error)
+	RejectCourseRelation(ctx context.Context, req *dto.RejectCourseRelationReq) (*dto.RejectCourseRelationResp, error)
+	DeleteCourseRelation(ctx context.Context, req *dto.DeleteCourseRelationReq) (*dto.DeleteCourseRelationResp, error)
 }
 
 type CourseService struct {
-	CourseRepo      *repo.CourseRepo
-	TeacherRepo     *repo.TeacherRepo
-	CourseAssembler *assembler.CourseAssembler
+	CourseRepo              *repo.CourseRepo
+	TeacherRepo             *repo.TeacherRepo
+	UserRepo                *repo.UserRepo
+	CommentRepo             *repo.CommentRepo
+	LikeRepo                *repo.LikeRepo
+	SearchHistoryRepo       *repo.SearchHistoryRepo
+	CourseOfferingRepo      *repo.CourseOfferingRepo
+	CourseRelationRepo      *repo.CourseRelationRepo
+	WatchlistRepo           *repo.WatchlistRepo
+	PlanRepo                *repo.PlanRepo
+	CourseAssembler         *assembler.CourseAssembler
+	CommentAssembler        *assembler.CommentAssembler
+	TeacherAssembler        *assembler.TeacherAssembler
+	CourseOfferingAssembler *assembler.CourseOfferingAssembler
+	CourseRelationAssembler *assembler.CourseRelationAssembler
+	CourseCache             *cache.CourseCache
+	ChangeLogService        IChangeLogService
+	EventBus                *eventbus.Bus
+	SearchIndexer           *SearchIndexer
 }
 
 var CourseServiceSet = wire.NewSet(
@@ -52,8 +100,8 @@ var CourseServiceSet = wire.NewSet(
 )
 
 // ListCourses 返回课程的分页结果
-// 当req.Type为"course"时，模糊分页搜索课程
-// 当req.Type为"teacher"时，精确分页搜索教师开设的课程
+// 当req.Type为"course"时，模糊分页搜索课程，内存索引可用时优先使用索引
+// 当req.Type为"teacher"时，精确分页搜索同名教师开设的课程，并返回这些教师用于跳转教师主页，指定教师ID时只搜索该教师
 // 当req.Type为"category"时，精确分页搜索该类别下的课程
 // 当req.Type为"department"时，精确分页搜索该开课院系下的课程
 func (s *CourseService) ListCourses(ctx context.Context, req *dto.ListCoursesReq) (*dto.ListCoursesResp, error) {
@@ -67,20 +115,35 @@ func (s *CourseService) ListCourses(ctx context.Context, req *dto.ListCoursesReq
 	var err error
 	var total int64
 	var courses []*model.Course
+	var teachers []*model.Teacher
 	switch req.Type {
 	case consts.ReqCourse:
+		if s.SearchIndexer.Ready() {
+			courses, total, err = s.findCoursesByIndex(ctx, req.Keyword, req.PageParam)
+			if err != nil {
+				logs.CtxErrorf(ctx, "[CourseService] [findCoursesByIndex] error: %v", err)
+				return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed, errorx.KV("name", req.Keyword))
+			}
+			break
+		}
 		courses, total, err = s.CourseRepo.FindManyByNameLike(ctx, req.Keyword, req.PageParam)
 		if err != nil {
 			logs.CtxErrorf(ctx, "[CourseRepo] [FindManyByNameLike] error: %v", err)
 			return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed, errorx.KV("name", req.Keyword))
 		}
 	case consts.ReqTeacher:
-		tid, err := s.TeacherRepo.GetIDByName(ctx, req.Keyword)
+		if teachers, err = s.findTeachers(ctx, req.Keyword, req.TeacherID); err != nil {
+			return nil, err
+		}
+		if len(teachers) == 0 {
+			break
+		}
+		courses, total, err = s.CourseRepo.FindManyByTeacherIDs(ctx, teacherIDs(teachers), req.PageParam)
 		if err != nil {
-			logs.CtxErrorf(ctx, "[CourseRepo] [GetIDByName] error: %v", err)
-			return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", req.Keyword))
+			logs.CtxErrorf(ctx, "[CourseRepo] [FindManyByTeacherIDs] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed,
+				errorx.KV("key", consts.ReqTeacher), errorx.KV("value", req.Keyword))
 		}
-		courses, total, err = s.CourseRepo.FindManyByTeacherID(ctx, tid, req.PageParam)
 	case consts.ReqCategory:
 		cid := mapping.Data.GetCategoryIDByName(req.Keyword)
 		courses, total, err = s.CourseRepo.FindManyByCategoryID(ctx, cid, req.PageParam)
@@ -118,6 +181,77 @@ func (s *CourseService) ListCourses(ctx context.Context, req *dto.ListCoursesReq
 	return &dto.ListCoursesResp{
 		Resp:             dto.Success(),
 		PaginatedCourses: pcs,
+		Teachers:         s.TeacherAssembler.ToTeacherVOArray(ctx, teachers),
+	}, nil
+}
+
+// SearchCourses 按名称关键词、教师、院系、类别、校区和标签组合搜索课程，按教师搜索时返回命中的同名教师用于跳转教师主页
+// 教师或映射名称不存在时与 ListCourses 一样返回空结果，关键词精确命中课程名称或代码时结果还包含其等价课程
+func (s *CourseService) SearchCourses(ctx context.Context, req *dto.SearchCoursesReq) (*dto.SearchCoursesResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 将名称解析为ID
+	var teachers []*model.Teacher
+	filter := &repo.CourseFilter{Keyword: req.Keyword, Tags: req.Tags}
+	matchable := true
+	if req.Teacher != "" || req.TeacherID != "" {
+		var err error
+		if teachers, err = s.findTeachers(ctx, req.Teacher, req.TeacherID); err != nil {
+			return nil, err
+		}
+		filter.TeacherIDs = teacherIDs(teachers)
+		matchable = matchable && len(teachers) > 0
+	}
+	if req.Department != "" {
+		filter.DepartmentID = mapping.Data.GetDepartmentIDByName(req.Department)
+		matchable = matchable && filter.DepartmentID != 0
+	}
+	if req.Category != "" {
+		filter.CategoryID = mapping.Data.GetCategoryIDByName(req.Category)
+		matchable = matchable && filter.CategoryID != 0
+	}
+	for _, campus := range req.Campuses {
+		if id := mapping.Data.GetCampusIDByName(campus); id != 0 {
+			filter.CampusIDs = append(filter.CampusIDs, id)
+		}
+	}
+	matchable = matchable && (len(req.Campuses) == 0 || len(filter.CampusIDs) > 0)
+
+	// 精确命中课程名称或代码时，一并返回其等价课程
+	if matchable && req.Keyword != "" {
+		extraIds, err := s.equivalentCourseIDsByKeyword(ctx, req.Keyword)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseService] [equivalentCourseIDsByKeyword] error: %v", err)
+		}
+		filter.ExtraIDs = extraIds
+	}
+
+	var total int64
+	courses := []*model.Course{}
+	if matchable {
+		var err error
+		if courses, total, err = s.CourseRepo.Search(ctx, filter, req.Sort, req.PageParam); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [Search] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed, errorx.KV("name", req.Keyword))
+		}
+	}
+
+	// 转换为分页结果
+	pcs, err := s.CourseAssembler.ToPaginatedCourses(ctx, courses, total, req.PageParam)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToPaginatedCourses] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "paginated courses"))
+	}
+
+	return &dto.SearchCoursesResp{
+		Resp:             dto.Success(),
+		PaginatedCourses: pcs,
+		Teachers:         s.TeacherAssembler.ToTeacherVOArray(ctx, teachers),
 	}, nil
 }
 
@@ -136,6 +270,11 @@ func (s *CourseService) GetCourse(ctx context.Context, req *dto.GetCourseReq) (*
 			errorx.KV("key", consts.CourseID), errorx.KV("value", req.CourseID))
 	}
 
+	// 已合并的课程重定向到保留课程
+	if course, err = s.resolveMerged(ctx, course); err != nil {
+		return nil, err
+	}
+
 	// 转换为VO
 	vo, err := s.CourseAssembler.ToCourseVO(ctx, course)
 	if err != nil {
@@ -144,6 +283,15 @@ func (s *CourseService) GetCourse(ctx context.Context, req *dto.GetCourseReq) (*
 			errorx.KV("src", "database course"), errorx.KV("dst", "course vo"))
 	}
 
+	// 课程关系查询失败不影响课程信息的返回
+	if vo.Relations, err = s.courseRelations(ctx, course.ID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseService] [courseRelations] error: %v, courseId: %s", err, course.ID)
+	}
+
+	if err = s.EventBus.Publish(ctx, event.CourseViewed{UserID: userId, CourseID: course.ID}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, courseId: %s", err, course.ID)
+	}
+
 	return &dto.GetCourseResp{
 		Resp:   dto.Success(),
 		Course: vo,
@@ -228,3 +376,461 @@ func (s *CourseService) GetCampuses(ctx context.Context, req *dto.GetCourseCampu
 		Campuses: campuses,
 	}, nil
 }
+
+// CreateCourse 管理员直接创建课程，不存在的院系、类别、校区和教师会自动注册
+func (s *CourseService) CreateCourse(ctx context.Context, req *dto.CreateCourseReq) (*dto.CreateCourseResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为课程实体
+	course, err := s.CourseAssembler.ToCourseDB(ctx, courseInfoToVO(&req.CourseInfo))
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDB] error: %v", err)
+		return nil, wrapCourseCvtErr(err, errorx.KV("src", "course vo"), errorx.KV("dst", "database course"))
+	}
+
+	// 防止重复创建
+	exists, err := s.CourseRepo.IsCourseInExistingCourses(ctx, course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [IsCourseInExistingCourses] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCreateFailed, errorx.KV("name", req.Name))
+	}
+	if exists {
+		return nil, errorx.New(errno.ErrCourseAlreadyExists, errorx.KV("name", req.Name))
+	}
+
+	now := time.Now()
+	course.ID = primitive.NewObjectID().Hex()
+	course.CreatedAt = now
+	course.UpdatedAt = now
+	if err = s.CourseRepo.Insert(ctx, course); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [Insert] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCreateFailed, errorx.KV("name", req.Name))
+	}
+
+	s.logCourseChange(ctx, course.ID, consts.ActionTypeCreateCourse, "创建课程「"+course.Name+"」", nil, course)
+	if err = s.EventBus.Publish(ctx, event.CourseCreated{Course: course}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, courseId: %s", err, course.ID)
+	}
+
+	vo, err := s.CourseAssembler.ToCourseVO(ctx, course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVO] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "course vo"))
+	}
+
+	return &dto.CreateCourseResp{
+		Resp:   dto.Success(),
+		Course: vo,
+	}, nil
+}
+
+// UpdateCourse 管理员修改课程信息，已删除的课程需先恢复
+func (s *CourseService) UpdateCourse(ctx context.Context, req *dto.UpdateCourseReq) (*dto.UpdateCourseResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if before.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", req.CourseID))
+	}
+
+	// 转换为课程实体，保留创建信息与来源提案
+	vo := courseInfoToVO(&req.CourseInfo)
+	vo.ID = before.ID
+	after, err := s.CourseAssembler.ToCourseDB(ctx, vo)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDB] error: %v", err)
+		return nil, wrapCourseCvtErr(err, errorx.KV("src", "course vo"), errorx.KV("dst", "database course"))
+	}
+	after.ProposalID = before.ProposalID
+	after.CreatedAt = before.CreatedAt
+	after.UpdatedAt = time.Now()
+
+	if err = s.CourseRepo.UpdateCourse(ctx, after); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [UpdateCourse] error: %v, courseId: %s", err, req.CourseID)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseUpdateFailed, errorx.KV("courseId", req.CourseID))
+	}
+
+	s.logCourseChange(ctx, after.ID, consts.ActionTypeUpdateCourse, "修改课程「"+after.Name+"」", before, after)
+
+	courseVO, err := s.CourseAssembler.ToCourseVO(ctx, after)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVO] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "course vo"))
+	}
+
+	return &dto.UpdateCourseResp{
+		Resp:   dto.Success(),
+		Course: courseVO,
+	}, nil
+}
+
+// DeleteCourse 管理员软删除课程
+func (s *CourseService) DeleteCourse(ctx context.Context, req *dto.DeleteCourseReq) (*dto.DeleteCourseResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if before.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", req.CourseID))
+	}
+
+	if err = s.CourseRepo.SoftDeleteByID(ctx, req.CourseID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [SoftDeleteByID] error: %v, courseId: %s", err, req.CourseID)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseDeleteFailed, errorx.KV("courseId", req.CourseID))
+	}
+
+	after := *before
+	after.Deleted = true
+	s.logCourseChange(ctx, before.ID, consts.ActionTypeDeleteCourse, "删除课程「"+before.Name+"」", before, &after)
+
+	return &dto.DeleteCourseResp{
+		Resp:    dto.Success(),
+		Deleted: true,
+	}, nil
+}
+
+// RestoreCourse 管理员恢复已软删除的课程
+func (s *CourseService) RestoreCourse(ctx context.Context, req *dto.RestoreCourseReq) (*dto.RestoreCourseResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !before.Deleted {
+		return nil, errorx.New(errno.ErrCourseNotDeleted, errorx.KV("courseId", req.CourseID))
+	}
+	if before.MergedInto != "" {
+		return nil, errorx.New(errno.ErrCourseMerged,
+			errorx.KV("courseId", req.CourseID), errorx.KV("targetId", before.MergedInto))
+	}
+
+	// UpdateCourse 会将 deleted 置为 false
+	after := *before
+	after.Deleted = false
+	if err = s.CourseRepo.UpdateCourse(ctx, &after); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [UpdateCourse] error: %v, courseId: %s", err, req.CourseID)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseUpdateFailed, errorx.KV("courseId", req.CourseID))
+	}
+
+	s.logCourseChange(ctx, before.ID, consts.ActionTypeRestoreCourse, "恢复课程「"+before.Name+"」", before, &after)
+
+	vo, err := s.CourseAssembler.ToCourseVO(ctx, &after)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVO] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "course vo"))
+	}
+
+	return &dto.RestoreCourseResp{
+		Resp:   dto.Success(),
+		Course: vo,
+	}, nil
+}
+
+// MergeCourses 将重复课程合并到保留课程：迁移评论（点赞随评论迁移）、关注、开设（选课计划随开设迁移）和来源提案，
+// 重复课程软删除并记录 mergedInto 以便旧ID重定向
+func (s *CourseService) MergeCourses(ctx context.Context, req *dto.MergeCoursesReq) (*dto.MergeCoursesResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验保留课程与重复课程
+	target, err := s.findCourse(ctx, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", req.TargetID))
+	}
+	sources := make([]*model.Course, 0, len(req.SourceIDs))
+	sourceIds := make([]string, 0, len(req.SourceIDs))
+	seen := map[string]bool{req.TargetID: true}
+	for _, id := range req.SourceIDs {
+		if seen[id] {
+			return nil, errorx.New(errno.ErrCourseMergeInvalid,
+				errorx.KV("reason", "duplicated or target course id "+id))
+		}
+		seen[id] = true
+		source, err := s.findCourse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if source.Deleted {
+			return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", id))
+		}
+		sources = append(sources, source)
+		sourceIds = append(sourceIds, id)
+	}
+
+	// 统计影响范围
+	commentIds, err := s.CommentRepo.FindIDsByCourseIDs(ctx, sourceIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [FindIDsByCourseIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	var likeCnt int64
+	if len(commentIds) > 0 {
+		cntMap, err := s.LikeRepo.CountByTargets(ctx, commentIds,
+			mapping.Data.GetLikeTargetTypeIDByName(consts.LikeTargetTypeComment))
+		if err != nil {
+			logs.CtxErrorf(ctx, "[LikeRepo] [CountByTargets] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+		}
+		for _, cnt := range cntMap {
+			likeCnt += cnt
+		}
+	}
+
+	// 重复课程的来源提案（包括其此前合并进来的）迁移到保留课程
+	var proposalIds []string
+	for _, source := range sources {
+		if source.ProposalID != "" {
+			proposalIds = append(proposalIds, source.ProposalID)
+		}
+		proposalIds = append(proposalIds, source.MergedProposalIDs...)
+	}
+
+	preview := &dto.CourseMergePreviewVO{
+		TargetID:     req.TargetID,
+		SourceIDs:    sourceIds,
+		CommentCount: int64(len(commentIds)),
+		LikeCount:    likeCnt,
+		ProposalIDs:  proposalIds,
+	}
+	if req.DryRun {
+		return &dto.MergeCoursesResp{
+			Resp:    dto.Success(),
+			Preview: preview,
+		}, nil
+	}
+
+	// 迁移评论，点赞挂在评论上随之迁移
+	if _, err = s.CommentRepo.MoveCourse(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [MoveCourse] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+
+	// 迁移关注，同一用户同时关注了多门课程时只保留一条
+	if _, err = s.WatchlistRepo.MoveCourse(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[WatchlistRepo] [MoveCourse] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+
+	// 同一学期同一校区的开设只保留一个，被并入的开设软删除，评论和选课计划改挂到保留的开设
+	folded, err := s.foldDuplicateOfferings(ctx, req.TargetID, sourceIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseService] [foldDuplicateOfferings] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	for keepId, ids := range folded {
+		if _, err = s.PlanRepo.ReplaceOffering(ctx, ids, keepId); err != nil {
+			logs.CtxErrorf(ctx, "[PlanRepo] [ReplaceOffering] error: %v, offeringId: %s", err, keepId)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+		}
+	}
+
+	// 迁移课程开设，评论上记录的开设ID保持有效
+	if _, err = s.CourseOfferingRepo.MoveCourse(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [MoveCourse] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+
+	// 迁移课程关系，重复课程之间的关系变为两端相同，展示时被忽略
+	if _, err = s.CourseRelationRepo.MoveCourse(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [MoveCourse] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+
+	// 软删除重复课程并记录重定向
+	if err = s.CourseRepo.MergeInto(ctx, sourceIds, req.TargetID, proposalIds); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [MergeInto] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	for _, source := range sources {
+		after := *source
+		after.Deleted = true
+		after.MergedInto = req.TargetID
+		after.ProposalID = ""
+		after.MergedProposalIDs = nil
+		s.logCourseChange(ctx, source.ID, consts.ActionTypeMergeCourse,
+			"课程「"+source.Name+"」合并到课程「"+target.Name+"」", source, &after)
+	}
+
+	// 评论已迁移，清除统计缓存
+	if err = s.CourseCache.DelStats(ctx, append(sourceIds, req.TargetID)...); err != nil {
+		logs.CtxErrorf(ctx, "[CourseCache] [DelStats] error: %v, courseId: %s", err, req.TargetID)
+	}
+
+	after := *target
+	after.MergedProposalIDs = mergeProposalIDs(target.MergedProposalIDs, proposalIds)
+	s.logCourseChange(ctx, target.ID, consts.ActionTypeMergeCourse,
+		"合并重复课程到「"+target.Name+"」", target, &after)
+
+	// 开设已迁移，重新计算保留课程的教师和校区
+	vo, err := s.CourseAssembler.ToCourseVO(ctx, s.syncCourseCatalog(ctx, &after))
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVO] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "course vo"))
+	}
+
+	return &dto.MergeCoursesResp{
+		Resp:    dto.Success(),
+		Preview: preview,
+		Course:  vo,
+	}, nil
+}
+
+// mergeProposalIDs 将新迁移的来源提案ID追加到已有列表，保持顺序并去重
+func mergeProposalIDs(existing, added []string) []string {
+	merged := slices.Clone(existing)
+	for _, id := range added {
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+// findCoursesByIndex 从内存全文索引检索课程ID并按相关度顺序加载课程，索引中残留的已删除课程会被跳过
+func (s *CourseService) findCoursesByIndex(ctx context.Context, keyword string, param *dto.PageParam) ([]*model.Course, int64, error) {
+	pageNum, pageSize := param.UnWrap()
+	hits, total := s.SearchIndexer.Search(keyword, consts.SearchDocTypeCourse, pageNum, pageSize)
+	if len(hits) == 0 {
+		return []*model.Course{}, total, nil
+	}
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	found, err := s.CourseRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*model.Course, len(found))
+	for _, course := range found {
+		byID[course.ID] = course
+	}
+	courses := make([]*model.Course, 0, len(found))
+	for _, id := range ids {
+		if course, ok := byID[id]; ok {
+			courses = append(courses, course)
+		}
+	}
+	return courses, total, nil
+}
+
+// findCourse 根据ID查询课程（包含已删除的），不存在时返回错误
+func (s *CourseService) findCourse(ctx context.Context, courseId string) (*model.Course, error) {
+	course, err := s.CourseRepo.FindByID(ctx, courseId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v, courseId: %s", err, courseId)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", courseId))
+	}
+	if course == nil {
+		return nil, errorx.New(errno.ErrCourseNotFound,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", courseId))
+	}
+	return course, nil
+}
+
+// findTeachers 查找按教师搜索时命中的教师：指定教师ID时只返回该教师（已合并的重定向到合并目标），否则返回全部同名教师
+func (s *CourseService) findTeachers(ctx context.Context, name, teacherId string) ([]*model.Teacher, error) {
+	if teacherId != "" {
+		teacher, err := resolveTeacher(ctx, s.TeacherRepo, teacherId)
+		if err != nil {
+			return nil, err
+		}
+		if teacher == nil {
+			return nil, nil
+		}
+		return []*model.Teacher{teacher}, nil
+	}
+	teachers, err := s.TeacherRepo.FindManyByName(ctx, name)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", name))
+	}
+	return teachers, nil
+}
+
+// resolveMerged 沿 MergedInto 找到最终保留的课程，出现环时停在最后一个未访问的课程
+func (s *CourseService) resolveMerged(ctx context.Context, course *model.Course) (*model.Course, error) {
+	var err error
+	visited := map[string]bool{course.ID: true}
+	for course.MergedInto != "" && !visited[course.MergedInto] {
+		visited[course.MergedInto] = true
+		if course, err = s.findCourse(ctx, course.MergedInto); err != nil {
+			return nil, err
+		}
+	}
+	return course, nil
+}
+
+// logCourseChange 记录课程变更日志及变更前后的快照，失败仅记录日志
+func (s *CourseService) logCourseChange(ctx context.Context, courseId string, action int32, content string, before, after *model.Course) {
+	req := &dto.CreateChangeLogReq{
+		TargetID:     courseId,
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeCourse),
+		Action:       action,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+	}
+	if before != nil {
+		req.Before = lib.JSONF(before)
+	}
+	if after != nil {
+		req.After = lib.JSONF(after)
+	}
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, req); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, courseId: %s", err, courseId)
+	}
+}
+
+// wrapCourseCvtErr 包装课程实体转换错误，同名教师无法区分时返回 ErrTeacherAmbiguous 提示指定教师
+func wrapCourseCvtErr(err error, options ...errorx.Option) error {
+	var ambiguous *homonym.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		return errorx.WrapByCode(err, errno.ErrTeacherAmbiguous, errorx.KV("name", ambiguous.Name))
+	}
+	return errorx.WrapByCode(err, errno.ErrCourseCvtFailed, options...)
+}
+
+// courseInfoToVO 将管理员提交的课程信息转换为课程VO
+func courseInfoToVO(info *dto.CourseInfo) *dto.CourseVO {
+	return &dto.CourseVO{
+		Name:       info.Name,
+		Code:       info.Code,
+		Category:   info.Category,
+		Department: info.Department,
+		Campuses:   info.Campuses,
+		Teachers:   info.Teachers,
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+)
+
+// CompareCourses 并排对比 2~4 门课程的教师、校区、类别、标签分布、评分、评论数和最热评论
+// 已合并的课程重定向到保留课程，重定向后重复的课程只保留一次；标签分布按全部课程的标签并集对齐
+func (s *CourseService) CompareCourses(ctx context.Context, req *dto.CompareCoursesReq) (*dto.CompareCoursesResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 解析并校验课程ID
+	ids := compareCourseIDs(req.IDs)
+	if len(ids) < consts.CourseCompareMinCount || len(ids) > consts.CourseCompareMaxCount {
+		return nil, errorx.New(errno.ErrCourseInvalidParam,
+			errorx.KV("key", "ids"), errorx.KV("value", strings.Join(req.IDs, ",")))
+	}
+
+	// 查询课程，保持请求顺序
+	courses := make([]*model.Course, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		course, err := s.findCourse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if course, err = s.resolveMerged(ctx, course); err != nil {
+			return nil, err
+		}
+		if seen[course.ID] {
+			continue
+		}
+		seen[course.ID] = true
+		courses = append(courses, course)
+	}
+	if len(courses) < consts.CourseCompareMinCount {
+		return nil, errorx.New(errno.ErrCourseInvalidParam,
+			errorx.KV("key", "ids"), errorx.KV("value", strings.Join(req.IDs, ",")))
+	}
+	courseIds := make([]string, len(courses))
+	for i, course := range courses {
+		courseIds[i] = course.ID
+	}
+
+	vos, err := s.CourseAssembler.ToCourseVOArray(ctx, courses)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "course vos"),
+		)
+	}
+
+	// 批量统计评论数和标签分布
+	counts, err := s.CommentRepo.CountByCourseIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [CountByCourseIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCompareFailed)
+	}
+	tags, err := s.CommentRepo.GetTagDistributionByCourseIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [GetTagDistributionByCourseIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCompareFailed)
+	}
+	topComments, err := s.topCommentsByCourseIDs(ctx, courseIds, userId)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCompareFailed)
+	}
+
+	// 标签并集按总数降序，总数相同按名称排序
+	totals := make(map[string]int64)
+	for _, dist := range tags {
+		for tag, cnt := range dist {
+			totals[tag] += cnt
+		}
+	}
+	allTags := make([]string, 0, len(totals))
+	for tag := range totals {
+		allTags = append(allTags, tag)
+	}
+	sort.Slice(allTags, func(i, j int) bool {
+		if totals[allTags[i]] != totals[allTags[j]] {
+			return totals[allTags[i]] > totals[allTags[j]]
+		}
+		return allTags[i] < allTags[j]
+	})
+
+	comparisons := make([]*dto.CourseComparisonVO, len(courses))
+	for i, vo := range vos {
+		tagCount := make(map[string]int64, len(allTags))
+		for _, tag := range allTags {
+			tagCount[tag] = tags[vo.ID][tag]
+		}
+		comparisons[i] = &dto.CourseComparisonVO{
+			Course:       vo,
+			CommentCount: counts[vo.ID],
+			TagCount:     tagCount,
+			TopComment:   topComments[vo.ID],
+		}
+	}
+
+	return &dto.CompareCoursesResp{
+		Resp:    dto.Success(),
+		Tags:    allTags,
+		Courses: comparisons,
+	}, nil
+}
+
+// compareCourseIDs 展开逗号分隔的课程ID，去掉空值和重复值并保持顺序
+func compareCourseIDs(raw []string) []string {
+	ids := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		for _, id := range strings.Split(r, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// topCommentsByCourseIDs 批量查询每门课程点赞最多的评论，没有点赞的课程不返回
+// 点赞数相同时取ID较小（较早发布）的评论，与课程详情统计一致
+func (s *CourseService) topCommentsByCourseIDs(ctx context.Context, courseIds []string, userId string) (map[string]*dto.CommentVO, error) {
+	results := make(map[string]*dto.CommentVO)
+	commentIds, err := s.CommentRepo.FindIDsByCourseIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [FindIDsByCourseIDs] error: %v", err)
+		return nil, err
+	}
+	if len(commentIds) == 0 {
+		return results, nil
+	}
+	likes, err := s.LikeRepo.CountByTargets(ctx, commentIds,
+		mapping.Data.GetLikeTargetTypeIDByName(consts.LikeTargetTypeComment))
+	if err != nil {
+		logs.CtxErrorf(ctx, "[LikeRepo] [CountByTargets] error: %v", err)
+		return nil, err
+	}
+	liked := make([]string, 0, len(likes))
+	for id, cnt := range likes {
+		if cnt > 0 {
+			liked = append(liked, id)
+		}
+	}
+	if len(liked) == 0 {
+		return results, nil
+	}
+	owners, err := s.CommentRepo.GetCourseIDsByIDs(ctx, liked)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [GetCourseIDsByIDs] error: %v", err)
+		return nil, err
+	}
+
+	top := make(map[string]string, len(courseIds))
+	for id, courseId := range owners {
+		cur, ok := top[courseId]
+		if !ok || likes[id] > likes[cur] || (likes[id] == likes[cur] && id < cur) {
+			top[courseId] = id
+		}
+	}
+	for courseId, commentId := range top {
+		comment, err := s.CommentRepo.FindByID(ctx, commentId)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CommentRepo] [FindByID] error: %v, commentId: %s", err, commentId)
+			return nil, err
+		}
+		if comment == nil || comment.Deleted {
+			continue
+		}
+		if results[courseId], err = s.CommentAssembler.ToCommentVO(ctx, comment, userId); err != nil {
+			logs.CtxErrorf(ctx, "[CommentAssembler] [ToCommentVO] error: %v", err)
+			return nil, err
+		}
+	}
+	return results, nil
+}
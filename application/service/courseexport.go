@@ -0,0 +1,251 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+)
+
+var courseExportHeader = []string{
+	"id", "name", "code", "department", "category", "campuses", "teachers", "commentCount", "tags",
+}
+
+// courseExportEncoder 将导出的课程逐条写出
+type courseExportEncoder interface {
+	Encode(vo *dto.CourseExportVO) error
+	Flush() error
+}
+
+// ExportCourses 按 ListCourses 的筛选条件将课程目录流式写入 w，支持 CSV 与 NDJSON
+// 课程通过游标逐条读取，每批统计一次评论数与标签分布，不会在内存中缓存全部结果。
+// 写出第一条数据前返回的错误可以作为普通响应返回给调用方。
+func (s *CourseService) ExportCourses(ctx context.Context, req *dto.ExportCoursesReq, w io.Writer) error {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return err
+	}
+
+	filter, matchable, err := s.courseExportFilter(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var enc courseExportEncoder
+	switch req.Format {
+	case consts.CourseExportFormatNDJSON:
+		enc = &ndjsonCourseEncoder{enc: json.NewEncoder(w)}
+	default:
+		enc, err = newCSVCourseEncoder(w)
+		if err != nil {
+			return errorx.WrapByCode(err, errno.ErrCourseExportFailed)
+		}
+	}
+	if !matchable {
+		return enc.Flush()
+	}
+
+	// 分批统计并写出
+	teacherNames := make(map[string]string)
+	batch := make([]*model.Course, 0, consts.CourseExportBatchSize)
+	writeBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.writeCourseExportBatch(ctx, enc, batch, teacherNames); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return enc.Flush()
+	}
+	if err = s.CourseRepo.ForEach(ctx, filter, func(course *model.Course) error {
+		batch = append(batch, course)
+		if len(batch) < consts.CourseExportBatchSize {
+			return nil
+		}
+		return writeBatch()
+	}); err == nil {
+		err = writeBatch()
+	}
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseService] [ExportCourses] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrCourseExportFailed)
+	}
+	return nil
+}
+
+// courseExportFilter 将 ListCourses 风格的 type/keyword 转换为筛选条件
+// 关键词对应的教师或映射不存在时 matchable 为 false，与 ListCourses 一样不返回任何课程
+func (s *CourseService) courseExportFilter(ctx context.Context, req *dto.ExportCoursesReq) (*repo.CourseFilter, bool, error) {
+	filter := &repo.CourseFilter{}
+	switch req.Type {
+	case "":
+		return filter, true, nil
+	case consts.ReqCourse:
+		filter.Keyword = req.Keyword
+	case consts.ReqTeacher:
+		teachers, err := s.TeacherRepo.FindManyByName(ctx, req.Keyword)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error: %v", err)
+			return nil, false, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", req.Keyword))
+		}
+		filter.TeacherIDs = teacherIDs(teachers)
+		return filter, len(teachers) > 0, nil
+	case consts.ReqCategory:
+		filter.CategoryID = mapping.Data.GetCategoryIDByName(req.Keyword)
+		return filter, filter.CategoryID != 0, nil
+	case consts.ReqDepartment:
+		filter.DepartmentID = mapping.Data.GetDepartmentIDByName(req.Keyword)
+		return filter, filter.DepartmentID != 0, nil
+	default:
+		return nil, false, errorx.New(errno.ErrCourseInvalidParam,
+			errorx.KV("key", consts.ReqType), errorx.KV("value", req.Type))
+	}
+	return filter, true, nil
+}
+
+// writeCourseExportBatch 批量统计一批课程的评论数与标签分布并写出，教师名称在整个导出过程中缓存
+func (s *CourseService) writeCourseExportBatch(ctx context.Context, enc courseExportEncoder, courses []*model.Course, teacherNames map[string]string) error {
+	ids := make([]string, len(courses))
+	for i, c := range courses {
+		ids[i] = c.ID
+	}
+	counts, err := s.CommentRepo.CountByCourseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	tags, err := s.CommentRepo.GetTagDistributionByCourseIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range courses {
+		vo := &dto.CourseExportVO{
+			ID:           c.ID,
+			Name:         c.Name,
+			Code:         c.Code,
+			Department:   mapping.Data.GetDepartmentNameByID(c.Department),
+			Category:     mapping.Data.GetCategoryNameByID(c.Category),
+			Campuses:     make([]string, 0, len(c.Campuses)),
+			Teachers:     make([]string, 0, len(c.TeacherIDs)),
+			CommentCount: counts[c.ID],
+			TagCount:     tags[c.ID],
+		}
+		if vo.TagCount == nil {
+			vo.TagCount = map[string]int64{}
+		}
+		for _, id := range c.Campuses {
+			vo.Campuses = append(vo.Campuses, mapping.Data.GetCampusNameByID(id))
+		}
+		for _, tid := range c.TeacherIDs {
+			name, ok := teacherNames[tid]
+			if !ok {
+				teacher, err := s.TeacherRepo.FindByID(ctx, tid)
+				if err != nil {
+					return err
+				}
+				if teacher != nil {
+					name = teacher.Name
+				}
+				teacherNames[tid] = name
+			}
+			if name != "" {
+				vo.Teachers = append(vo.Teachers, name)
+			}
+		}
+		if err = enc.Encode(vo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvCourseEncoder 输出带 UTF-8 BOM 的 CSV，方便直接用 Excel 打开
+type csvCourseEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVCourseEncoder(w io.Writer) (*csvCourseEncoder, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	enc := &csvCourseEncoder{w: csv.NewWriter(w)}
+	if err := enc.w.Write(courseExportHeader); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+func (e *csvCourseEncoder) Encode(vo *dto.CourseExportVO) error {
+	// 标签按次数降序，格式为 标签:次数;标签:次数
+	tags := make([]string, 0, len(vo.TagCount))
+	for tag := range vo.TagCount {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if vo.TagCount[tags[i]] != vo.TagCount[tags[j]] {
+			return vo.TagCount[tags[i]] > vo.TagCount[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	for i, tag := range tags {
+		tags[i] = tag + ":" + strconv.FormatInt(vo.TagCount[tag], 10)
+	}
+
+	return e.w.Write([]string{
+		vo.ID,
+		vo.Name,
+		vo.Code,
+		vo.Department,
+		vo.Category,
+		strings.Join(vo.Campuses, "、"),
+		strings.Join(vo.Teachers, "、"),
+		strconv.FormatInt(vo.CommentCount, 10),
+		strings.Join(tags, ";"),
+	})
+}
+
+func (e *csvCourseEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonCourseEncoder 每行输出一个 JSON 对象
+type ndjsonCourseEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonCourseEncoder) Encode(vo *dto.CourseExportVO) error {
+	return e.enc.Encode(vo)
+}
+
+func (e *ndjsonCourseEncoder) Flush() error {
+	return nil
+}
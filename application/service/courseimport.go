@@ -0,0 +1,370 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/homonym"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/sheet"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 导入文件的列，表头支持英文或中文名称
+const (
+	importColName       = "name"
+	importColCode       = "code"
+	importColTeachers   = "teachers"
+	importColDepartment = "department"
+	importColCategory   = "category"
+	importColCampuses   = "campuses"
+)
+
+var importColumnAliases = map[string]string{
+	"name": importColName, "课程名称": importColName, "课程名": importColName,
+	"code": importColCode, "课程代码": importColCode, "课程号": importColCode,
+	"teachers": importColTeachers, "教师": importColTeachers, "授课教师": importColTeachers,
+	"department": importColDepartment, "开课院系": importColDepartment, "院系": importColDepartment,
+	"category": importColCategory, "课程类别": importColCategory, "课程类型": importColCategory,
+	"campuses": importColCampuses, "校区": importColCampuses, "开设校区": importColCampuses,
+}
+
+// courseImportRow 解析并校验后的一行数据
+type courseImportRow struct {
+	vo       *dto.CourseInfo
+	row      *dto.CourseImportRowVO
+	existing *model.Course
+}
+
+// ImportCourses 从 CSV/XLSX 批量导入课程目录
+// 按课程名称和代码匹配现有课程，生成新建、变更与冲突的差异；DryRun 为 false 时应用新建与变更并记录变更日志。
+// 未知的开课院系作为冲突上报而不会自动注册，未知的教师会在应用时自动创建。
+func (s *CourseService) ImportCourses(ctx context.Context, req *dto.ImportCoursesReq) (*dto.ImportCoursesResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析文件
+	if len(req.Data) > consts.CourseImportMaxSize {
+		return nil, errorx.New(errno.ErrCourseImportInvalidFile, errorx.KV("reason", "file too large"))
+	}
+	records, err := sheet.Read(req.FileName, req.Data)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrCourseImportInvalidFile, errorx.KV("reason", err.Error()))
+	}
+	rows, diff, err := parseCourseImport(records)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生成差异
+	valid := make([]*courseImportRow, 0, len(rows))
+	for _, r := range rows {
+		if err = s.diffCourseImportRow(ctx, r); err != nil {
+			return nil, err
+		}
+		switch {
+		case r.row.Reason != "":
+			diff.Conflicts = append(diff.Conflicts, r.row)
+		case r.existing == nil:
+			diff.Created = append(diff.Created, r.row)
+			valid = append(valid, r)
+		case len(r.row.Changes) > 0:
+			diff.Changed = append(diff.Changed, r.row)
+			valid = append(valid, r)
+		default:
+			diff.Unchanged++
+		}
+	}
+	if req.DryRun {
+		return &dto.ImportCoursesResp{
+			Resp: dto.Success(),
+			Diff: diff,
+		}, nil
+	}
+
+	// 应用差异，单行失败不影响其他行
+	for _, r := range valid {
+		if err = s.applyCourseImportRow(ctx, r); err != nil {
+			logs.CtxErrorf(ctx, "[CourseService] [ImportCourses] error: %v, line: %d", err, r.row.Line)
+			r.row.Reason = err.Error()
+			diff.Failed = append(diff.Failed, r.row)
+		}
+	}
+
+	return &dto.ImportCoursesResp{
+		Resp: dto.Success(),
+		Diff: diff,
+	}, nil
+}
+
+// parseCourseImport 解析表头与数据行，缺少必填字段或文件内重复的行直接记为冲突
+func parseCourseImport(records [][]string) ([]*courseImportRow, *dto.CourseImportDiffVO, error) {
+	diff := &dto.CourseImportDiffVO{
+		Created:   []*dto.CourseImportRowVO{},
+		Changed:   []*dto.CourseImportRowVO{},
+		Conflicts: []*dto.CourseImportRowVO{},
+		Failed:    []*dto.CourseImportRowVO{},
+	}
+	if len(records) == 0 {
+		return nil, nil, errorx.New(errno.ErrCourseImportInvalidFile, errorx.KV("reason", "empty file"))
+	}
+	if len(records)-1 > consts.CourseImportMaxRows {
+		return nil, nil, errorx.New(errno.ErrCourseImportInvalidFile, errorx.KV("reason", "too many rows"))
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		if col, ok := importColumnAliases[strings.ToLower(strings.TrimSpace(header))]; ok {
+			columns[col] = i
+		}
+	}
+	for _, col := range []string{importColName, importColDepartment, importColCategory} {
+		if _, ok := columns[col]; !ok {
+			return nil, nil, errorx.New(errno.ErrCourseImportInvalidFile, errorx.KV("reason", "missing column "+col))
+		}
+	}
+
+	rows := make([]*courseImportRow, 0, len(records)-1)
+	seen := make(map[string]int)
+	for i, record := range records[1:] {
+		cell := func(col string) string {
+			idx, ok := columns[col]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		info := &dto.CourseInfo{
+			Name:       cell(importColName),
+			Code:       cell(importColCode),
+			Department: cell(importColDepartment),
+			Category:   cell(importColCategory),
+			Campuses:   splitImportList(cell(importColCampuses)),
+		}
+		for _, name := range splitImportList(cell(importColTeachers)) {
+			info.Teachers = append(info.Teachers, &dto.TeacherVO{Name: name, Department: info.Department})
+		}
+		row := &dto.CourseImportRowVO{Line: i + 2, Name: info.Name, Code: info.Code}
+		diff.Total++
+
+		key := info.Name + "\x00" + info.Code
+		switch {
+		case info.Name == "" || info.Department == "" || info.Category == "":
+			row.Reason = "缺少课程名称、开课院系或课程类别"
+		case seen[key] != 0:
+			row.Reason = "与第" + strconv.Itoa(seen[key]) + "行重复"
+		default:
+			seen[key] = row.Line
+		}
+		rows = append(rows, &courseImportRow{vo: info, row: row})
+	}
+	return rows, diff, nil
+}
+
+// diffCourseImportRow 解析映射与教师，并与现有课程比较字段
+func (s *CourseService) diffCourseImportRow(ctx context.Context, r *courseImportRow) error {
+	if r.row.Reason != "" {
+		return nil
+	}
+	// 未知的院系、类别与校区不在导入时自动登记，需先维护映射
+	if mapping.Data.GetDepartmentIDByName(r.vo.Department) == 0 {
+		r.row.Reason = "未知的开课院系: " + r.vo.Department
+		return nil
+	}
+	if mapping.Data.GetCategoryIDByName(r.vo.Category) == 0 {
+		r.row.Reason = "未知的课程类别: " + r.vo.Category
+		return nil
+	}
+	for _, campus := range r.vo.Campuses {
+		if mapping.Data.GetCampusIDByName(campus) == 0 {
+			r.row.Reason = "未知的校区: " + campus
+			return nil
+		}
+	}
+	for _, teacher := range r.vo.Teachers {
+		matched, err := s.matchImportTeacher(ctx, teacher)
+		if errors.Is(err, homonym.ErrAmbiguous) {
+			r.row.Reason = "有多位同名教师「" + teacher.Name + "」且无法按开课院系区分，请先合并或在课程中指定教师"
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if matched == nil {
+			r.row.NewTeachers = append(r.row.NewTeachers, teacher.Name)
+			continue
+		}
+		teacher.ID = matched.ID
+	}
+
+	courses, err := s.CourseRepo.FindByNameAndCode(ctx, r.vo.Name, r.vo.Code)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByNameAndCode] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrCourseFindFailed, errorx.KV("name", r.vo.Name))
+	}
+	switch len(courses) {
+	case 0:
+		return nil
+	case 1:
+		r.existing = courses[0]
+		r.row.CourseID = r.existing.ID
+	default:
+		r.row.Reason = "匹配到" + strconv.Itoa(len(courses)) + "门同名同代码的课程，请先合并"
+		return nil
+	}
+
+	// 比较字段
+	teacherNames := make([]string, 0, len(r.existing.TeacherIDs))
+	for _, tid := range r.existing.TeacherIDs {
+		teacher, err := s.TeacherRepo.FindByID(ctx, tid)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, tid)
+			return errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", tid))
+		}
+		if teacher != nil {
+			teacherNames = append(teacherNames, teacher.Name)
+		}
+	}
+	campuses := make([]string, 0, len(r.existing.Campuses))
+	for _, id := range r.existing.Campuses {
+		campuses = append(campuses, mapping.Data.GetCampusNameByID(id))
+	}
+	importTeachers := make([]string, 0, len(r.vo.Teachers))
+	for _, teacher := range r.vo.Teachers {
+		importTeachers = append(importTeachers, teacher.Name)
+	}
+
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{importColDepartment, mapping.Data.GetDepartmentNameByID(r.existing.Department), r.vo.Department},
+		{importColCategory, mapping.Data.GetCategoryNameByID(r.existing.Category), r.vo.Category},
+		{importColCampuses, joinImportList(campuses), joinImportList(r.vo.Campuses)},
+		{importColTeachers, joinImportList(teacherNames), joinImportList(importTeachers)},
+	}
+	for _, f := range fields {
+		if f.before != f.after {
+			r.row.Changes = append(r.row.Changes, &dto.CourseFieldChangeVO{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+	return nil
+}
+
+// matchImportTeacher 按姓名匹配导入的教师，开课院系只用于区分多位同名教师，无法区分时返回 *homonym.AmbiguousError
+func (s *CourseService) matchImportTeacher(ctx context.Context, teacher *dto.TeacherVO) (*model.Teacher, error) {
+	candidates, err := s.TeacherRepo.FindManyByName(ctx, teacher.Name)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error: %v, name: %s", err, teacher.Name)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", teacher.Name))
+	}
+	return homonym.MatchByName(candidates, mapping.Data.GetDepartmentIDByName(teacher.Department))
+}
+
+// applyCourseImportRow 新建或更新一门课程并记录变更日志
+// 差异生成后新出现的同名教师（如前面的行刚创建的）在此重新匹配，无法区分时该行失败而不创建重复教师
+func (s *CourseService) applyCourseImportRow(ctx context.Context, r *courseImportRow) error {
+	for _, teacher := range r.vo.Teachers {
+		if teacher.ID != "" {
+			continue
+		}
+		matched, err := s.matchImportTeacher(ctx, teacher)
+		var ambiguous *homonym.AmbiguousError
+		if errors.As(err, &ambiguous) {
+			return errorx.WrapByCode(err, errno.ErrTeacherAmbiguous, errorx.KV("name", ambiguous.Name))
+		}
+		if err != nil {
+			return err
+		}
+		if matched != nil {
+			teacher.ID = matched.ID
+		}
+	}
+
+	vo := courseInfoToVO(r.vo)
+	if r.existing != nil {
+		vo.ID = r.existing.ID
+	}
+	course, err := s.CourseAssembler.ToCourseDB(ctx, vo)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if r.existing == nil {
+		course.ID = primitive.NewObjectID().Hex()
+		course.CreatedAt = now
+		course.UpdatedAt = now
+		if err = s.CourseRepo.Insert(ctx, course); err != nil {
+			return err
+		}
+		r.row.CourseID = course.ID
+		s.logCourseChange(ctx, course.ID, consts.ActionTypeImportCourse, "导入课程「"+course.Name+"」", nil, course)
+		if err = s.EventBus.Publish(ctx, event.CourseCreated{Course: course}); err != nil {
+			logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, courseId: %s", err, course.ID)
+		}
+		return nil
+	}
+
+	course.ProposalID = r.existing.ProposalID
+	course.CreatedAt = r.existing.CreatedAt
+	course.UpdatedAt = now
+	if err = s.CourseRepo.UpdateCourse(ctx, course); err != nil {
+		return err
+	}
+	s.logCourseChange(ctx, course.ID, consts.ActionTypeImportCourse, "导入更新课程「"+course.Name+"」", r.existing, course)
+	return nil
+}
+
+// splitImportList 拆分单元格中以顿号、逗号、分号或斜杠分隔的多个值
+func splitImportList(cell string) []string {
+	parts := strings.FieldsFunc(cell, func(r rune) bool {
+		return strings.ContainsRune("、,，;；/|", r)
+	})
+	list := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// joinImportList 排序后拼接，用于忽略顺序比较多值字段
+func joinImportList(list []string) string {
+	sorted := append([]string(nil), list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "、")
+}
@@ -0,0 +1,378 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/semester"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/timetable"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListCourseOfferings 查询课程按学期分组的开设，已合并的课程重定向到保留课程
+func (s *CourseService) ListCourseOfferings(ctx context.Context, req *dto.ListCourseOfferingsReq) (*dto.ListCourseOfferingsResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	if req.Semester != "" && !semester.Valid(req.Semester) {
+		return nil, errorx.New(errno.ErrOfferingInvalidParam,
+			errorx.KV("key", consts.Semester), errorx.KV("value", req.Semester))
+	}
+
+	course, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course, err = s.resolveMerged(ctx, course); err != nil {
+		return nil, err
+	}
+
+	offerings, err := s.CourseOfferingRepo.FindManyByCourseID(ctx, course.ID, req.Semester)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindManyByCourseID] error: %v, courseId: %s", err, course.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", course.ID))
+	}
+
+	return &dto.ListCourseOfferingsResp{
+		Resp:      dto.Success(),
+		Offerings: s.CourseOfferingAssembler.ToSemesterOfferingsVOArray(ctx, offerings),
+	}, nil
+}
+
+// CreateCourseOffering 管理员为课程新增一个学期的开设，同一学期同一校区只能有一个开设
+func (s *CourseService) CreateCourseOffering(ctx context.Context, req *dto.CreateCourseOfferingReq) (*dto.CreateCourseOfferingResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", req.CourseID))
+	}
+
+	now := time.Now()
+	offering, err := s.offeringFromInfo(ctx, &req.CourseOfferingInfo)
+	if err != nil {
+		return nil, err
+	}
+	offering.ID = primitive.NewObjectID().Hex()
+	offering.CourseID = course.ID
+	offering.CreatedAt = now
+	offering.UpdatedAt = now
+	if err = s.checkOfferingDuplicate(ctx, offering); err != nil {
+		return nil, err
+	}
+
+	if err = s.CourseOfferingRepo.Insert(ctx, offering); err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [Insert] error: %v, courseId: %s", err, course.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingCreateFailed, errorx.KV("courseId", course.ID))
+	}
+
+	s.syncCourseCatalog(ctx, course)
+	s.logOfferingChange(ctx, consts.ActionTypeCreateOffering,
+		"新增课程「"+course.Name+"」"+offering.Semester+"学期的开设", nil, offering)
+
+	return &dto.CreateCourseOfferingResp{
+		Resp:     dto.Success(),
+		Offering: s.CourseOfferingAssembler.ToCourseOfferingVO(ctx, offering),
+	}, nil
+}
+
+// UpdateCourseOffering 管理员修改开设的学期、教师、校区、容量和上课时间
+func (s *CourseService) UpdateCourseOffering(ctx context.Context, req *dto.UpdateCourseOfferingReq) (*dto.UpdateCourseOfferingResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findOffering(ctx, req.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.findCourse(ctx, before.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", course.ID))
+	}
+
+	after, err := s.offeringFromInfo(ctx, &req.CourseOfferingInfo)
+	if err != nil {
+		return nil, err
+	}
+	after.ID = before.ID
+	after.CourseID = before.CourseID
+	after.CreatedAt = before.CreatedAt
+	after.UpdatedAt = time.Now()
+	if err = s.checkOfferingDuplicate(ctx, after); err != nil {
+		return nil, err
+	}
+
+	if err = s.CourseOfferingRepo.Update(ctx, after); err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [Update] error: %v, offeringId: %s", err, after.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingUpdateFailed, errorx.KV("offeringId", after.ID))
+	}
+
+	s.syncCourseCatalog(ctx, course)
+	s.logOfferingChange(ctx, consts.ActionTypeUpdateOffering,
+		"修改课程「"+course.Name+"」"+after.Semester+"学期的开设", before, after)
+
+	return &dto.UpdateCourseOfferingResp{
+		Resp:     dto.Success(),
+		Offering: s.CourseOfferingAssembler.ToCourseOfferingVO(ctx, after),
+	}, nil
+}
+
+// DeleteCourseOffering 管理员软删除开设，已关联该开设的评论不受影响
+func (s *CourseService) DeleteCourseOffering(ctx context.Context, req *dto.DeleteCourseOfferingReq) (*dto.DeleteCourseOfferingResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findOffering(ctx, req.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.CourseOfferingRepo.SoftDeleteByID(ctx, before.ID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [SoftDeleteByID] error: %v, offeringId: %s", err, before.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingDeleteFailed, errorx.KV("offeringId", before.ID))
+	}
+
+	after := *before
+	after.Deleted = true
+	if course, err := s.findCourse(ctx, before.CourseID); err == nil && !course.Deleted {
+		s.syncCourseCatalog(ctx, course)
+	}
+	s.logOfferingChange(ctx, consts.ActionTypeDeleteOffering, "删除"+before.Semester+"学期的开设", before, &after)
+
+	return &dto.DeleteCourseOfferingResp{
+		Resp:    dto.Success(),
+		Deleted: true,
+	}, nil
+}
+
+// findOffering 根据ID查询未删除的开设，不存在时返回错误
+func (s *CourseService) findOffering(ctx context.Context, offeringId string) (*model.CourseOffering, error) {
+	offering, err := s.CourseOfferingRepo.FindByID(ctx, offeringId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindByID] error: %v, offeringId: %s", err, offeringId)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", offeringId))
+	}
+	if offering == nil || offering.Deleted {
+		return nil, errorx.New(errno.ErrOfferingNotFound,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", offeringId))
+	}
+	return offering, nil
+}
+
+// offeringFromInfo 校验管理员提交的开设信息并转换为开设实体，教师必须已存在，校区必须是已知校区，上课时间必须合法
+func (s *CourseService) offeringFromInfo(ctx context.Context, info *dto.CourseOfferingInfo) (*model.CourseOffering, error) {
+	if !semester.Valid(info.Semester) {
+		return nil, errorx.New(errno.ErrOfferingInvalidParam,
+			errorx.KV("key", consts.Semester), errorx.KV("value", info.Semester))
+	}
+	campus := mapping.Data.GetCampusIDByName(info.Campus)
+	if campus == 0 {
+		return nil, errorx.New(errno.ErrOfferingInvalidParam,
+			errorx.KV("key", consts.Campus), errorx.KV("value", info.Campus))
+	}
+
+	teacherIds := make([]string, 0, len(info.TeacherIDs))
+	for _, tid := range info.TeacherIDs {
+		if slices.Contains(teacherIds, tid) {
+			continue
+		}
+		exist, err := s.TeacherRepo.IsExistByID(ctx, tid)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [IsExistByID] error: %v, teacherId: %s", err, tid)
+			return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", tid))
+		}
+		if !exist {
+			return nil, errorx.New(errno.ErrOfferingInvalidParam,
+				errorx.KV("key", consts.TeacherIDs), errorx.KV("value", tid))
+		}
+		teacherIds = append(teacherIds, tid)
+	}
+
+	slots := s.CourseOfferingAssembler.ToTimeSlotDBArray(ctx, info.Slots)
+	for _, slot := range slots {
+		if err := timetable.Validate(slot); err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrOfferingInvalidParam,
+				errorx.KV("key", consts.Slots), errorx.KV("value", err.Error()))
+		}
+	}
+
+	return &model.CourseOffering{
+		Semester:   info.Semester,
+		TeacherIDs: teacherIds,
+		Campus:     campus,
+		Capacity:   info.Capacity,
+		Slots:      slots,
+	}, nil
+}
+
+// checkOfferingDuplicate 同一课程同一学期同一校区只能有一个未删除的开设
+func (s *CourseService) checkOfferingDuplicate(ctx context.Context, offering *model.CourseOffering) error {
+	dup, err := s.CourseOfferingRepo.IsDuplicate(ctx, offering)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [IsDuplicate] error: %v, courseId: %s", err, offering.CourseID)
+		return errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", offering.CourseID))
+	}
+	if dup {
+		return errorx.New(errno.ErrOfferingAlreadyExists,
+			errorx.KV("courseId", offering.CourseID), errorx.KV("semester", offering.Semester))
+	}
+	return nil
+}
+
+// foldDuplicateOfferings 合并课程前，将重复课程中与保留课程（或彼此之间）同一学期、同一校区的开设并入先出现的开设：
+// 软删除被并入的开设并将其评论改挂到保留的开设，返回保留的开设ID到被并入的开设ID列表
+func (s *CourseService) foldDuplicateOfferings(ctx context.Context, targetId string, sourceIds []string) (map[string][]string, error) {
+	targetOfferings, err := s.CourseOfferingRepo.FindManyByCourseID(ctx, targetId, "")
+	if err != nil {
+		return nil, err
+	}
+	sourceOfferings, err := s.CourseOfferingRepo.FindManyByCourseIDs(ctx, sourceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	type slot struct {
+		semester string
+		campus   int32
+	}
+	kept := make(map[slot]string)
+	folded := make(map[string][]string)
+	for _, offering := range append(targetOfferings, sourceOfferings...) {
+		key := slot{semester: offering.Semester, campus: offering.Campus}
+		keepId, ok := kept[key]
+		if !ok {
+			kept[key] = offering.ID
+			continue
+		}
+		if err = s.CourseOfferingRepo.SoftDeleteByID(ctx, offering.ID); err != nil {
+			return nil, err
+		}
+		folded[keepId] = append(folded[keepId], offering.ID)
+	}
+	for keepId, ids := range folded {
+		if _, err = s.CommentRepo.ReplaceOffering(ctx, ids, keepId); err != nil {
+			return nil, err
+		}
+	}
+	return folded, nil
+}
+
+// syncCourseCatalog 按课程未删除的开设重新计算课程的教师和校区，使按教师、校区检索课程与开设保持一致，
+// 没有未删除的开设时保持课程原有信息不变；返回同步后的课程，失败仅记录日志并返回原课程
+func (s *CourseService) syncCourseCatalog(ctx context.Context, course *model.Course) *model.Course {
+	offerings, err := s.CourseOfferingRepo.FindManyByCourseID(ctx, course.ID, "")
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindManyByCourseID] error: %v, courseId: %s", err, course.ID)
+		return course
+	}
+	if len(offerings) == 0 {
+		return course
+	}
+
+	after := *course
+	after.TeacherIDs = []string{}
+	after.Campuses = []int32{}
+	for _, offering := range offerings {
+		for _, tid := range offering.TeacherIDs {
+			if !slices.Contains(after.TeacherIDs, tid) {
+				after.TeacherIDs = append(after.TeacherIDs, tid)
+			}
+		}
+		if !slices.Contains(after.Campuses, offering.Campus) {
+			after.Campuses = append(after.Campuses, offering.Campus)
+		}
+	}
+	if sameElements(course.TeacherIDs, after.TeacherIDs) && sameElements(course.Campuses, after.Campuses) {
+		return course
+	}
+	if err = s.CourseRepo.UpdateCourse(ctx, &after); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [UpdateCourse] error: %v, courseId: %s", err, course.ID)
+		return course
+	}
+	s.logCourseChange(ctx, course.ID, consts.ActionTypeUpdateCourse,
+		"课程「"+course.Name+"」同步开设的教师和校区", course, &after)
+	return &after
+}
+
+// sameElements 判断两个切片去重后的元素集合是否相同，不考虑顺序
+func sameElements[T comparable](a, b []T) bool {
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// logOfferingChange 以开设所属课程为目标记录开设变更日志及前后快照，失败仅记录日志
+func (s *CourseService) logOfferingChange(ctx context.Context, action int32, content string, before, after *model.CourseOffering) {
+	courseId := ""
+	req := &dto.CreateChangeLogReq{
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeCourse),
+		Action:       action,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+	}
+	if before != nil {
+		courseId = before.CourseID
+		req.Before = lib.JSONF(before)
+	}
+	if after != nil {
+		courseId = after.CourseID
+		req.After = lib.JSONF(after)
+	}
+	req.TargetID = courseId
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, req); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, courseId: %s", err, courseId)
+	}
+}
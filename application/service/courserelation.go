@@ -0,0 +1,481 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/graph"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListCourseRelations 查询课程已生效的关系和待审核的提议，已合并的课程重定向到保留课程
+func (s *CourseService) ListCourseRelations(ctx context.Context, req *dto.ListCourseRelationsReq) (*dto.ListCourseRelationsResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+	admin, err := s.UserRepo.IsAdminByID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+		return nil, errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", userId))
+	}
+
+	course, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course, err = s.resolveMerged(ctx, course); err != nil {
+		return nil, err
+	}
+
+	relations, err := s.courseRelations(ctx, course.ID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseService] [courseRelations] error: %v, courseId: %s", err, course.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", course.ID))
+	}
+
+	// 普通用户只能看到自己提议的待审核关系
+	dbs, err := s.CourseRelationRepo.FindManyByCourseID(ctx, course.ID, []int32{consts.CourseRelationStatusPending})
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [FindManyByCourseID] error: %v, courseId: %s", err, course.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", course.ID))
+	}
+	visible := make([]*model.CourseRelation, 0, len(dbs))
+	for _, db := range dbs {
+		if admin || db.UserID == userId {
+			visible = append(visible, db)
+		}
+	}
+	pending, err := s.CourseRelationAssembler.ToCourseRelationVOArray(ctx, visible)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationAssembler] [ToCourseRelationVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", course.ID))
+	}
+
+	return &dto.ListCourseRelationsResp{
+		Resp:      dto.Success(),
+		Relations: relations,
+		Pending:   pending,
+	}, nil
+}
+
+// CreateCourseRelation 为课程添加关系，管理员添加的直接生效，普通用户添加的作为提议等待审核
+// 先修关系不能成环，同一关系已生效或待审核时不能重复添加
+func (s *CourseService) CreateCourseRelation(ctx context.Context, req *dto.CreateCourseRelationReq) (*dto.CreateCourseRelationResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+	admin, err := s.UserRepo.IsAdminByID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+		return nil, errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", userId))
+	}
+
+	course, err := s.findRelatableCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findRelatableCourse(ctx, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if course.ID == target.ID {
+		return nil, errorx.New(errno.ErrRelationInvalidParam,
+			errorx.KV("key", "targetId"), errorx.KV("value", req.TargetID))
+	}
+
+	// 先修关系由先修课指向本课程，其余关系由本课程指向目标课程
+	fromId, toId := course.ID, target.ID
+	if req.Type == consts.CourseRelationPrerequisite {
+		fromId, toId = target.ID, course.ID
+	}
+	if err = s.checkRelationDuplicate(ctx, req.Type, fromId, toId); err != nil {
+		return nil, err
+	}
+	if err = s.checkPrerequisiteCycle(ctx, req.Type, fromId, toId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	relation := &model.CourseRelation{
+		ID:        primitive.NewObjectID().Hex(),
+		FromID:    fromId,
+		ToID:      toId,
+		Type:      req.Type,
+		Status:    consts.CourseRelationStatusPending,
+		Note:      req.Note,
+		UserID:    userId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if admin {
+		relation.Status = consts.CourseRelationStatusApproved
+		relation.ReviewerID = userId
+	}
+	if err = s.CourseRelationRepo.Insert(ctx, relation); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [Insert] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationCreateFailed,
+			errorx.KV("type", req.Type), errorx.KV("fromId", fromId), errorx.KV("toId", toId))
+	}
+
+	source := consts.UpdateSourceUser
+	if admin {
+		source = consts.UpdateSourceAdmin
+	}
+	s.logRelationChange(ctx, course.ID, consts.ActionTypeCreateRelation, source,
+		"为课程「"+course.Name+"」添加与「"+target.Name+"」的"+relationTypeName(req.Type)+"关系", nil, relation)
+
+	vos, err := s.CourseRelationAssembler.ToCourseRelationVOArray(ctx, []*model.CourseRelation{relation})
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationAssembler] [ToCourseRelationVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course relation"), errorx.KV("dst", "course relation vo"))
+	}
+
+	return &dto.CreateCourseRelationResp{
+		Resp:     dto.Success(),
+		Relation: vos[0],
+	}, nil
+}
+
+// ListPendingCourseRelations 管理员分页查询待审核的课程关系提议
+func (s *CourseService) ListPendingCourseRelations(ctx context.Context, req *dto.ListPendingCourseRelationsReq) (*dto.ListPendingCourseRelationsResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs, total, err := s.CourseRelationRepo.FindManyByStatus(ctx, consts.CourseRelationStatusPending, req.PageParam)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [FindManyByStatus] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.Status), errorx.KV("value", consts.ProposalStatusPending))
+	}
+	vos, err := s.CourseRelationAssembler.ToCourseRelationVOArray(ctx, dbs)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationAssembler] [ToCourseRelationVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course relations"), errorx.KV("dst", "course relation vos"))
+	}
+
+	return &dto.ListPendingCourseRelationsResp{
+		Resp:      dto.Success(),
+		Total:     total,
+		Relations: vos,
+	}, nil
+}
+
+// ApproveCourseRelation 管理员通过课程关系提议，通过前重新检查两端课程和先修环
+func (s *CourseService) ApproveCourseRelation(ctx context.Context, req *dto.ApproveCourseRelationReq) (*dto.ApproveCourseRelationResp, error) {
+	// 鉴权
+	userId, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findPendingRelation(ctx, req.RelationID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.findRelatableCourse(ctx, before.FromID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.findRelatableCourse(ctx, before.ToID); err != nil {
+		return nil, err
+	}
+	if err = s.checkPrerequisiteCycle(ctx, before.Type, before.FromID, before.ToID); err != nil {
+		return nil, err
+	}
+
+	after, err := s.reviewRelation(ctx, before, consts.CourseRelationStatusApproved, userId, "")
+	if err != nil {
+		return nil, err
+	}
+	s.logRelationChange(ctx, from.ID, consts.ActionTypeReviewRelation, consts.UpdateSourceAdmin,
+		"通过课程「"+from.Name+"」的"+relationTypeName(after.Type)+"关系提议", before, after)
+
+	vos, err := s.CourseRelationAssembler.ToCourseRelationVOArray(ctx, []*model.CourseRelation{after})
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationAssembler] [ToCourseRelationVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course relation"), errorx.KV("dst", "course relation vo"))
+	}
+
+	return &dto.ApproveCourseRelationResp{
+		Resp:     dto.Success(),
+		Relation: vos[0],
+	}, nil
+}
+
+// RejectCourseRelation 管理员拒绝课程关系提议
+func (s *CourseService) RejectCourseRelation(ctx context.Context, req *dto.RejectCourseRelationReq) (*dto.RejectCourseRelationResp, error) {
+	// 鉴权
+	userId, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.findPendingRelation(ctx, req.RelationID)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.reviewRelation(ctx, before, consts.CourseRelationStatusRejected, userId, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	s.logRelationChange(ctx, after.FromID, consts.ActionTypeReviewRelation, consts.UpdateSourceAdmin,
+		"拒绝"+relationTypeName(after.Type)+"关系提议", before, after)
+
+	vos, err := s.CourseRelationAssembler.ToCourseRelationVOArray(ctx, []*model.CourseRelation{after})
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationAssembler] [ToCourseRelationVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course relation"), errorx.KV("dst", "course relation vo"))
+	}
+
+	return &dto.RejectCourseRelationResp{
+		Resp:     dto.Success(),
+		Relation: vos[0],
+	}, nil
+}
+
+// DeleteCourseRelation 删除课程关系，管理员可删除任意关系，普通用户只能撤回自己待审核的提议
+func (s *CourseService) DeleteCourseRelation(ctx context.Context, req *dto.DeleteCourseRelationReq) (*dto.DeleteCourseRelationResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+	admin, err := s.UserRepo.IsAdminByID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+		return nil, errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", userId))
+	}
+
+	relation, err := s.findRelation(ctx, req.RelationID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin && (relation.UserID != userId || relation.Status != consts.CourseRelationStatusPending) {
+		return nil, errorx.New(errno.ErrUserNotAdmin, errorx.KV("id", userId))
+	}
+
+	if err = s.CourseRelationRepo.SoftDeleteByID(ctx, relation.ID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [SoftDeleteByID] error: %v, relationId: %s", err, relation.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationDeleteFailed, errorx.KV("relationId", relation.ID))
+	}
+
+	source := consts.UpdateSourceUser
+	if admin {
+		source = consts.UpdateSourceAdmin
+	}
+	after := *relation
+	after.Deleted = true
+	s.logRelationChange(ctx, relation.FromID, consts.ActionTypeDeleteRelation, source,
+		"删除"+relationTypeName(relation.Type)+"关系", relation, &after)
+
+	return &dto.DeleteCourseRelationResp{
+		Resp:    dto.Success(),
+		Deleted: true,
+	}, nil
+}
+
+// courseRelations 查询课程已生效的关系，等价课程沿等价关系传递展开
+func (s *CourseService) courseRelations(ctx context.Context, courseId string) (*dto.CourseRelationsVO, error) {
+	dbs, err := s.CourseRelationRepo.FindManyByCourseID(ctx, courseId, []int32{consts.CourseRelationStatusApproved})
+	if err != nil {
+		return nil, err
+	}
+	equivalentIds, err := s.equivalentCourseIDs(ctx, []string{courseId}, consts.CourseRelationWalkLimit)
+	if err != nil {
+		return nil, err
+	}
+	return s.CourseRelationAssembler.ToCourseRelationsVO(ctx, courseId, dbs, equivalentIds)
+}
+
+// equivalentCourseIDs 沿已生效的等价关系展开，返回与 seeds 直接或间接等价的课程ID（不含 seeds）
+func (s *CourseService) equivalentCourseIDs(ctx context.Context, seeds []string, limit int) ([]string, error) {
+	ids, _, err := graph.Walk(seeds, func(ids []string) ([]string, error) {
+		to, err := s.CourseRelationRepo.FindToIDs(ctx, consts.CourseRelationEquivalent, ids)
+		if err != nil {
+			return nil, err
+		}
+		from, err := s.CourseRelationRepo.FindFromIDs(ctx, consts.CourseRelationEquivalent, ids)
+		if err != nil {
+			return nil, err
+		}
+		return append(to, from...), nil
+	}, limit)
+	return ids, err
+}
+
+// equivalentCourseIDsByKeyword 查询名称或代码与关键词完全相同的课程的等价课程
+func (s *CourseService) equivalentCourseIDsByKeyword(ctx context.Context, keyword string) ([]string, error) {
+	seeds, err := s.CourseRepo.FindIDsByExactKeyword(ctx, keyword, consts.CourseRelationSearchSeedLimit)
+	if err != nil || len(seeds) == 0 {
+		return nil, err
+	}
+	return s.equivalentCourseIDs(ctx, seeds, consts.CourseRelationSearchExpandLimit)
+}
+
+// findRelatableCourse 查询可以建立关系的课程，已合并的课程重定向到保留课程，已删除的课程不能建立关系
+func (s *CourseService) findRelatableCourse(ctx context.Context, courseId string) (*model.Course, error) {
+	course, err := s.findCourse(ctx, courseId)
+	if err != nil {
+		return nil, err
+	}
+	if course, err = s.resolveMerged(ctx, course); err != nil {
+		return nil, err
+	}
+	if course.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", course.ID))
+	}
+	return course, nil
+}
+
+// checkRelationDuplicate 同一关系已生效或待审核时不能重复添加，等价关系不区分方向
+func (s *CourseService) checkRelationDuplicate(ctx context.Context, relationType, fromId, toId string) error {
+	pairs := [][2]string{{fromId, toId}}
+	if relationType == consts.CourseRelationEquivalent {
+		pairs = append(pairs, [2]string{toId, fromId})
+	}
+	for _, pair := range pairs {
+		existing, err := s.CourseRelationRepo.FindActive(ctx, relationType, pair[0], pair[1])
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseRelationRepo] [FindActive] error: %v", err)
+			return errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+				errorx.KV("key", consts.FromID), errorx.KV("value", pair[0]))
+		}
+		if existing != nil {
+			return errorx.New(errno.ErrRelationAlreadyExists,
+				errorx.KV("type", relationType), errorx.KV("fromId", fromId), errorx.KV("toId", toId))
+		}
+	}
+	return nil
+}
+
+// checkPrerequisiteCycle 新增先修关系 fromId -> toId 前检查已生效的先修关系中 toId 是否已能到达 fromId
+func (s *CourseService) checkPrerequisiteCycle(ctx context.Context, relationType, fromId, toId string) error {
+	if relationType != consts.CourseRelationPrerequisite {
+		return nil
+	}
+	cycle, err := graph.Reachable(toId, fromId, func(ids []string) ([]string, error) {
+		return s.CourseRelationRepo.FindToIDs(ctx, consts.CourseRelationPrerequisite, ids)
+	}, consts.CourseRelationWalkLimit)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseService] [checkPrerequisiteCycle] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.FromID), errorx.KV("value", toId))
+	}
+	if cycle {
+		return errorx.New(errno.ErrRelationCycle, errorx.KV("fromId", fromId), errorx.KV("toId", toId))
+	}
+	return nil
+}
+
+// findRelation 查询未删除的课程关系
+func (s *CourseService) findRelation(ctx context.Context, relationId string) (*model.CourseRelation, error) {
+	relation, err := s.CourseRelationRepo.FindByID(ctx, relationId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [FindByID] error: %v, relationId: %s", err, relationId)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationFindFailed,
+			errorx.KV("key", consts.RelationID), errorx.KV("value", relationId))
+	}
+	if relation == nil || relation.Deleted {
+		return nil, errorx.New(errno.ErrRelationNotFound,
+			errorx.KV("key", consts.RelationID), errorx.KV("value", relationId))
+	}
+	return relation, nil
+}
+
+// findPendingRelation 查询待审核的课程关系
+func (s *CourseService) findPendingRelation(ctx context.Context, relationId string) (*model.CourseRelation, error) {
+	relation, err := s.findRelation(ctx, relationId)
+	if err != nil {
+		return nil, err
+	}
+	if relation.Status != consts.CourseRelationStatusPending {
+		return nil, errorx.New(errno.ErrRelationNotPending, errorx.KV("relationId", relationId))
+	}
+	return relation, nil
+}
+
+// reviewRelation 审核关系，并发审核时只有一个管理员成功
+func (s *CourseService) reviewRelation(ctx context.Context, before *model.CourseRelation, status int32, reviewerId, reason string) (*model.CourseRelation, error) {
+	reviewed, err := s.CourseRelationRepo.Review(ctx, before.ID, status, reviewerId, reason)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRelationRepo] [Review] error: %v, relationId: %s", err, before.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrRelationUpdateFailed, errorx.KV("relationId", before.ID))
+	}
+	if !reviewed {
+		return nil, errorx.New(errno.ErrRelationNotPending, errorx.KV("relationId", before.ID))
+	}
+	after := *before
+	after.Status = status
+	after.ReviewerID = reviewerId
+	after.RejectReason = reason
+	after.UpdatedAt = time.Now()
+	return &after, nil
+}
+
+// logRelationChange 记录课程关系的变更，记录在 courseId 对应课程名下
+func (s *CourseService) logRelationChange(ctx context.Context, courseId string, action, source int32, content string, before, after *model.CourseRelation) {
+	req := &dto.CreateChangeLogReq{
+		TargetID:     courseId,
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeCourse),
+		Action:       action,
+		Content:      content,
+		UpdateSource: source,
+	}
+	if before != nil {
+		req.Before = lib.JSONF(before)
+	}
+	if after != nil {
+		req.After = lib.JSONF(after)
+	}
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, req); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, courseId: %s", err, courseId)
+	}
+}
+
+// relationTypeName 关系类型的中文名称
+func relationTypeName(relationType string) string {
+	switch relationType {
+	case consts.CourseRelationPrerequisite:
+		return "先修"
+	case consts.CourseRelationEquivalent:
+		return "等价"
+	default:
+		return "后续"
+	}
+}
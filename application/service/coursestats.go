@@ -0,0 +1,142 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/semester"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+)
+
+// GetCourseStats 返回课程详情页所需的统计：评论数、点赞总数、全部标签分布、评分汇总、评论量时间线和点赞最多的评论
+// 统计结果按课程缓存，新评论发布时失效；最热评论的点赞状态按当前用户实时查询
+func (s *CourseService) GetCourseStats(ctx context.Context, req *dto.GetCourseStatsReq) (*dto.GetCourseStatsResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 查询课程，已合并的课程重定向到保留课程
+	course, err := s.findCourse(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course, err = s.resolveMerged(ctx, course); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.loadCourseStats(ctx, course.ID)
+	if err != nil {
+		return nil, errorx.WrapByCode(err, errno.ErrCourseStatsFailed, errorx.KV("courseId", course.ID))
+	}
+
+	vo := &dto.CourseStatsVO{
+		CourseID:     stats.CourseID,
+		CommentCount: stats.CommentCount,
+		LikeCount:    stats.LikeCount,
+		TagCount:     stats.TagCount,
+		Timeline:     commentTimeline(stats.MonthlyCount, req.Granularity),
+	}
+
+	// 最热评论
+	if stats.TopCommentID != "" {
+		comment, err := s.CommentRepo.FindByID(ctx, stats.TopCommentID)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CommentRepo] [FindByID] error: %v, commentId: %s", err, stats.TopCommentID)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseStatsFailed, errorx.KV("courseId", course.ID))
+		}
+		if comment != nil && !comment.Deleted {
+			if vo.TopComment, err = s.CommentAssembler.ToCommentVO(ctx, comment, userId); err != nil {
+				logs.CtxErrorf(ctx, "[CommentAssembler] [ToCommentVO] error: %v", err)
+				return nil, errorx.WrapByCode(err, errno.ErrCourseStatsFailed, errorx.KV("courseId", course.ID))
+			}
+		}
+	}
+
+	return &dto.GetCourseStatsResp{
+		Resp:  dto.Success(),
+		Stats: vo,
+	}, nil
+}
+
+// loadCourseStats 优先读取缓存，未命中时聚合评论与点赞并回写缓存，缓存读写失败只记录日志
+func (s *CourseService) loadCourseStats(ctx context.Context, courseId string) (*model.CourseStats, error) {
+	stats, hit, err := s.CourseCache.GetStats(ctx, courseId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseCache] [GetStats] error: %v, courseId: %s", err, courseId)
+	}
+	if hit {
+		return stats, nil
+	}
+
+	stats, commentIds, err := s.CommentRepo.GetCourseStats(ctx, courseId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [GetCourseStats] error: %v, courseId: %s", err, courseId)
+		return nil, err
+	}
+	if len(commentIds) > 0 {
+		likes, err := s.LikeRepo.CountByTargets(ctx, commentIds,
+			mapping.Data.GetLikeTargetTypeIDByName(consts.LikeTargetTypeComment))
+		if err != nil {
+			logs.CtxErrorf(ctx, "[LikeRepo] [CountByTargets] error: %v, courseId: %s", err, courseId)
+			return nil, err
+		}
+		var topLikes int64
+		for id, cnt := range likes {
+			stats.LikeCount += cnt
+			// 点赞数相同时取ID较小（较早发布）的评论，保证结果稳定
+			if cnt > topLikes || (cnt == topLikes && id < stats.TopCommentID) {
+				stats.TopCommentID, topLikes = id, cnt
+			}
+		}
+	}
+
+	if err = s.CourseCache.SetStats(ctx, stats, consts.CacheCourseStatsTTL); err != nil {
+		logs.CtxErrorf(ctx, "[CourseCache] [SetStats] error: %v, courseId: %s", err, courseId)
+	}
+	return stats, nil
+}
+
+// commentTimeline 将按月评论量转换为时间线，按学期统计时合并同一学期的月份
+func commentTimeline(monthly map[string]int64, granularity string) []*dto.CommentVolumeVO {
+	counts := monthly
+	if granularity == consts.CourseStatsGranularitySemester {
+		counts = make(map[string]int64, len(monthly))
+		for month, cnt := range monthly {
+			term, err := semester.OfMonth(month)
+			if err != nil {
+				continue
+			}
+			counts[term] += cnt
+		}
+	}
+
+	timeline := make([]*dto.CommentVolumeVO, 0, len(counts))
+	for period, cnt := range counts {
+		timeline = append(timeline, &dto.CommentVolumeVO{Period: period, Count: cnt})
+	}
+	// "2025-03" 与 "2024-2025-2" 的字典序即时间顺序
+	sort.Slice(timeline, func(i, j int) bool { return timeline[i].Period < timeline[j].Period })
+	return timeline
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
@@ -35,9 +37,9 @@ type ILikeService interface {
 }
 
 type LikeService struct {
-	LikeRepo     *repo.LikeRepo
-	LikeCache    *cache.LikeCache
-	ProposalRepo *repo.ProposalRepo
+	LikeRepo  *repo.LikeRepo
+	LikeCache *cache.LikeCache
+	EventBus  *eventbus.Bus
 }
 
 var LikeServiceSet = wire.NewSet(
@@ -77,15 +79,14 @@ func (s *LikeService) ToggleLike(ctx context.Context, req *dto.ToggleLikeReq) (r
 			errorx.KV("key", consts.ReqTargetID), errorx.KV("value", req.TargetID))
 	}
 
-	// 同步更新 proposal 文档的 likeCnt
-	if req.TargetType == consts.LikeTargetTypeProposal {
-		delta := int64(1)
-		if !active {
-			delta = int64(-1)
-		}
-		if err := s.ProposalRepo.IncrementLikeCnt(ctx, req.TargetID, delta); err != nil {
-			logs.CtxWarnf(ctx, "[ProposalRepo] [IncrementLikeCnt] error: %v", err)
-		}
+	// 点赞数同步与通知由事件订阅者处理
+	if err = s.EventBus.Publish(ctx, event.LikeToggled{
+		UserID:     userId,
+		TargetID:   req.TargetID,
+		TargetType: req.TargetType,
+		Active:     active,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, targetId: %s", err, req.TargetID)
 	}
 
 	return &dto.ToggleLikeResp{
@@ -0,0 +1,262 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var _ INotificationService = (*NotificationService)(nil)
+
+type INotificationService interface {
+	CreateNotification(ctx context.Context, req *dto.CreateNotificationReq) error
+
+	ListNotifications(ctx context.Context, req *dto.ListNotificationsReq) (*dto.ListNotificationsResp, error)
+	MarkNotificationRead(ctx context.Context, req *dto.MarkNotificationReadReq) (*dto.MarkNotificationReadResp, error)
+	MarkAllNotificationsRead(ctx context.Context, req *dto.MarkAllNotificationsReadReq) (*dto.MarkAllNotificationsReadResp, error)
+	GetNotificationSetting(ctx context.Context, req *dto.GetNotificationSettingReq) (*dto.GetNotificationSettingResp, error)
+	UpdateNotificationSetting(ctx context.Context, req *dto.UpdateNotificationSettingReq) (*dto.UpdateNotificationSettingResp, error)
+}
+
+type NotificationService struct {
+	NotificationRepo        *repo.NotificationRepo
+	NotificationSettingRepo *repo.NotificationSettingRepo
+}
+
+var NotificationServiceSet = wire.NewSet(
+	wire.Struct(new(NotificationService), "*"),
+	wire.Bind(new(INotificationService), new(*NotificationService)),
+)
+
+// CreateNotification 向用户发送一条站内通知
+// 触发者为接收者本人或接收者屏蔽了该类型时不发送；由异步事件触发时同一事件补发不会重复通知
+func (s *NotificationService) CreateNotification(ctx context.Context, req *dto.CreateNotificationReq) error {
+	actorId, _ := ctx.Value(consts.CtxUserID).(string)
+	if req.UserID == "" || req.UserID == actorId {
+		return nil
+	}
+
+	// 校验通知类型
+	typeId := mapping.Data.GetNotificationTypeIDByName(req.Type)
+	if typeId == 0 {
+		return errorx.New(errno.ErrNotificationTypeInvalid, errorx.KV("type", req.Type))
+	}
+
+	// 检查接收者是否屏蔽了该类型
+	setting, err := s.NotificationSettingRepo.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationSettingRepo] [FindByUserID] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrNotificationSettingFindFailed, errorx.KV("userId", req.UserID))
+	}
+	if setting != nil && slices.Contains(setting.MutedTypes, typeId) {
+		return nil
+	}
+
+	// 插入通知
+	eventKey := req.EventKey
+	if eventKey == "" {
+		eventKey = eventbus.DispatchKey(ctx)
+	}
+	now := time.Now()
+	notification := &model.Notification{
+		ID:        primitive.NewObjectID().Hex(),
+		UserID:    req.UserID,
+		ActorID:   actorId,
+		Type:      typeId,
+		TargetID:  req.TargetID,
+		Content:   req.Content,
+		Read:      false,
+		EventKey:  eventKey,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err = s.NotificationRepo.Insert(ctx, notification); err != nil {
+		logs.CtxErrorf(ctx, "[NotificationRepo] [Insert] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrNotificationInsertFailed, errorx.KV("userId", req.UserID))
+	}
+	return nil
+}
+
+// ListNotifications 分页获取我的通知，并返回未读总数
+func (s *NotificationService) ListNotifications(ctx context.Context, req *dto.ListNotificationsReq) (*dto.ListNotificationsResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 查询通知列表
+	notifications, total, err := s.NotificationRepo.FindManyByUserID(ctx, req.PageParam, userId, req.UnreadOnly)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationRepo] [FindManyByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationFindFailed,
+			errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
+	}
+
+	// 统计未读数
+	unreadCnt, err := s.NotificationRepo.CountUnreadByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationRepo] [CountUnreadByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationCountFailed, errorx.KV("userId", userId))
+	}
+
+	// 转换为VO
+	vos := make([]*dto.NotificationVO, len(notifications))
+	for i, n := range notifications {
+		vos[i] = &dto.NotificationVO{
+			ID:        n.ID,
+			Type:      mapping.Data.GetNotificationTypeNameByID(n.Type),
+			ActorID:   n.ActorID,
+			TargetID:  n.TargetID,
+			Content:   n.Content,
+			Read:      n.Read,
+			CreatedAt: n.CreatedAt,
+		}
+	}
+
+	return &dto.ListNotificationsResp{
+		Resp:          dto.Success(),
+		Total:         total,
+		UnreadCnt:     unreadCnt,
+		Notifications: vos,
+	}, nil
+}
+
+// MarkNotificationRead 将一条通知标记为已读
+func (s *NotificationService) MarkNotificationRead(ctx context.Context, req *dto.MarkNotificationReadReq) (*dto.MarkNotificationReadResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 标记已读，只能操作自己的通知
+	matched, err := s.NotificationRepo.MarkReadByID(ctx, userId, req.NotificationID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationRepo] [MarkReadByID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationUpdateFailed, errorx.KV("notificationId", req.NotificationID))
+	}
+	if !matched {
+		return nil, errorx.New(errno.ErrNotificationNotFound, errorx.KV("notificationId", req.NotificationID))
+	}
+
+	// 返回最新未读数
+	unreadCnt, err := s.NotificationRepo.CountUnreadByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxWarnf(ctx, "[NotificationRepo] [CountUnreadByUserID] error: %v", err)
+	}
+
+	return &dto.MarkNotificationReadResp{
+		Resp:      dto.Success(),
+		UnreadCnt: unreadCnt,
+	}, nil
+}
+
+// MarkAllNotificationsRead 将我的所有通知标记为已读
+func (s *NotificationService) MarkAllNotificationsRead(ctx context.Context, req *dto.MarkAllNotificationsReadReq) (*dto.MarkAllNotificationsReadResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	marked, err := s.NotificationRepo.MarkAllReadByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationRepo] [MarkAllReadByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationUpdateFailed, errorx.KV("notificationId", "all"))
+	}
+
+	return &dto.MarkAllNotificationsReadResp{
+		Resp:   dto.Success(),
+		Marked: marked,
+	}, nil
+}
+
+// GetNotificationSetting 获取我屏蔽的通知类型
+func (s *NotificationService) GetNotificationSetting(ctx context.Context, req *dto.GetNotificationSettingReq) (*dto.GetNotificationSettingResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	setting, err := s.NotificationSettingRepo.FindByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[NotificationSettingRepo] [FindByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationSettingFindFailed, errorx.KV("userId", userId))
+	}
+
+	mutedTypes := []string{}
+	if setting != nil {
+		for _, t := range setting.MutedTypes {
+			mutedTypes = append(mutedTypes, mapping.Data.GetNotificationTypeNameByID(t))
+		}
+	}
+
+	return &dto.GetNotificationSettingResp{
+		Resp:       dto.Success(),
+		MutedTypes: mutedTypes,
+	}, nil
+}
+
+// UpdateNotificationSetting 覆盖我屏蔽的通知类型
+func (s *NotificationService) UpdateNotificationSetting(ctx context.Context, req *dto.UpdateNotificationSettingReq) (*dto.UpdateNotificationSettingResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 校验并转换通知类型
+	mutedTypeIds := make([]int32, 0, len(req.MutedTypes))
+	for _, t := range req.MutedTypes {
+		typeId := mapping.Data.GetNotificationTypeIDByName(t)
+		if typeId == 0 {
+			return nil, errorx.New(errno.ErrNotificationTypeInvalid, errorx.KV("type", t))
+		}
+		if !slices.Contains(mutedTypeIds, typeId) {
+			mutedTypeIds = append(mutedTypeIds, typeId)
+		}
+	}
+
+	if err := s.NotificationSettingRepo.UpsertMutedTypes(ctx, userId, mutedTypeIds); err != nil {
+		logs.CtxErrorf(ctx, "[NotificationSettingRepo] [UpsertMutedTypes] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrNotificationSettingSaveFailed, errorx.KV("userId", userId))
+	}
+
+	mutedTypes := make([]string, len(mutedTypeIds))
+	for i, t := range mutedTypeIds {
+		mutedTypes[i] = mapping.Data.GetNotificationTypeNameByID(t)
+	}
+
+	return &dto.UpdateNotificationSettingResp{
+		Resp:       dto.Success(),
+		MutedTypes: mutedTypes,
+	}, nil
+}
@@ -0,0 +1,375 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/semester"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/timetable"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+var _ IPlanService = (*PlanService)(nil)
+
+type IPlanService interface {
+	GetPlanTimetable(ctx context.Context, req *dto.GetPlanTimetableReq) (*dto.GetPlanTimetableResp, error)
+	AddPlanOffering(ctx context.Context, req *dto.AddPlanOfferingReq) (*dto.AddPlanOfferingResp, error)
+	RemovePlanOffering(ctx context.Context, req *dto.RemovePlanOfferingReq) (*dto.RemovePlanOfferingResp, error)
+	ExportPlanICS(ctx context.Context, req *dto.ExportPlanICSReq, w io.Writer) error
+}
+
+type PlanService struct {
+	PlanRepo                *repo.PlanRepo
+	CourseRepo              *repo.CourseRepo
+	CourseOfferingRepo      *repo.CourseOfferingRepo
+	CourseOfferingAssembler *assembler.CourseOfferingAssembler
+}
+
+var PlanServiceSet = wire.NewSet(
+	wire.Struct(new(PlanService), "*"),
+	wire.Bind(new(IPlanService), new(*PlanService)),
+)
+
+// planItem 计划中的一个开设及其所属课程
+type planItem struct {
+	offering *model.CourseOffering
+	course   *model.Course
+}
+
+// GetPlanTimetable 查询用户某学期选课计划的周课表和时间冲突
+func (s *PlanService) GetPlanTimetable(ctx context.Context, req *dto.GetPlanTimetableReq) (*dto.GetPlanTimetableResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	term, err := s.planSemester(req.Semester)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.loadPlan(ctx, userId, term)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings := make([]*dto.PlanOfferingVO, 0, len(items))
+	for _, item := range items {
+		offerings = append(offerings, &dto.PlanOfferingVO{
+			CourseName: item.course.Name,
+			CourseCode: item.course.Code,
+			Offering:   s.CourseOfferingAssembler.ToCourseOfferingVO(ctx, item.offering),
+		})
+	}
+	entries := planEntries(items)
+	periods, _ := schedulePeriods(ctx)
+
+	return &dto.GetPlanTimetableResp{
+		Resp:      dto.Success(),
+		Semester:  term,
+		Periods:   periods,
+		Offerings: offerings,
+		Grid:      timetable.Grid(entries),
+		Conflicts: toPlanConflictVOs(timetable.Conflicts(entries)),
+	}, nil
+}
+
+// AddPlanOffering 将开设加入其所在学期的选课计划，返回与计划中其他开设的时间冲突
+func (s *PlanService) AddPlanOffering(ctx context.Context, req *dto.AddPlanOfferingReq) (*dto.AddPlanOfferingResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	offering, err := s.CourseOfferingRepo.FindByID(ctx, req.OfferingID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindByID] error: %v, offeringId: %s", err, req.OfferingID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+	}
+	if offering == nil || offering.Deleted {
+		return nil, errorx.New(errno.ErrOfferingNotFound,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+	}
+
+	items, err := s.loadPlan(ctx, userId, offering.Semester)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(items, func(item *planItem) bool { return item.offering.ID == offering.ID }) {
+		if len(items) >= consts.PlanMaxOfferings {
+			return nil, errorx.New(errno.ErrPlanTooManyOfferings, errorx.KV("limit", strconv.Itoa(consts.PlanMaxOfferings)))
+		}
+		course, err := s.CourseRepo.FindByID(ctx, offering.CourseID)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v, courseId: %s", err, offering.CourseID)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed,
+				errorx.KV("key", consts.CourseID), errorx.KV("value", offering.CourseID))
+		}
+		if course == nil || course.Deleted {
+			return nil, errorx.New(errno.ErrCourseNotFound,
+				errorx.KV("key", consts.CourseID), errorx.KV("value", offering.CourseID))
+		}
+		if err = s.PlanRepo.AddOffering(ctx, userId, offering.Semester, offering.ID); err != nil {
+			logs.CtxErrorf(ctx, "[PlanRepo] [AddOffering] error: %v, offeringId: %s", err, offering.ID)
+			return nil, errorx.WrapByCode(err, errno.ErrPlanUpdateFailed, errorx.KV("semester", offering.Semester))
+		}
+		items = append(items, &planItem{offering: offering, course: course})
+	}
+
+	// 只返回与新加入开设相关的冲突
+	conflicts := make([]*timetable.Conflict, 0)
+	for _, c := range timetable.Conflicts(planEntries(items)) {
+		if c.A == offering.ID || c.B == offering.ID {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	return &dto.AddPlanOfferingResp{
+		Resp:      dto.Success(),
+		Semester:  offering.Semester,
+		Conflicts: toPlanConflictVOs(conflicts),
+	}, nil
+}
+
+// RemovePlanOffering 从选课计划中移除开设，已删除的开设也可以移除
+func (s *PlanService) RemovePlanOffering(ctx context.Context, req *dto.RemovePlanOfferingReq) (*dto.RemovePlanOfferingResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	offering, err := s.CourseOfferingRepo.FindByID(ctx, req.OfferingID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindByID] error: %v, offeringId: %s", err, req.OfferingID)
+		return nil, errorx.WrapByCode(err, errno.ErrOfferingFindFailed,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+	}
+	if offering == nil {
+		return nil, errorx.New(errno.ErrOfferingNotFound,
+			errorx.KV("key", consts.OfferingID), errorx.KV("value", req.OfferingID))
+	}
+
+	plan, err := s.PlanRepo.FindByUserID(ctx, userId, offering.Semester)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[PlanRepo] [FindByUserID] error: %v, semester: %s", err, offering.Semester)
+		return nil, errorx.WrapByCode(err, errno.ErrPlanFindFailed, errorx.KV("semester", offering.Semester))
+	}
+	if plan == nil || !slices.Contains(plan.OfferingIDs, offering.ID) {
+		return &dto.RemovePlanOfferingResp{Resp: dto.Success(), Removed: false}, nil
+	}
+	if err = s.PlanRepo.RemoveOffering(ctx, userId, offering.Semester, offering.ID); err != nil {
+		logs.CtxErrorf(ctx, "[PlanRepo] [RemoveOffering] error: %v, offeringId: %s", err, offering.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrPlanUpdateFailed, errorx.KV("semester", offering.Semester))
+	}
+
+	return &dto.RemovePlanOfferingResp{Resp: dto.Success(), Removed: true}, nil
+}
+
+// ExportPlanICS 将选课计划展开为每次上课的日历事件，以 iCalendar 格式写入 w
+// 节次时间和学期第一周来自配置，超出配置节次的上课时间被跳过
+func (s *PlanService) ExportPlanICS(ctx context.Context, req *dto.ExportPlanICSReq, w io.Writer) error {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return errorx.New(errno.ErrUserNotLogin)
+	}
+
+	term, err := s.planSemester(req.Semester)
+	if err != nil {
+		return err
+	}
+	items, err := s.loadPlan(ctx, userId, term)
+	if err != nil {
+		return err
+	}
+
+	_, periods := schedulePeriods(ctx)
+	weekOne := semesterWeekOne(ctx, term)
+	events := make([]*timetable.Event, 0)
+	for _, item := range items {
+		vo := s.CourseOfferingAssembler.ToCourseOfferingVO(ctx, item.offering)
+		teachers := make([]string, 0, len(vo.Teachers))
+		for _, t := range vo.Teachers {
+			teachers = append(teachers, t.Name)
+		}
+		var desc []string
+		if len(teachers) > 0 {
+			desc = append(desc, "教师："+strings.Join(teachers, "、"))
+		}
+		if vo.Campus != "" {
+			desc = append(desc, "校区："+vo.Campus)
+		}
+
+		for i, slot := range item.offering.Slots {
+			occurrences, err := timetable.Occurrences(slot, weekOne, periods)
+			if err != nil {
+				logs.CtxErrorf(ctx, "[PlanService] [ExportPlanICS] skip slot %d of offering %s: %v", i, item.offering.ID, err)
+				continue
+			}
+			for _, o := range occurrences {
+				events = append(events, &timetable.Event{
+					UID:         fmt.Sprintf("%s-%d-%d@%s", item.offering.ID, i, o.Week, consts.PlanICSUIDDomain),
+					Summary:     item.course.Name,
+					Location:    slot.Location,
+					Description: strings.Join(desc, "\n"),
+					Start:       o.Start,
+					End:         o.End,
+				})
+			}
+		}
+	}
+
+	if err = timetable.WriteICS(w, term+" 课表", events, time.Now()); err != nil {
+		logs.CtxErrorf(ctx, "[PlanService] [ExportPlanICS] write error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrPlanExportFailed, errorx.KV("semester", term))
+	}
+	return nil
+}
+
+// planSemester 校验请求的学期，为空时返回当前学期
+func (s *PlanService) planSemester(term string) (string, error) {
+	if term == "" {
+		return semester.Of(time.Now().In(scheduleLocation())), nil
+	}
+	if !semester.Valid(term) {
+		return "", errorx.New(errno.ErrPlanInvalidParam,
+			errorx.KV("key", consts.Semester), errorx.KV("value", term))
+	}
+	return term, nil
+}
+
+// loadPlan 按加入顺序查询计划中仍然有效的开设及其课程，已删除的开设和课程被跳过
+func (s *PlanService) loadPlan(ctx context.Context, userId, term string) ([]*planItem, error) {
+	plan, err := s.PlanRepo.FindByUserID(ctx, userId, term)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[PlanRepo] [FindByUserID] error: %v, semester: %s", err, term)
+		return nil, errorx.WrapByCode(err, errno.ErrPlanFindFailed, errorx.KV("semester", term))
+	}
+	if plan == nil || len(plan.OfferingIDs) == 0 {
+		return []*planItem{}, nil
+	}
+
+	offerings, err := s.CourseOfferingRepo.FindByIDs(ctx, plan.OfferingIDs)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [FindByIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrPlanFindFailed, errorx.KV("semester", term))
+	}
+	offeringByID := make(map[string]*model.CourseOffering, len(offerings))
+	courseIds := make([]string, 0, len(offerings))
+	for _, o := range offerings {
+		offeringByID[o.ID] = o
+		courseIds = append(courseIds, o.CourseID)
+	}
+	courses, err := s.CourseRepo.FindByIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrPlanFindFailed, errorx.KV("semester", term))
+	}
+	courseByID := make(map[string]*model.Course, len(courses))
+	for _, c := range courses {
+		courseByID[c.ID] = c
+	}
+
+	items := make([]*planItem, 0, len(plan.OfferingIDs))
+	for _, id := range plan.OfferingIDs {
+		offering, ok := offeringByID[id]
+		if !ok {
+			continue
+		}
+		if course, ok := courseByID[offering.CourseID]; ok {
+			items = append(items, &planItem{offering: offering, course: course})
+		}
+	}
+	return items, nil
+}
+
+// planEntries 将计划转换为课表条目
+func planEntries(items []*planItem) []*timetable.Entry {
+	entries := make([]*timetable.Entry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, &timetable.Entry{ID: item.offering.ID, Slots: item.offering.Slots})
+	}
+	return entries
+}
+
+func toPlanConflictVOs(conflicts []*timetable.Conflict) []*dto.PlanConflictVO {
+	vos := make([]*dto.PlanConflictVO, 0, len(conflicts))
+	for _, c := range conflicts {
+		vos = append(vos, &dto.PlanConflictVO{
+			OfferingIDs: []string{c.A, c.B},
+			Day:         c.Day,
+			StartPeriod: c.StartPeriod,
+			EndPeriod:   c.EndPeriod,
+			Weeks:       c.Weeks,
+		})
+	}
+	return vos
+}
+
+// scheduleLocation 课表使用的时区，加载失败时使用本地时区
+func scheduleLocation() *time.Location {
+	loc, err := time.LoadLocation(consts.StatsTimezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// schedulePeriods 返回配置的节次时间，未配置或配置不合法时使用默认作息
+func schedulePeriods(ctx context.Context) ([]string, []timetable.Period) {
+	specs := config.GetConfig().Schedule.PeriodTimes
+	if len(specs) > 0 {
+		periods, err := timetable.ParsePeriods(specs)
+		if err == nil {
+			return specs, periods
+		}
+		logs.CtxErrorf(ctx, "[PlanService] [schedulePeriods] invalid Schedule.PeriodTimes: %v", err)
+	}
+	periods, _ := timetable.ParsePeriods(timetable.DefaultPeriods)
+	return timetable.DefaultPeriods, periods
+}
+
+// semesterWeekOne 返回学期第一周周一，优先使用配置的校历
+func semesterWeekOne(ctx context.Context, term string) time.Time {
+	loc := scheduleLocation()
+	if start, ok := config.GetConfig().Schedule.SemesterStarts[term]; ok {
+		day, err := time.ParseInLocation(consts.PlanDateLayout, start, loc)
+		if err == nil {
+			return day
+		}
+		logs.CtxErrorf(ctx, "[PlanService] [semesterWeekOne] invalid Schedule.SemesterStarts[%s]: %v", term, err)
+	}
+	day, _ := semester.WeekOne(term, loc)
+	return day
+}
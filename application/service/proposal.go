@@ -16,15 +16,18 @@ package service
 
 import (
 	"context"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
@@ -50,18 +53,26 @@ type IProposalService interface {
 	ApproveProposal(ctx context.Context, req *dto.ToggleProposalReq) (*dto.ToggleProposalResp, error)
 	RevokeProposal(ctx context.Context, req *dto.RevokeProposalReq) (*dto.RevokeProposalResp, error)
 	RejectProposal(ctx context.Context, req *dto.RejectProposalReq) (*dto.RejectProposalResp, error)
+	CreateProposalComment(ctx context.Context, req *dto.CreateProposalCommentReq) (*dto.CreateProposalCommentResp, error)
+	DeleteProposalComment(ctx context.Context, req *dto.DeleteProposalCommentReq) (*dto.DeleteProposalCommentResp, error)
+	RequestProposalInfo(ctx context.Context, req *dto.RequestProposalInfoReq) (*dto.RequestProposalInfoResp, error)
 }
 
 type ProposalService struct {
-	CourseRepo        *repo.CourseRepo
-	CourseAssembler   *assembler.CourseAssembler
-	ProposalRepo      *repo.ProposalRepo
-	ProposalAssembler *assembler.ProposalAssembler
-	LikeRepo          *repo.LikeRepo
-	LikeCache         *cache.LikeCache
-	UserRepo          *repo.UserRepo
-	TeacherRepo       *repo.TeacherRepo
-	ChangeLogService  IChangeLogService
+	CourseRepo          *repo.CourseRepo
+	CourseAssembler     *assembler.CourseAssembler
+	ProposalRepo        *repo.ProposalRepo
+	ProposalAssembler   *assembler.ProposalAssembler
+	LikeRepo            *repo.LikeRepo
+	LikeCache           *cache.LikeCache
+	ProposalCache       *cache.ProposalCache
+	UserRepo            *repo.UserRepo
+	TeacherRepo         *repo.TeacherRepo
+	ChangeLogService    IChangeLogService
+	NotificationService INotificationService
+	EventBus            *eventbus.Bus
+	SearchIndexer       *SearchIndexer
+	ProposalCommentRepo *repo.ProposalCommentRepo
 }
 
 var ProposalServiceSet = wire.NewSet(
@@ -77,20 +88,20 @@ func (s *ProposalService) CreateProposal(ctx context.Context, req *dto.CreatePro
 		return nil, errorx.New(errno.ErrUserNotLogin)
 	}
 
+	// 指定课程ID时为修改现有课程的提案，指定教师ID时为更正教师信息的提案
+	if req.CourseID != "" {
+		return s.createChangeProposal(ctx, userId, req)
+	}
+	if req.TeacherID != "" {
+		return s.createTeacherProposal(ctx, userId, req)
+	}
+	if req.Course == nil {
+		return nil, errorx.New(errno.ErrProposalCourseRequired)
+	}
+
 	// 校验校区合法性
-	if req.Course != nil {
-		for _, campusName := range req.Course.Campuses {
-			campusName = strings.TrimSpace(campusName)
-			if campusName == "" {
-				continue
-			}
-			if mapping.Data.GetCampusIDByName(campusName) == 0 {
-				return nil, errorx.New(errno.ErrProposalInvalidCampus,
-					errorx.KV("key", consts.Campuses),
-					errorx.KV("value", campusName),
-				)
-			}
-		}
+	if err := validateCampuses(req.Course.Campuses); err != nil {
+		return nil, err
 	}
 
 	// 转换为 proposalCourseModel，不执行自动注册
@@ -140,9 +151,8 @@ func (s *ProposalService) CreateProposal(ctx context.Context, req *dto.CreatePro
 		)
 	}
 
-	// 1. 构建数据库模型
 	now := time.Now()
-	proposalVO := &dto.ProposalVO{
+	return s.saveProposal(ctx, userId, &dto.ProposalVO{
 		ID:        primitive.NewObjectID().Hex(),
 		UserID:    userId,
 		Title:     req.Title,
@@ -150,10 +160,15 @@ func (s *ProposalService) CreateProposal(ctx context.Context, req *dto.CreatePro
 		Status:    consts.ProposalStatusPending,
 		Deleted:   false,
 		Course:    req.Course,
+		Type:      consts.ProposalTypeAdd,
 		CreatedAt: now,
 		UpdatedAt: now,
-	}
+	})
+}
 
+// saveProposal 保存新提案并发布提案创建事件
+func (s *ProposalService) saveProposal(ctx context.Context, userId string, proposalVO *dto.ProposalVO) (*dto.CreateProposalResp, error) {
+	// 1. 构建数据库模型
 	proposal, err := s.ProposalAssembler.ToProposalDB(ctx, proposalVO)
 	if err != nil {
 		logs.CtxErrorf(ctx, "[ProposalAssembler] [ToProposalDB] error: %v", err)
@@ -165,7 +180,7 @@ func (s *ProposalService) CreateProposal(ctx context.Context, req *dto.CreatePro
 	// 2. 保存提案到数据库
 	if err = s.ProposalRepo.Insert(ctx, proposal); err != nil {
 		logs.CtxErrorf(ctx, "[ProposalRepo] [Insert] error: %v", err)
-		return nil, errorx.WrapByCode(err, errno.ErrProposalCreateFailed, errorx.KV("name", req.Course.Name))
+		return nil, errorx.WrapByCode(err, errno.ErrProposalCreateFailed, errorx.KV("name", proposalVO.Title))
 	}
 
 	// 3. 转换为 VO (包含点赞信息)
@@ -176,15 +191,8 @@ func (s *ProposalService) CreateProposal(ctx context.Context, req *dto.CreatePro
 			errorx.KV("src", "database proposal"), errorx.KV("dst", "proposal vo"))
 	}
 
-	if _, err = s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
-		TargetID:     proposal.ID,
-		TargetType:   consts.TargetTypeProposal,
-		Action:       consts.ActionTypeCreateProposal,
-		Content:      "创建提案",
-		UpdateSource: consts.UpdateSourceUser,
-		ProposalID:   proposal.ID,
-	}); err != nil {
-		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", err, proposal.ID)
+	if err = s.EventBus.Publish(ctx, event.ProposalCreated{Proposal: proposal}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, proposalId: %s", err, proposal.ID)
 	}
 
 	return &dto.CreateProposalResp{
@@ -370,30 +378,30 @@ func (s *ProposalService) GetProposal(ctx context.Context, req *dto.GetProposalR
 	// 贡献值仅创建者可见（统一过滤）
 	filterContributionVisibility([]*dto.ProposalVO{vo}, userId)
 
-	// 填充最终课程信息：仅提案状态为已通过，且当前用户为提案创建者或管理员时可见
+	// 管理员查询失败不影响主流程，按非管理员处理；提案创建者无需查询
 	isCreator := proposal.UserID == userId
-	if vo.Status == consts.ProposalStatusApproved {
-		isAdmin := false
-		if !isCreator {
-			isAdmin, err = s.UserRepo.IsAdminByID(ctx, userId)
-			if err != nil {
-				// 管理员查询失败不影响主流程，按非管理员处理
-				logs.CtxWarnf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
-				isAdmin = false
-			}
-		}
-		if isCreator || isAdmin {
-			course, err := s.CourseRepo.FindByProposalID(ctx, proposal.ID)
+	isAdmin := s.canSeeInternalNotes(ctx, proposal, userId)
+
+	// 填充讨论：内部备注仅非创建者的管理员可见
+	comments, err := s.ProposalCommentRepo.FindManyByProposalID(ctx, proposal.ID, isAdmin)
+	if err != nil {
+		logs.CtxWarnf(ctx, "[ProposalCommentRepo] [FindManyByProposalID] error: %v, proposalId: %s", err, proposal.ID)
+	} else {
+		vo.Comments = s.ProposalAssembler.ToProposalCommentVOTree(comments, proposal.UserID)
+	}
+
+	// 填充最终课程信息：仅提案状态为已通过，且当前用户为提案创建者或管理员时可见
+	if vo.Status == consts.ProposalStatusApproved && (isCreator || isAdmin) {
+		course, err := s.findFinalCourse(ctx, vo)
+		if err != nil {
+			// 查询失败不影响主流程，FinalCourse 保持为空
+			logs.CtxWarnf(ctx, "[ProposalService] [findFinalCourse] error: %v, proposalId: %s", err, proposal.ID)
+		} else if course != nil {
+			finalCourse, err := s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, course)
 			if err != nil {
-				// 查询失败不影响主流程，FinalCourse 保持为空
-				logs.CtxWarnf(ctx, "[CourseRepo] [FindByProposalID] error: %v, proposalId: %s", err, proposal.ID)
-			} else if course != nil {
-				finalCourse, err := s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, course)
-				if err != nil {
-					logs.CtxWarnf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, proposalId: %s", err, proposal.ID)
-				} else {
-					vo.FinalCourse = finalCourse
-				}
+				logs.CtxWarnf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, proposalId: %s", err, proposal.ID)
+			} else {
+				vo.FinalCourse = finalCourse
 			}
 		}
 	}
@@ -468,7 +476,7 @@ func (s *ProposalService) DeleteProposal(ctx context.Context, req *dto.DeletePro
 	}, nil
 }
 
-// UpdateProposal 更新提案
+// UpdateProposal 作者更新自己待审核或待补充信息的提案
 func (s *ProposalService) UpdateProposal(ctx context.Context, req *dto.UpdateProposalReq) (*dto.UpdateProposalResp, error) {
 	// 鉴权
 	userId, ok := ctx.Value(consts.CtxUserID).(string)
@@ -487,16 +495,40 @@ func (s *ProposalService) UpdateProposal(ctx context.Context, req *dto.UpdatePro
 		return nil, errorx.New(errno.ErrProposalNotFound, errorx.KV("key", consts.ReqProposalID), errorx.KV("value", req.ProposalID))
 	}
 
+	// 权限检查：仅允许作者修改自己的提案
+	if proposal.UserID != userId {
+		return nil, errorx.New(errno.ErrUserNotOwner, errorx.KV("id", userId))
+	}
+
+	// 状态检查：审批后提案快照已生效，只有待审核和待补充信息的提案允许修改
+	if !slices.Contains(openProposalStatusIDs(), proposal.Status) {
+		return nil, errorx.New(errno.ErrProposalStatusNotPending, errorx.KV("proposalId", proposal.ID))
+	}
+
 	// 更新提案字段
 	proposal.Title = req.Title
 	proposal.Content = req.Content
-	courseModel, err := s.CourseAssembler.ToProposalCourseDB(ctx, req.Course)
-	if err != nil {
-		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
-			errorx.KV("src", "course vo"), errorx.KV("dst", "proposal course model"),
-		)
+	switch proposal.Type {
+	case consts.ProposalTypeChange:
+		if err = s.updateChangeProposal(ctx, proposal, req); err != nil {
+			return nil, err
+		}
+	case consts.ProposalTypeTeacher:
+		if err = s.updateTeacherProposal(ctx, proposal, req); err != nil {
+			return nil, err
+		}
+	default:
+		if req.Course == nil {
+			return nil, errorx.New(errno.ErrProposalCourseRequired)
+		}
+		courseModel, err := s.CourseAssembler.ToProposalCourseDB(ctx, req.Course)
+		if err != nil {
+			return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+				errorx.KV("src", "course vo"), errorx.KV("dst", "proposal course model"),
+			)
+		}
+		proposal.Course = courseModel
 	}
-	proposal.Course = courseModel
 	proposal.UpdatedAt = time.Now()
 
 	// 执行更新
@@ -516,6 +548,9 @@ func (s *ProposalService) UpdateProposal(ctx context.Context, req *dto.UpdatePro
 		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", err, proposal.ID)
 	}
 
+	// 作者补充修改后，待补充信息的提案重新进入待审核
+	s.resumeReview(ctx, proposal, "作者更新提案，重新进入待审核")
+
 	return &dto.UpdateProposalResp{
 		Resp:       dto.Success(),
 		ProposalID: proposal.ID,
@@ -530,6 +565,24 @@ func (s *ProposalService) GetProposalSuggestions(ctx context.Context, req *dto.G
 		return nil, errorx.New(errno.ErrUserNotLogin)
 	}
 
+	// 内存索引可用时优先使用索引
+	if s.SearchIndexer.Ready() {
+		pageNum, pageSize := req.PageParam.UnWrap()
+		hits, _ := s.SearchIndexer.Search(req.Keyword, consts.SearchDocTypeProposal, pageNum, pageSize)
+		vos := make([]*dto.ProposalSuggestionsVO, 0, len(hits))
+		for _, hit := range hits {
+			vos = append(vos, &dto.ProposalSuggestionsVO{
+				ID:        hit.ID,
+				Title:     hit.Text,
+				Highlight: hit.Highlight,
+			})
+		}
+		return &dto.GetProposalSuggestionsResp{
+			Resp:        dto.Success(),
+			Suggestions: vos,
+		}, nil
+	}
+
 	// 查询提案建议
 	proposals, _, err := s.ProposalRepo.GetSuggestionsByTitle(ctx, req.Keyword, req.PageParam)
 	if err != nil {
@@ -648,10 +701,18 @@ func (s *ProposalService) GetProposalFieldSuggestions(ctx context.Context, req *
 				errorx.KV("keyword", req.Keyword))
 		}
 		for _, teacher := range teachers {
+			// 标签带上院系，便于区分同名教师
+			label := teacher.Name
+			if department := mapping.Data.GetDepartmentNameByID(teacher.Department); department != "" {
+				label += " - " + department
+			}
+			if teacher.Title != "" {
+				label += " - " + teacher.Title
+			}
 			suggestions = append(suggestions, &dto.FieldSuggestionVO{
 				ID:    teacher.ID,
 				Value: teacher.Name,
-				Label: teacher.Name + " - " + teacher.Title,
+				Label: label,
 			})
 		}
 		_ = total
@@ -702,11 +763,11 @@ func (s *ProposalService) GetMyProposals(ctx context.Context, req *dto.GetMyProp
 			continue
 		}
 
-		// 根据提案 ID 查询关联的正式课程（仅返回未删除的课程）
-		course, err := s.CourseRepo.FindByProposalID(ctx, vo.ID)
+		// 查询关联的正式课程（仅返回未删除的课程）
+		course, err := s.findFinalCourse(ctx, vo)
 		if err != nil {
 			// 查询失败不影响主流程，FinalCourse 保持为空
-			logs.CtxWarnf(ctx, "[CourseRepo] [FindByProposalID] error: %v, proposalId: %s", err, vo.ID)
+			logs.CtxWarnf(ctx, "[ProposalService] [findFinalCourse] error: %v, proposalId: %s", err, vo.ID)
 			continue
 		}
 		if course == nil {
@@ -769,19 +830,35 @@ func (s *ProposalService) ApproveProposal(ctx context.Context, req *dto.TogglePr
 		return nil, errorx.New(errno.ErrProposalAlreadyProcessed, errorx.KV("key", consts.ReqProposalID), errorx.KV("value", req.ProposalID))
 	}
 
-	// 确定最终课程：管理员确认的 finalCourse 优先，未传则用提案原始课程兜底
-	courseVO, err := s.resolveFinalCourse(ctx, req, proposal)
-	if err != nil {
-		return nil, err
-	}
-	if courseVO == nil {
-		logs.CtxErrorf(ctx, "[ProposalService] [ApproveProposal] course is nil, proposalId: %s", req.ProposalID)
-		return nil, errorx.New(errno.ErrCourseCvtFailed, errorx.KV("proposalId", req.ProposalID))
-	}
+	var courseVO *dto.ProposalCourseVO
+	var finalChange *dto.CourseChangeVO
+	var finalTeacherChange *dto.TeacherChangeVO
+	switch proposal.Type {
+	case consts.ProposalTypeChange:
+		// 修改提案：将修改应用到现有课程
+		if finalChange, courseVO, err = s.approveChangeProposal(ctx, req, proposal); err != nil {
+			return nil, err
+		}
+	case consts.ProposalTypeTeacher:
+		// 教师更正提案：将更正应用到教师
+		if finalTeacherChange, err = s.approveTeacherProposal(ctx, req, proposal); err != nil {
+			return nil, err
+		}
+	default:
+		// 确定最终课程：管理员确认的 finalCourse 优先，未传则用提案原始课程兜底
+		courseVO, err = s.resolveFinalCourse(ctx, req, proposal)
+		if err != nil {
+			return nil, err
+		}
+		if courseVO == nil {
+			logs.CtxErrorf(ctx, "[ProposalService] [ApproveProposal] course is nil, proposalId: %s", req.ProposalID)
+			return nil, errorx.New(errno.ErrCourseCvtFailed, errorx.KV("proposalId", req.ProposalID))
+		}
 
-	// 课程创建或恢复（遵循一对一原则，先创建课程再改状态保证一致性）
-	if err = s.createOrRestoreCourse(ctx, proposal, courseVO); err != nil {
-		return nil, err
+		// 课程创建或恢复（遵循一对一原则，先创建课程再改状态保证一致性）
+		if err = s.createOrRestoreCourse(ctx, proposal, courseVO); err != nil {
+			return nil, err
+		}
 	}
 
 	// 更新提案状态为已通过
@@ -795,36 +872,48 @@ func (s *ProposalService) ApproveProposal(ctx context.Context, req *dto.TogglePr
 		return nil, errorx.New(errno.ErrProposalUpdateFailed, errorx.KV("proposalId", req.ProposalID))
 	}
 
-	// 结算贡献值（失败不影响审批主流程，仅记录错误日志）
-	s.settleContribution(ctx, proposal, courseVO)
+	// 贡献值结算、变更日志与通知由事件订阅者处理
+	if err = s.EventBus.Publish(ctx, event.ProposalApproved{
+		Proposal:    proposal,
+		FinalCourse: courseVO,
+		FinalChange: finalChange,
+		ReviewerID:  userId,
 
-	// 记录变更日志
-	if _, err = s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
-		TargetID:     req.ProposalID,
-		TargetType:   consts.TargetTypeProposal,
-		Action:       consts.ActionTypeApproveProposal,
-		Content:      "审批提案：通过",
-		UpdateSource: consts.UpdateSourceAdmin,
-		ProposalID:   req.ProposalID,
+		FinalTeacherChange: finalTeacherChange,
 	}); err != nil {
-		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", err, req.ProposalID)
-	}
-
-	// 获取剩余待处理提案数量
-	pendingStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending)
-	_, pendingCount, err := s.ProposalRepo.FindManyByStatus(ctx, &dto.PageParam{Page: 1, PageSize: 1}, pendingStatusID)
-	if err != nil {
-		logs.CtxWarnf(ctx, "[ProposalRepo] [FindManyByStatus] error: %v", err)
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, proposalId: %s", err, req.ProposalID)
 	}
 
 	// 返回成功响应
 	return &dto.ToggleProposalResp{
 		Resp:        dto.Success(),
 		Proposal:    true,
-		ProposalCnt: pendingCount,
+		ProposalCnt: s.countPendingProposals(ctx),
 	}, nil
 }
 
+// countPendingProposals 获取剩余待审核提案数，优先读缓存；缓存由计数订阅者在提案状态变化时清除
+func (s *ProposalService) countPendingProposals(ctx context.Context) int64 {
+	count, ok, err := s.ProposalCache.GetPendingCount(ctx)
+	if err == nil && ok {
+		return count
+	}
+	if err != nil {
+		logs.CtxWarnf(ctx, "[ProposalCache] [GetPendingCount] error: %v", err)
+	}
+
+	pendingStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending)
+	_, count, err = s.ProposalRepo.FindManyByStatus(ctx, &dto.PageParam{Page: 1, PageSize: 1}, pendingStatusID)
+	if err != nil {
+		logs.CtxWarnf(ctx, "[ProposalRepo] [FindManyByStatus] error: %v", err)
+		return 0
+	}
+	if err = s.ProposalCache.SetPendingCount(ctx, count, consts.CacheProposalPendingTTL); err != nil {
+		logs.CtxWarnf(ctx, "[ProposalCache] [SetPendingCount] error: %v", err)
+	}
+	return count
+}
+
 // resolveFinalCourse 确定审批使用的最终课程信息，管理员传入的 finalCourse 优先
 func (s *ProposalService) resolveFinalCourse(ctx context.Context, req *dto.ToggleProposalReq, proposal *model.Proposal) (*dto.ProposalCourseVO, error) {
 	if req.FinalCourse != nil {
@@ -855,7 +944,7 @@ func (s *ProposalService) createOrRestoreCourse(ctx context.Context, proposal *m
 			restoredCourse, cvtErr := s.CourseAssembler.ToCourseDBFromProposalCourse(ctx, courseVO)
 			if cvtErr != nil {
 				logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDBFromProposalCourse] error: %v", cvtErr)
-				return errorx.WrapByCode(cvtErr, errno.ErrCourseCvtFailed)
+				return wrapCourseCvtErr(cvtErr)
 			}
 			if restoredCourse == nil {
 				return errorx.New(errno.ErrCourseCvtFailed)
@@ -898,7 +987,7 @@ func (s *ProposalService) createOrRestoreCourse(ctx context.Context, proposal *m
 	course, err := s.CourseAssembler.ToCourseDBFromProposalCourse(ctx, courseVO)
 	if err != nil {
 		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDBFromProposalCourse] error: %v", err)
-		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+		return wrapCourseCvtErr(err)
 	}
 	if course == nil {
 		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDBFromProposalCourse] course is nil")
@@ -915,32 +1004,11 @@ func (s *ProposalService) createOrRestoreCourse(ctx context.Context, proposal *m
 		logs.CtxErrorf(ctx, "[CourseRepo] [Insert] error: %v", err)
 		return errorx.WrapByCode(err, errno.ErrCourseCreateFailed, errorx.KV("name", course.Name))
 	}
-	return nil
-}
-
-// settleContribution 结算提案创建者的贡献值，结算失败仅记录日志不影响主流程
-func (s *ProposalService) settleContribution(ctx context.Context, proposal *model.Proposal, courseVO *dto.ProposalCourseVO) {
-	originalVO, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Course)
-	if err != nil {
-		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v, proposalId: %s", err, proposal.ID)
-		return
-	}
-
-	score := calcContributionScore(originalVO, courseVO)
-	if score <= 0 {
-		return
-	}
-
-	// 原子增加用户贡献值
-	if err := s.UserRepo.IncrementContribution(ctx, proposal.UserID, score); err != nil {
-		logs.CtxErrorf(ctx, "[UserRepo] [IncrementContribution] error: %v, userId: %s", err, proposal.UserID)
-		return
-	}
 
-	// 将得分写入提案记录
-	if err := s.ProposalRepo.UpdateContributionByID(ctx, proposal.ID, score); err != nil {
-		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateContributionByID] error: %v, proposalId: %s", err, proposal.ID)
+	if err = s.EventBus.Publish(ctx, event.CourseCreated{Course: course, ProposalID: proposal.ID}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, courseId: %s", err, course.ID)
 	}
+	return nil
 }
 
 // rollbackContribution 撤回审批通过时扣回用户的贡献值，并清空提案上的贡献值记录
@@ -969,6 +1037,13 @@ func (s *ProposalService) rollbackContribution(ctx context.Context, proposal *mo
 
 // recalcContribution 重新计算提案的贡献值得分（兜底，对比提案原始课程与关联的最终课程）
 func (s *ProposalService) recalcContribution(ctx context.Context, proposal *model.Proposal) int64 {
+	switch proposal.Type {
+	case consts.ProposalTypeChange:
+		return s.recalcChangeContribution(ctx, proposal)
+	case consts.ProposalTypeTeacher:
+		return s.recalcTeacherContribution(proposal)
+	}
+
 	originalVO, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Course)
 	if err != nil {
 		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v, proposalId: %s", err, proposal.ID)
@@ -1116,6 +1191,14 @@ func filterContributionVisibility(vos []*dto.ProposalVO, userId string) {
 	}
 }
 
+// openProposalStatusIDs 未结提案的状态ID（待审核、待补充信息）
+func openProposalStatusIDs() []int32 {
+	return []int32{
+		mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending),
+		mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusNeedsInfo),
+	}
+}
+
 // RevokeProposal 撤回提案操作（通过/拒绝）
 func (s *ProposalService) RevokeProposal(ctx context.Context, req *dto.RevokeProposalReq) (*dto.RevokeProposalResp, error) {
 	// 鉴权
@@ -1162,16 +1245,21 @@ func (s *ProposalService) RevokeProposal(ctx context.Context, req *dto.RevokePro
 			return nil, errorx.New(errno.ErrProposalStatusNotApproved, errorx.KV("proposalId", req.ProposalID))
 		}
 
-		// 删除关联课程（通过提案ID查找，课程已被手动删除则仅回退提案状态）
-		associatedCourse, findErr := s.CourseRepo.FindByProposalIDIncludeDeleted(ctx, req.ProposalID)
-		if findErr != nil {
-			logs.CtxErrorf(ctx, "[CourseRepo] [FindByProposalID] error: %v, proposalId: %s", findErr, req.ProposalID)
-			return nil, errorx.WrapByCode(findErr, errno.ErrCourseNotFoundCannotRevoke)
-		}
-		if associatedCourse != nil && !associatedCourse.Deleted {
-			if delErr := s.CourseRepo.SoftDeleteByID(ctx, associatedCourse.ID); delErr != nil {
-				logs.CtxErrorf(ctx, "[CourseRepo] [SoftDeleteByID] error: %v, courseId: %s", delErr, associatedCourse.ID)
-				return nil, errorx.WrapByCode(delErr, errno.ErrProposalUpdateFailed, errorx.KV("courseId", associatedCourse.ID))
+		switch proposal.Type {
+		case consts.ProposalTypeChange:
+			// 修改提案：将课程恢复为审批前的状态
+			if revertErr := s.revokeChangeProposal(ctx, proposal); revertErr != nil {
+				return nil, revertErr
+			}
+		case consts.ProposalTypeTeacher:
+			// 教师更正提案：将教师恢复为审批前的信息
+			if revertErr := s.revokeTeacherProposal(ctx, proposal); revertErr != nil {
+				return nil, revertErr
+			}
+		default:
+			// 新增提案：删除或解除关联课程
+			if revertErr := s.revokeCreatedCourse(ctx, proposal); revertErr != nil {
+				return nil, revertErr
 			}
 		}
 
@@ -1188,18 +1276,6 @@ func (s *ProposalService) RevokeProposal(ctx context.Context, req *dto.RevokePro
 			return nil, errorx.New(errno.ErrProposalUpdateFailed, errorx.KV("proposalId", req.ProposalID))
 		}
 
-		// 记录变更日志
-		if _, logErr := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
-			TargetID:     req.ProposalID,
-			TargetType:   consts.TargetTypeProposal,
-			Action:       consts.ActionTypeRevokeApproveProposal,
-			Content:      "撤回提案审批：通过→待审核",
-			UpdateSource: consts.UpdateSourceAdmin,
-			ProposalID:   req.ProposalID,
-		}); logErr != nil {
-			logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", logErr, req.ProposalID)
-		}
-
 	case consts.RevokeActionReject:
 		// 撤回已拒绝的提案
 		if proposal.Status != rejectedStatusID {
@@ -1216,29 +1292,63 @@ func (s *ProposalService) RevokeProposal(ctx context.Context, req *dto.RevokePro
 			return nil, errorx.New(errno.ErrProposalUpdateFailed, errorx.KV("proposalId", req.ProposalID))
 		}
 
-		// 记录变更日志
-		if _, logErr := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
-			TargetID:     req.ProposalID,
-			TargetType:   consts.TargetTypeProposal,
-			Action:       consts.ActionTypeRevokeRejectProposal,
-			Content:      "撤回提案审批：拒绝→待审核",
-			UpdateSource: consts.UpdateSourceAdmin,
-			ProposalID:   req.ProposalID,
-		}); logErr != nil {
-			logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", logErr, req.ProposalID)
-		}
-
 	default:
 		return nil, errorx.New(errno.ErrRevokeActionTypeInvalid, errorx.KV("actionType", req.ActionType))
 	}
 
+	// 变更日志、计数与通知由事件订阅者处理
+	if err = s.EventBus.Publish(ctx, event.ProposalRevoked{
+		Proposal:   proposal,
+		ReviewerID: userId,
+		ActionType: req.ActionType,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, proposalId: %s", err, req.ProposalID)
+	}
+
 	return &dto.RevokeProposalResp{
 		Resp:       dto.Success(),
 		ProposalID: req.ProposalID,
 	}, nil
 }
 
-// RejectProposal 拒绝提案，将状态从 pending 改为 rejected
+// revokeCreatedCourse 撤回已通过的新增提案，删除提案创建的课程，课程已被手动删除则仅回退提案状态
+// 课程参与过合并时，删除会连带丢失合并进来的评论与开设，此时只解除课程与提案的关联
+func (s *ProposalService) revokeCreatedCourse(ctx context.Context, proposal *model.Proposal) error {
+	course, err := s.CourseRepo.FindByProposalIDIncludeDeleted(ctx, proposal.ID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByProposalID] error: %v, proposalId: %s", err, proposal.ID)
+		return errorx.WrapByCode(err, errno.ErrCourseNotFoundCannotRevoke)
+	}
+	if course == nil {
+		return nil
+	}
+
+	// 提案随重复课程迁移到了保留课程，或提案创建的课程是合并的保留课程
+	merged := course.ProposalID != proposal.ID
+	if !merged {
+		if merged, err = s.CourseRepo.IsMergeTarget(ctx, course.ID); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [IsMergeTarget] error: %v, courseId: %s", err, course.ID)
+			return errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("courseId", course.ID))
+		}
+	}
+	if merged {
+		if err = s.CourseRepo.UnlinkProposal(ctx, course.ID, proposal.ID); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [UnlinkProposal] error: %v, courseId: %s", err, course.ID)
+			return errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("courseId", course.ID))
+		}
+		return nil
+	}
+
+	if !course.Deleted {
+		if err = s.CourseRepo.SoftDeleteByID(ctx, course.ID); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [SoftDeleteByID] error: %v, courseId: %s", err, course.ID)
+			return errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("courseId", course.ID))
+		}
+	}
+	return nil
+}
+
+// RejectProposal 拒绝提案，将状态从 pending 或 needsInfo 改为 rejected
 func (s *ProposalService) RejectProposal(ctx context.Context, req *dto.RejectProposalReq) (*dto.RejectProposalResp, error) {
 	userId, ok := ctx.Value(consts.CtxUserID).(string)
 	if !ok || userId == "" {
@@ -1270,7 +1380,8 @@ func (s *ProposalService) RejectProposal(ctx context.Context, req *dto.RejectPro
 
 	pendingStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending)
 	rejectedStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusRejected)
-	if proposal.Status != pendingStatusID {
+	needsInfoStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusNeedsInfo)
+	if proposal.Status != pendingStatusID && proposal.Status != needsInfoStatusID {
 		return nil, errorx.New(errno.ErrProposalAlreadyProcessed, errorx.KV("key", consts.ReqProposalID), errorx.KV("value", req.ProposalID))
 	}
 
@@ -1284,30 +1395,17 @@ func (s *ProposalService) RejectProposal(ctx context.Context, req *dto.RejectPro
 		return nil, errorx.New(errno.ErrProposalUpdateFailed, errorx.KV("proposalId", req.ProposalID))
 	}
 
-	content := "审批提案：拒绝"
-	if req.Reason != "" {
-		content = req.Reason
-	}
-
-	if _, err = s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
-		TargetID:     req.ProposalID,
-		TargetType:   consts.TargetTypeProposal,
-		Action:       consts.ActionTypeRejectProposal,
-		Content:      content,
-		UpdateSource: consts.UpdateSourceAdmin,
-		ProposalID:   req.ProposalID,
+	if err = s.EventBus.Publish(ctx, event.ProposalRejected{
+		Proposal:   proposal,
+		ReviewerID: userId,
+		Reason:     req.Reason,
 	}); err != nil {
-		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", err, req.ProposalID)
-	}
-
-	_, pendingCount, err := s.ProposalRepo.FindManyByStatus(ctx, nil, pendingStatusID)
-	if err != nil {
-		logs.CtxWarnf(ctx, "[ProposalRepo] [FindManyByStatus] error: %v", err)
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, proposalId: %s", err, req.ProposalID)
 	}
 
 	return &dto.RejectProposalResp{
 		Resp:         dto.Success(),
 		Rejected:     true,
-		PendingCount: pendingCount,
+		PendingCount: s.countPendingProposals(ctx),
 	}, nil
 }
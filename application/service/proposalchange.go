@@ -0,0 +1,527 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// createChangeProposal 创建针对现有课程的修改提案
+func (s *ProposalService) createChangeProposal(ctx context.Context, userId string, req *dto.CreateProposalReq) (*dto.CreateProposalResp, error) {
+	before, after, change, err := s.prepareCourseChange(ctx, req.CourseID, req.Change, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.saveProposal(ctx, userId, &dto.ProposalVO{
+		ID:        primitive.NewObjectID().Hex(),
+		UserID:    userId,
+		Title:     req.Title,
+		Content:   req.Content,
+		Status:    consts.ProposalStatusPending,
+		Course:    after,
+		Type:      consts.ProposalTypeChange,
+		CourseID:  req.CourseID,
+		Change:    change,
+		Before:    before,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// updateChangeProposal 以课程当前状态为基准重新生成修改提案的修改内容
+func (s *ProposalService) updateChangeProposal(ctx context.Context, proposal *model.Proposal, req *dto.UpdateProposalReq) error {
+	before, after, change, err := s.prepareCourseChange(ctx, proposal.CourseID, req.Change, proposal.ID)
+	if err != nil {
+		return err
+	}
+
+	proposal.Before, err = s.CourseAssembler.ToProposalCourseDB(ctx, before)
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "course vo"), errorx.KV("dst", "proposal course model"))
+	}
+	proposal.Course, err = s.CourseAssembler.ToProposalCourseDB(ctx, after)
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "course vo"), errorx.KV("dst", "proposal course model"))
+	}
+	proposal.Course.Deleted = change.Retired
+	proposal.Change = s.ProposalAssembler.ToCourseChangeDB(change)
+	return nil
+}
+
+// prepareCourseChange 校验修改提案并以课程当前状态为基准归一化修改内容，返回修改前后的课程与只包含实际变化字段的修改
+func (s *ProposalService) prepareCourseChange(ctx context.Context, courseId string, change *dto.CourseChangeVO, proposalId string) (before, after *dto.ProposalCourseVO, normalized *dto.CourseChangeVO, err error) {
+	if change == nil {
+		return nil, nil, nil, errorx.New(errno.ErrProposalChangeEmpty, errorx.KV("courseId", courseId))
+	}
+	if err = validateCampuses(change.Campuses); err != nil {
+		return nil, nil, nil, err
+	}
+
+	course, err := s.findChangeTarget(ctx, courseId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 同一门课程同时只允许一个未结的修改提案，避免修改互相覆盖
+	pending, err := s.ProposalRepo.IsChangePending(ctx, consts.ProposalTypeChange, courseId, openProposalStatusIDs(), proposalId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [IsChangePending] error: %v, courseId: %s", err, courseId)
+		return nil, nil, nil, errorx.WrapByCode(err, errno.ErrProposalFindFailed, errorx.KV("courseId", courseId))
+	}
+	if pending {
+		return nil, nil, nil, errorx.New(errno.ErrProposalChangePending, errorx.KV("courseId", courseId))
+	}
+
+	before, err = s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, courseId: %s", err, courseId)
+		return nil, nil, nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "proposal course vo"))
+	}
+
+	if change.Retired {
+		return before, applyCourseChange(before, nil), &dto.CourseChangeVO{Retired: true}, nil
+	}
+	fillKnownTeacherIDs(change.Teachers, before.Teachers)
+	after = applyCourseChange(before, change)
+	normalized = courseChangeBetween(before, after)
+	if isEmptyCourseChange(normalized) {
+		return nil, nil, nil, errorx.New(errno.ErrProposalChangeEmpty, errorx.KV("courseId", courseId))
+	}
+	return before, after, normalized, nil
+}
+
+// findChangeTarget 查询修改提案针对的课程，课程必须存在且未删除
+func (s *ProposalService) findChangeTarget(ctx context.Context, courseId string) (*model.Course, error) {
+	course, err := s.CourseRepo.FindByID(ctx, courseId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v, courseId: %s", err, courseId)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", courseId))
+	}
+	if course == nil {
+		return nil, errorx.New(errno.ErrCourseNotFound,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", courseId))
+	}
+	if course.MergedInto != "" {
+		return nil, errorx.New(errno.ErrCourseMerged,
+			errorx.KV("courseId", courseId), errorx.KV("targetId", course.MergedInto))
+	}
+	if course.Deleted {
+		return nil, errorx.New(errno.ErrCourseDeleted, errorx.KV("courseId", courseId))
+	}
+	return course, nil
+}
+
+// approveChangeProposal 将修改提案应用到课程并记录变更日志，返回实际生效的修改与修改后的课程
+// 管理员传入的 finalChange 优先，修改以审批时课程的当前状态为基准
+func (s *ProposalService) approveChangeProposal(ctx context.Context, req *dto.ToggleProposalReq, proposal *model.Proposal) (*dto.CourseChangeVO, *dto.ProposalCourseVO, error) {
+	change := req.FinalChange
+	if change == nil {
+		change = s.ProposalAssembler.ToCourseChangeVO(proposal.Change)
+	}
+	if change == nil {
+		return nil, nil, errorx.New(errno.ErrProposalChangeEmpty, errorx.KV("courseId", proposal.CourseID))
+	}
+	if err := validateCampuses(change.Campuses); err != nil {
+		return nil, nil, err
+	}
+
+	course, err := s.findChangeTarget(ctx, proposal.CourseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, courseId: %s", err, course.ID)
+		return nil, nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database course"), errorx.KV("dst", "proposal course vo"))
+	}
+
+	var after *dto.ProposalCourseVO
+	if change.Retired {
+		change = &dto.CourseChangeVO{Retired: true}
+		if err = s.CourseRepo.SoftDeleteByID(ctx, course.ID); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [SoftDeleteByID] error: %v, courseId: %s", err, course.ID)
+			return nil, nil, errorx.WrapByCode(err, errno.ErrCourseDeleteFailed, errorx.KV("courseId", course.ID))
+		}
+		deleted := *course
+		deleted.Deleted = true
+		s.logProposalCourseChange(ctx, proposal.ID, consts.ActionTypeDeleteCourse,
+			"修改提案：课程「"+course.Name+"」不再开设", course, &deleted)
+		after = applyCourseChange(before, nil)
+	} else {
+		fillKnownTeacherIDs(change.Teachers, before.Teachers)
+		change = courseChangeBetween(before, applyCourseChange(before, change))
+		if isEmptyCourseChange(change) {
+			return nil, nil, errorx.New(errno.ErrProposalChangeEmpty, errorx.KV("courseId", course.ID))
+		}
+		updated, err := s.updateCourseFromVO(ctx, course, applyCourseChange(before, change))
+		if err != nil {
+			return nil, nil, err
+		}
+		s.logProposalCourseChange(ctx, proposal.ID, consts.ActionTypeUpdateCourse,
+			"修改提案：修改课程「"+updated.Name+"」", course, updated)
+		// 以落库后的课程作为快照，新建的教师在快照中带上正式ID，便于撤回时比对
+		after, err = s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, updated)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, courseId: %s", err, course.ID)
+			return nil, nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+				errorx.KV("src", "database course"), errorx.KV("dst", "proposal course vo"))
+		}
+	}
+
+	// 刷新提案中修改前后的快照为审批时的实际状态，用于展示对比与撤回
+	if proposal.Before, err = s.CourseAssembler.ToProposalCourseDB(ctx, before); err != nil {
+		return nil, nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+	}
+	if proposal.Course, err = s.CourseAssembler.ToProposalCourseDB(ctx, after); err != nil {
+		return nil, nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+	}
+	proposal.Course.Deleted = change.Retired
+	proposal.UpdatedAt = time.Now()
+	if err = s.ProposalRepo.UpdateProposal(ctx, proposal); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateProposal] error: %v, proposalId: %s", err, proposal.ID)
+		return nil, nil, errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("proposalId", proposal.ID))
+	}
+	return change, after, nil
+}
+
+// revokeChangeProposal 撤回已通过的修改提案，将课程中被修改的字段恢复为审批前的值
+// 这些字段在审批后又被修改过时不允许撤回
+func (s *ProposalService) revokeChangeProposal(ctx context.Context, proposal *model.Proposal) error {
+	course, err := s.CourseRepo.FindByID(ctx, proposal.CourseID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v, courseId: %s", err, proposal.CourseID)
+		return errorx.WrapByCode(err, errno.ErrCourseNotFoundCannotRevoke)
+	}
+	if course == nil || proposal.Before == nil || proposal.Course == nil {
+		return errorx.New(errno.ErrCourseNotFoundCannotRevoke)
+	}
+
+	// 课程不再开设：恢复课程
+	if proposal.Course.Deleted {
+		if !course.Deleted || course.MergedInto != "" {
+			return errorx.New(errno.ErrCourseModifiedCannotRevoke)
+		}
+		restored := *course
+		restored.Deleted = false
+		if err = s.CourseRepo.UpdateCourse(ctx, &restored); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [UpdateCourse] error: %v, courseId: %s", err, course.ID)
+			return errorx.WrapByCode(err, errno.ErrCourseUpdateFailed, errorx.KV("courseId", course.ID))
+		}
+		s.logProposalCourseChange(ctx, proposal.ID, consts.ActionTypeRestoreCourse,
+			"撤回修改提案：恢复课程「"+course.Name+"」", course, &restored)
+		return nil
+	}
+	if course.Deleted {
+		return errorx.New(errno.ErrCourseModifiedCannotRevoke)
+	}
+
+	before, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Before)
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+	}
+	after, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Course)
+	if err != nil {
+		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+	}
+	current, err := s.CourseAssembler.ToProposalCourseVOFromCourse(ctx, course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVOFromCourse] error: %v, courseId: %s", err, course.ID)
+		return errorx.WrapByCode(err, errno.ErrCourseCvtFailed)
+	}
+
+	revert := courseChangeBetween(after, before)
+	drifted := courseChangeBetween(after, current)
+	for _, field := range courseChangeFields(revert) {
+		for _, f := range courseChangeFields(drifted) {
+			if field == f {
+				return errorx.New(errno.ErrCourseModifiedCannotRevoke)
+			}
+		}
+	}
+	if isEmptyCourseChange(revert) {
+		return nil
+	}
+
+	restored, err := s.updateCourseFromVO(ctx, course, applyCourseChange(current, revert))
+	if err != nil {
+		return err
+	}
+	s.logProposalCourseChange(ctx, proposal.ID, consts.ActionTypeUpdateCourse,
+		"撤回修改提案：恢复课程「"+restored.Name+"」", course, restored)
+	return nil
+}
+
+// updateCourseFromVO 用修改后的课程信息覆盖课程，保留创建信息与来源提案
+func (s *ProposalService) updateCourseFromVO(ctx context.Context, course *model.Course, vo *dto.ProposalCourseVO) (*model.Course, error) {
+	updated, err := s.CourseAssembler.ToCourseDBFromProposalCourse(ctx, vo)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseDBFromProposalCourse] error: %v", err)
+		return nil, wrapCourseCvtErr(err)
+	}
+	updated.ID = course.ID
+	updated.ProposalID = course.ProposalID
+	updated.CreatedAt = course.CreatedAt
+	updated.UpdatedAt = time.Now()
+	if err = s.CourseRepo.UpdateCourse(ctx, updated); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [UpdateCourse] error: %v, courseId: %s", err, course.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseUpdateFailed, errorx.KV("courseId", course.ID))
+	}
+	return updated, nil
+}
+
+// logProposalCourseChange 记录修改提案引起的课程变更日志，关联来源提案
+func (s *ProposalService) logProposalCourseChange(ctx context.Context, proposalId string, action int32, content string, before, after *model.Course) {
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     before.ID,
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeCourse),
+		Action:       action,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   proposalId,
+		Before:       lib.JSONF(before),
+		After:        lib.JSONF(after),
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, courseId: %s", err, before.ID)
+	}
+}
+
+// findFinalCourse 查询已通过提案对应的正式课程：新增提案为其创建的课程（已合并时为迁移了该提案的保留课程），修改提案为被修改的课程，课程已删除或教师更正提案时返回 nil
+func (s *ProposalService) findFinalCourse(ctx context.Context, vo *dto.ProposalVO) (*model.Course, error) {
+	if vo.Type == consts.ProposalTypeTeacher {
+		return nil, nil
+	}
+	if vo.Type != consts.ProposalTypeChange {
+		return s.CourseRepo.FindByProposalID(ctx, vo.ID)
+	}
+	course, err := s.CourseRepo.FindByID(ctx, vo.CourseID)
+	if err != nil || course == nil || course.Deleted {
+		return nil, err
+	}
+	return course, nil
+}
+
+// recalcChangeContribution 重新计算修改提案的贡献值得分（兜底，对比提交的修改与审批快照中实际生效的修改）
+func (s *ProposalService) recalcChangeContribution(ctx context.Context, proposal *model.Proposal) int64 {
+	proposed := s.ProposalAssembler.ToCourseChangeVO(proposal.Change)
+	if proposal.Course != nil && proposal.Course.Deleted {
+		return calcChangeContributionScore(proposed, &dto.CourseChangeVO{Retired: true})
+	}
+	before, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Before)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v, proposalId: %s", err, proposal.ID)
+		return 0
+	}
+	after, err := s.CourseAssembler.ToProposalCourseVO(ctx, proposal.Course)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v, proposalId: %s", err, proposal.ID)
+		return 0
+	}
+	if before == nil || after == nil {
+		return 0
+	}
+	return calcChangeContributionScore(proposed, courseChangeBetween(before, after))
+}
+
+// validateCampuses 校验校区名称均已存在
+func validateCampuses(campuses []string) error {
+	for _, campusName := range campuses {
+		campusName = strings.TrimSpace(campusName)
+		if campusName == "" {
+			continue
+		}
+		if mapping.Data.GetCampusIDByName(campusName) == 0 {
+			return errorx.New(errno.ErrProposalInvalidCampus,
+				errorx.KV("key", consts.Campuses),
+				errorx.KV("value", campusName),
+			)
+		}
+	}
+	return nil
+}
+
+// applyCourseChange 返回应用修改后的课程副本，change 为空时原样复制
+func applyCourseChange(course *dto.ProposalCourseVO, change *dto.CourseChangeVO) *dto.ProposalCourseVO {
+	result := *course
+	if change == nil {
+		return &result
+	}
+	if change.Name != nil {
+		result.Name = strings.TrimSpace(*change.Name)
+	}
+	if change.Code != nil {
+		result.Code = strings.TrimSpace(*change.Code)
+	}
+	if change.Department != nil {
+		result.Department = strings.TrimSpace(*change.Department)
+	}
+	if change.Category != nil {
+		result.Category = strings.TrimSpace(*change.Category)
+	}
+	if len(change.Campuses) > 0 {
+		result.Campuses = change.Campuses
+	}
+	if len(change.Teachers) > 0 {
+		result.Teachers = change.Teachers
+	}
+	return &result
+}
+
+// courseChangeBetween 计算从 from 到 to 的字段级修改，只包含有变化的字段
+func courseChangeBetween(from, to *dto.ProposalCourseVO) *dto.CourseChangeVO {
+	change := &dto.CourseChangeVO{}
+	if strings.TrimSpace(from.Name) != strings.TrimSpace(to.Name) {
+		change.Name = &to.Name
+	}
+	if strings.TrimSpace(from.Code) != strings.TrimSpace(to.Code) {
+		change.Code = &to.Code
+	}
+	if strings.TrimSpace(from.Department) != strings.TrimSpace(to.Department) {
+		change.Department = &to.Department
+	}
+	if strings.TrimSpace(from.Category) != strings.TrimSpace(to.Category) {
+		change.Category = &to.Category
+	}
+	if !stringSetEqual(from.Campuses, to.Campuses) {
+		change.Campuses = to.Campuses
+	}
+	if !teacherSetEqual(from.Teachers, to.Teachers) {
+		change.Teachers = to.Teachers
+	}
+	return change
+}
+
+// courseChangeFields 修改涉及的字段名
+func courseChangeFields(change *dto.CourseChangeVO) []string {
+	if change == nil {
+		return nil
+	}
+	if change.Retired {
+		return []string{consts.ProposalChangeFieldRetired}
+	}
+	var fields []string
+	if change.Name != nil {
+		fields = append(fields, consts.ProposalChangeFieldName)
+	}
+	if change.Code != nil {
+		fields = append(fields, consts.ProposalChangeFieldCode)
+	}
+	if change.Department != nil {
+		fields = append(fields, consts.ProposalChangeFieldDepartment)
+	}
+	if change.Category != nil {
+		fields = append(fields, consts.ProposalChangeFieldCategory)
+	}
+	if len(change.Campuses) > 0 {
+		fields = append(fields, consts.ProposalChangeFieldCampuses)
+	}
+	if len(change.Teachers) > 0 {
+		fields = append(fields, consts.ProposalChangeFieldTeachers)
+	}
+	return fields
+}
+
+func isEmptyCourseChange(change *dto.CourseChangeVO) bool {
+	return len(courseChangeFields(change)) == 0
+}
+
+// calcChangeContributionScore 对比用户提交的修改与最终生效的修改，每个被采纳且取值一致的字段加1分
+func calcChangeContributionScore(proposed, final *dto.CourseChangeVO) int64 {
+	if proposed == nil || final == nil {
+		return 0
+	}
+	if proposed.Retired || final.Retired {
+		if proposed.Retired && final.Retired {
+			return 1
+		}
+		return 0
+	}
+
+	var score int64
+	for _, field := range courseChangeFields(proposed) {
+		var kept bool
+		switch field {
+		case consts.ProposalChangeFieldName:
+			kept = final.Name != nil && strings.TrimSpace(*proposed.Name) == strings.TrimSpace(*final.Name)
+		case consts.ProposalChangeFieldCode:
+			kept = final.Code != nil && strings.TrimSpace(*proposed.Code) == strings.TrimSpace(*final.Code)
+		case consts.ProposalChangeFieldDepartment:
+			kept = final.Department != nil && strings.TrimSpace(*proposed.Department) == strings.TrimSpace(*final.Department)
+		case consts.ProposalChangeFieldCategory:
+			kept = final.Category != nil && strings.TrimSpace(*proposed.Category) == strings.TrimSpace(*final.Category)
+		case consts.ProposalChangeFieldCampuses:
+			kept = len(final.Campuses) > 0 && stringSetEqual(proposed.Campuses, final.Campuses)
+		case consts.ProposalChangeFieldTeachers:
+			kept = len(final.Teachers) > 0 && teacherNameSetEqual(proposed.Teachers, final.Teachers)
+		}
+		if kept {
+			score++
+		}
+	}
+	return score
+}
+
+// teacherSetEqual 比较两个教师列表是否为同一批教师（忽略顺序），有正式ID时按ID比较，否则按姓名、院系与职称比较
+func teacherSetEqual(a, b []*dto.TeacherVO) bool {
+	return stringSetEqual(teacherKeys(a), teacherKeys(b))
+}
+
+func teacherKeys(teachers []*dto.TeacherVO) []string {
+	keys := make([]string, 0, len(teachers))
+	for _, t := range teachers {
+		if t == nil {
+			continue
+		}
+		if t.ID != "" {
+			keys = append(keys, t.ID)
+			continue
+		}
+		keys = append(keys, t.Name+"|"+t.Department+"|"+t.Title)
+	}
+	return keys
+}
+
+// fillKnownTeacherIDs 为修改中未带ID的教师补上课程现有同名教师的ID，院系不一致的视为不同教师
+func fillKnownTeacherIDs(teachers, known []*dto.TeacherVO) {
+	for _, t := range teachers {
+		if t == nil || t.ID != "" {
+			continue
+		}
+		for _, k := range known {
+			if k.Name == t.Name && (t.Department == "" || t.Department == k.Department) {
+				t.ID = k.ID
+				break
+			}
+		}
+	}
+}
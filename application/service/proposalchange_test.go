@@ -0,0 +1,233 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testCourse() *dto.ProposalCourseVO {
+	return &dto.ProposalCourseVO{
+		Name:       "高等数学",
+		Code:       "MATH101",
+		Department: "数学科学学院",
+		Category:   "专业必修",
+		Campuses:   []string{"闵行"},
+		Teachers:   []*dto.TeacherVO{{ID: "t1", Name: "张三"}},
+	}
+}
+
+// TestApplyCourseChange 测试将修改应用到课程副本
+func TestApplyCourseChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change *dto.CourseChangeVO
+		want   func(c *dto.ProposalCourseVO)
+	}{
+		{
+			name:   "修改为空时原样复制",
+			change: nil,
+			want:   func(c *dto.ProposalCourseVO) {},
+		},
+		{
+			name:   "字段去除首尾空白",
+			change: &dto.CourseChangeVO{Name: strPtr("  高等数学A "), Code: strPtr(" MATH102")},
+			want: func(c *dto.ProposalCourseVO) {
+				c.Name = "高等数学A"
+				c.Code = "MATH102"
+			},
+		},
+		{
+			name:   "空列表表示不变",
+			change: &dto.CourseChangeVO{Category: strPtr("通识选修"), Campuses: []string{}},
+			want:   func(c *dto.ProposalCourseVO) { c.Category = "通识选修" },
+		},
+		{
+			name: "替换校区与教师",
+			change: &dto.CourseChangeVO{
+				Campuses: []string{"徐汇", "闵行"},
+				Teachers: []*dto.TeacherVO{{ID: "t2", Name: "李四"}},
+			},
+			want: func(c *dto.ProposalCourseVO) {
+				c.Campuses = []string{"徐汇", "闵行"}
+				c.Teachers = []*dto.TeacherVO{{ID: "t2", Name: "李四"}}
+			},
+		},
+		{
+			name:   "不再开设时不修改字段",
+			change: &dto.CourseChangeVO{Retired: true},
+			want:   func(c *dto.ProposalCourseVO) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			course := testCourse()
+			got := applyCourseChange(course, tt.change)
+			want := testCourse()
+			tt.want(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applyCourseChange() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(course, testCourse()) {
+				t.Errorf("applyCourseChange() modified the input course")
+			}
+		})
+	}
+}
+
+// TestCourseChangeBetween 测试计算两个课程之间的字段级修改
+func TestCourseChangeBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		to         func(c *dto.ProposalCourseVO)
+		wantFields []string
+	}{
+		{
+			name:       "没有变化",
+			to:         func(c *dto.ProposalCourseVO) {},
+			wantFields: nil,
+		},
+		{
+			name: "只有首尾空白不同视为未变化",
+			to: func(c *dto.ProposalCourseVO) {
+				c.Name = " 高等数学 "
+				c.Campuses = []string{" 闵行"}
+			},
+			wantFields: nil,
+		},
+		{
+			name: "同一教师ID视为未变化",
+			to: func(c *dto.ProposalCourseVO) {
+				c.Teachers = []*dto.TeacherVO{{ID: "t1", Name: "张三（新）"}}
+			},
+			wantFields: nil,
+		},
+		{
+			name: "只包含变化的字段",
+			to: func(c *dto.ProposalCourseVO) {
+				c.Department = "物理与天文学院"
+				c.Teachers = append(c.Teachers, &dto.TeacherVO{Name: "李四", Department: "物理与天文学院"})
+			},
+			wantFields: []string{"department", "teachers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := testCourse()
+			tt.to(to)
+			got := courseChangeFields(courseChangeBetween(testCourse(), to))
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("courseChangeBetween() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+// TestCourseChangeDrift 测试审批前课程已被改动时，最终生效的修改只包含仍有变化的字段
+func TestCourseChangeDrift(t *testing.T) {
+	proposed := &dto.CourseChangeVO{Name: strPtr("高等数学A"), Category: strPtr("通识选修")}
+
+	// 提交后课程名称已被其他提案改成相同的值
+	current := testCourse()
+	current.Name = "高等数学A"
+	final := courseChangeBetween(current, applyCourseChange(current, proposed))
+
+	if got := courseChangeFields(final); !reflect.DeepEqual(got, []string{"category"}) {
+		t.Errorf("final change fields = %v, want [category]", got)
+	}
+	if got := calcChangeContributionScore(proposed, final); got != 1 {
+		t.Errorf("calcChangeContributionScore() = %d, want 1", got)
+	}
+}
+
+// TestCalcChangeContributionScore 测试修改提案的贡献值计算
+func TestCalcChangeContributionScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposed *dto.CourseChangeVO
+		final    *dto.CourseChangeVO
+		want     int64
+	}{
+		{
+			name:     "修改为空",
+			proposed: nil,
+			final:    &dto.CourseChangeVO{Name: strPtr("高等数学A")},
+			want:     0,
+		},
+		{
+			name:     "全部采纳",
+			proposed: &dto.CourseChangeVO{Name: strPtr("高等数学A"), Campuses: []string{"徐汇", "闵行"}},
+			final:    &dto.CourseChangeVO{Name: strPtr("高等数学A"), Campuses: []string{"闵行", "徐汇"}},
+			want:     2,
+		},
+		{
+			name:     "首尾空白不同仍视为一致",
+			proposed: &dto.CourseChangeVO{Code: strPtr(" MATH102 ")},
+			final:    &dto.CourseChangeVO{Code: strPtr("MATH102")},
+			want:     1,
+		},
+		{
+			name:     "管理员修正了取值",
+			proposed: &dto.CourseChangeVO{Name: strPtr("高等数学A"), Department: strPtr("数学系")},
+			final:    &dto.CourseChangeVO{Name: strPtr("高等数学（A）"), Department: strPtr("数学系")},
+			want:     1,
+		},
+		{
+			name:     "最终未修改的字段不计分",
+			proposed: &dto.CourseChangeVO{Category: strPtr("通识选修"), Teachers: []*dto.TeacherVO{{Name: "李四"}}},
+			final:    &dto.CourseChangeVO{Category: strPtr("通识选修")},
+			want:     1,
+		},
+		{
+			name:     "教师按姓名比较",
+			proposed: &dto.CourseChangeVO{Teachers: []*dto.TeacherVO{{Name: "李四"}}},
+			final:    &dto.CourseChangeVO{Teachers: []*dto.TeacherVO{{ID: "t2", Name: "李四", Department: "物理与天文学院"}}},
+			want:     1,
+		},
+		{
+			name:     "不再开设被采纳",
+			proposed: &dto.CourseChangeVO{Retired: true},
+			final:    &dto.CourseChangeVO{Retired: true},
+			want:     1,
+		},
+		{
+			name:     "不再开设未被采纳",
+			proposed: &dto.CourseChangeVO{Retired: true},
+			final:    &dto.CourseChangeVO{Name: strPtr("高等数学A")},
+			want:     0,
+		},
+		{
+			name:     "管理员改为不再开设",
+			proposed: &dto.CourseChangeVO{Name: strPtr("高等数学A")},
+			final:    &dto.CourseChangeVO{Retired: true},
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calcChangeContributionScore(tt.proposed, tt.final); got != tt.want {
+				t.Errorf("calcChangeContributionScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateProposalComment 在提案下发表讨论或回复，内部备注仅管理员可发表
+func (s *ProposalService) CreateProposalComment(ctx context.Context, req *dto.CreateProposalCommentReq) (*dto.CreateProposalCommentResp, error) {
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	proposal, err := s.findProposal(ctx, req.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	canSeeInternal := s.canSeeInternalNotes(ctx, proposal, userId)
+
+	now := time.Now()
+	comment := &model.ProposalComment{
+		ID:         primitive.NewObjectID().Hex(),
+		ProposalID: proposal.ID,
+		UserID:     userId,
+		Content:    req.Content,
+		Internal:   req.Internal,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if req.ParentID != "" {
+		parent, err := s.ProposalCommentRepo.FindByID(ctx, req.ParentID)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[ProposalCommentRepo] [FindByID] error: %v, commentId: %s", err, req.ParentID)
+			return nil, errorx.WrapByCode(err, errno.ErrProposalCommentFindFailed)
+		}
+		// 不可见的内部备注按不存在处理
+		if parent == nil || parent.ProposalID != proposal.ID || (parent.Internal && !canSeeInternal) {
+			return nil, errorx.New(errno.ErrProposalCommentParentInvalid,
+				errorx.KV("parentId", req.ParentID), errorx.KV("proposalId", proposal.ID))
+		}
+
+		// 回复统一挂在顶层评论下，内部备注下的回复同样为内部备注
+		comment.ParentID = parent.ID
+		if parent.ParentID != "" {
+			comment.ParentID = parent.ParentID
+		}
+		comment.ReplyToUserID = parent.UserID
+		comment.Internal = comment.Internal || parent.Internal
+	}
+	if comment.Internal && !canSeeInternal {
+		return nil, errorx.New(errno.ErrProposalCommentInternalNotAdmin, errorx.KV("userId", userId))
+	}
+
+	if err = s.ProposalCommentRepo.Insert(ctx, comment); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalCommentRepo] [Insert] error: %v, proposalId: %s", err, proposal.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalCommentCreateFailed, errorx.KV("proposalId", proposal.ID))
+	}
+
+	// 作者在讨论中补充信息后，提案重新进入待审核
+	if !comment.Internal && proposal.UserID == userId {
+		s.resumeReview(ctx, proposal, "作者在讨论中补充信息，重新进入待审核")
+	}
+
+	s.notifyProposalComment(ctx, proposal, comment)
+
+	return &dto.CreateProposalCommentResp{
+		Resp:    dto.Success(),
+		Comment: s.ProposalAssembler.ToProposalCommentVO(comment, proposal.UserID),
+	}, nil
+}
+
+// DeleteProposalComment 删除提案讨论，仅发表者或管理员可删除，删除顶层评论时其回复一并隐藏
+func (s *ProposalService) DeleteProposalComment(ctx context.Context, req *dto.DeleteProposalCommentReq) (*dto.DeleteProposalCommentResp, error) {
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	comment, err := s.ProposalCommentRepo.FindByID(ctx, req.CommentID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalCommentRepo] [FindByID] error: %v, commentId: %s", err, req.CommentID)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalCommentFindFailed)
+	}
+	if comment == nil {
+		return nil, errorx.New(errno.ErrProposalCommentNotFound, errorx.KV("commentId", req.CommentID))
+	}
+
+	if comment.UserID != userId {
+		isAdmin, err := s.UserRepo.IsAdminByID(ctx, userId)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+			return nil, errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", userId))
+		}
+		if !isAdmin {
+			return nil, errorx.New(errno.ErrUserNotOwner, errorx.KV("id", userId))
+		}
+	}
+
+	if err = s.ProposalCommentRepo.SoftDeleteByID(ctx, comment.ID); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalCommentRepo] [SoftDeleteByID] error: %v, commentId: %s", err, comment.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalCommentDeleteFailed, errorx.KV("commentId", comment.ID))
+	}
+
+	return &dto.DeleteProposalCommentResp{
+		Resp:      dto.Success(),
+		CommentID: comment.ID,
+	}, nil
+}
+
+// RequestProposalInfo 管理员要求作者补充信息，问题以讨论形式发布，提案进入待补充信息状态并通知作者
+func (s *ProposalService) RequestProposalInfo(ctx context.Context, req *dto.RequestProposalInfoReq) (*dto.RequestProposalInfoResp, error) {
+	userId, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := s.findProposal(ctx, req.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending) {
+		return nil, errorx.New(errno.ErrProposalStatusNotPending, errorx.KV("proposalId", proposal.ID))
+	}
+
+	// 先按待审核状态条件更新，与并发的通过、驳回互斥；发布问题失败时退回待审核
+	pendingStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending)
+	needsInfoStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusNeedsInfo)
+	updated, err := s.ProposalRepo.UpdateStatusByIDFrom(ctx, proposal.ID, pendingStatusID, needsInfoStatusID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateStatusByIDFrom] error: %v, proposalId: %s", err, proposal.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("proposalId", proposal.ID))
+	}
+	if !updated {
+		return nil, errorx.New(errno.ErrProposalStatusNotPending, errorx.KV("proposalId", proposal.ID))
+	}
+
+	now := time.Now()
+	comment := &model.ProposalComment{
+		ID:         primitive.NewObjectID().Hex(),
+		ProposalID: proposal.ID,
+		UserID:     userId,
+		Content:    req.Question,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err = s.ProposalCommentRepo.Insert(ctx, comment); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalCommentRepo] [Insert] error: %v, proposalId: %s", err, proposal.ID)
+		if _, revertErr := s.ProposalRepo.UpdateStatusByIDFrom(ctx, proposal.ID, needsInfoStatusID, pendingStatusID); revertErr != nil {
+			logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateStatusByIDFrom] error: %v, proposalId: %s", revertErr, proposal.ID)
+		}
+		return nil, errorx.WrapByCode(err, errno.ErrProposalCommentCreateFailed, errorx.KV("proposalId", proposal.ID))
+	}
+
+	// 变更日志、计数与通知由事件订阅者处理
+	if err = s.EventBus.Publish(ctx, event.ProposalNeedsInfo{
+		Proposal:   proposal,
+		ReviewerID: userId,
+		Question:   req.Question,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, proposalId: %s", err, proposal.ID)
+	}
+
+	return &dto.RequestProposalInfoResp{
+		Resp:       dto.Success(),
+		ProposalID: proposal.ID,
+		Status:     consts.ProposalStatusNeedsInfo,
+		Comment:    s.ProposalAssembler.ToProposalCommentVO(comment, proposal.UserID),
+	}, nil
+}
+
+// findProposal 查询未删除的提案，不存在时返回错误
+func (s *ProposalService) findProposal(ctx context.Context, proposalId string) (*model.Proposal, error) {
+	if proposalId == "" {
+		return nil, errorx.New(errno.ErrProposalIDRequired, errorx.KV("key", consts.ReqProposalID))
+	}
+	proposal, err := s.ProposalRepo.FindByID(ctx, proposalId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [FindByID] error: %v, proposalId: %s", err, proposalId)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalFindFailed, errorx.KV("proposalId", proposalId))
+	}
+	if proposal == nil {
+		logs.CtxWarnf(ctx, "[ProposalRepo] [FindByID] proposal not found, proposalId: %s", proposalId)
+		return nil, errorx.New(errno.ErrProposalNotFound, errorx.KV("key", consts.ReqProposalID), errorx.KV("value", proposalId))
+	}
+	return proposal, nil
+}
+
+// canSeeInternalNotes 内部备注仅非作者的管理员可见，查询失败时按不可见处理
+func (s *ProposalService) canSeeInternalNotes(ctx context.Context, proposal *model.Proposal, userId string) bool {
+	if proposal.UserID == userId {
+		return false
+	}
+	isAdmin, err := s.UserRepo.IsAdminByID(ctx, userId)
+	if err != nil {
+		logs.CtxWarnf(ctx, "[UserRepo] [IsAdminByID] error: %v, userId: %s", err, userId)
+		return false
+	}
+	return isAdmin
+}
+
+// resumeReview 待补充信息的提案在作者回应后重新进入待审核，失败不影响主流程
+func (s *ProposalService) resumeReview(ctx context.Context, proposal *model.Proposal, content string) {
+	if proposal.Status != mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusNeedsInfo) {
+		return
+	}
+
+	// 只从待补充信息恢复，提案已被并发审核时不覆盖审核结果
+	pendingStatusID := mapping.Data.GetProposalStatusIDByName(consts.ProposalStatusPending)
+	updated, err := s.ProposalRepo.UpdateStatusByIDFrom(ctx, proposal.ID, proposal.Status, pendingStatusID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateStatusByIDFrom] error: %v, proposalId: %s", err, proposal.ID)
+		return
+	}
+	if !updated {
+		logs.CtxWarnf(ctx, "[ProposalService] [resumeReview] proposal status changed concurrently, proposalId: %s", proposal.ID)
+		return
+	}
+	proposal.Status = pendingStatusID
+
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       consts.ActionTypeUpdateProposal,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceUser,
+		ProposalID:   proposal.ID,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, proposalId: %s", err, proposal.ID)
+	}
+}
+
+// notifyProposalComment 通知提案作者有新讨论、被回复者有新回复，内部备注与发表者本人不发送通知
+func (s *ProposalService) notifyProposalComment(ctx context.Context, proposal *model.Proposal, comment *model.ProposalComment) {
+	if comment.Internal {
+		return
+	}
+
+	notify := func(userId, content string) {
+		if userId == comment.UserID {
+			return
+		}
+		if err := s.NotificationService.CreateNotification(ctx, &dto.CreateNotificationReq{
+			UserID:   userId,
+			Type:     consts.NotificationTypeProposalCommented,
+			TargetID: proposal.ID,
+			Content:  content,
+		}); err != nil {
+			logs.CtxErrorf(ctx, "[NotificationService] [CreateNotification] error: %v, proposalId: %s", err, proposal.ID)
+		}
+	}
+
+	notify(proposal.UserID, "你的提案「"+proposal.Title+"」有新的讨论")
+	if comment.ReplyToUserID != "" && comment.ReplyToUserID != proposal.UserID {
+		notify(comment.ReplyToUserID, "你在提案「"+proposal.Title+"」下的讨论收到了回复")
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// createTeacherProposal 创建更正教师信息的提案
+func (s *ProposalService) createTeacherProposal(ctx context.Context, userId string, req *dto.CreateProposalReq) (*dto.CreateProposalResp, error) {
+	before, after, change, err := s.prepareTeacherChange(ctx, req.TeacherID, req.TeacherChange, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.saveProposal(ctx, userId, &dto.ProposalVO{
+		ID:            primitive.NewObjectID().Hex(),
+		UserID:        userId,
+		Title:         req.Title,
+		Content:       req.Content,
+		Status:        consts.ProposalStatusPending,
+		Type:          consts.ProposalTypeTeacher,
+		TeacherID:     req.TeacherID,
+		Teacher:       after,
+		TeacherChange: change,
+		TeacherBefore: before,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// updateTeacherProposal 以教师当前信息为基准重新生成教师更正提案的修改内容
+func (s *ProposalService) updateTeacherProposal(ctx context.Context, proposal *model.Proposal, req *dto.UpdateProposalReq) error {
+	before, after, change, err := s.prepareTeacherChange(ctx, proposal.TeacherID, req.TeacherChange, proposal.ID)
+	if err != nil {
+		return err
+	}
+	proposal.TeacherBefore = s.ProposalAssembler.ToProposalTeacherDB(before)
+	proposal.Teacher = s.ProposalAssembler.ToProposalTeacherDB(after)
+	proposal.TeacherChange = s.ProposalAssembler.ToTeacherChangeDB(change)
+	return nil
+}
+
+// prepareTeacherChange 校验教师更正提案并以教师当前信息为基准归一化修改内容，返回更正前后的教师与只包含实际变化字段的修改
+func (s *ProposalService) prepareTeacherChange(ctx context.Context, teacherId string, change *dto.TeacherChangeVO, proposalId string) (before, after *dto.TeacherVO, normalized *dto.TeacherChangeVO, err error) {
+	if change == nil {
+		return nil, nil, nil, errorx.New(errno.ErrProposalTeacherChangeEmpty, errorx.KV("teacherId", teacherId))
+	}
+
+	teacher, err := s.findCorrectionTarget(ctx, teacherId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 同一位教师同时只允许一个未结的更正提案
+	pending, err := s.ProposalRepo.IsChangePending(ctx, consts.ProposalTypeTeacher, teacherId, openProposalStatusIDs(), proposalId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [IsChangePending] error: %v, teacherId: %s", err, teacherId)
+		return nil, nil, nil, errorx.WrapByCode(err, errno.ErrProposalFindFailed, errorx.KV("teacherId", teacherId))
+	}
+	if pending {
+		return nil, nil, nil, errorx.New(errno.ErrProposalTeacherChangePending, errorx.KV("teacherId", teacherId))
+	}
+
+	before = teacherSnapshot(teacher)
+	after = applyTeacherChange(before, change)
+	normalized = teacherChangeBetween(before, after)
+	if isEmptyTeacherChange(normalized) {
+		return nil, nil, nil, errorx.New(errno.ErrProposalTeacherChangeEmpty, errorx.KV("teacherId", teacherId))
+	}
+	return before, after, normalized, nil
+}
+
+// findCorrectionTarget 查询教师更正提案针对的教师，教师必须存在且未被合并
+func (s *ProposalService) findCorrectionTarget(ctx context.Context, teacherId string) (*model.Teacher, error) {
+	teacher, err := s.TeacherRepo.FindByID(ctx, teacherId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, teacherId)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", teacherId))
+	}
+	if teacher == nil {
+		return nil, errorx.New(errno.ErrTeacherNotFound, errorx.KV("name", teacherId))
+	}
+	if teacher.MergedInto != "" {
+		return nil, errorx.New(errno.ErrTeacherMerged,
+			errorx.KV("teacherId", teacherId), errorx.KV("targetId", teacher.MergedInto))
+	}
+	return teacher, nil
+}
+
+// approveTeacherProposal 将教师更正提案应用到教师并记录变更日志，返回实际生效的修改
+// 管理员传入的 finalTeacherChange 优先，修改以审批时教师的当前信息为基准
+func (s *ProposalService) approveTeacherProposal(ctx context.Context, req *dto.ToggleProposalReq, proposal *model.Proposal) (*dto.TeacherChangeVO, error) {
+	change := req.FinalTeacherChange
+	if change == nil {
+		change = s.ProposalAssembler.ToTeacherChangeVO(proposal.TeacherChange)
+	}
+	if change == nil {
+		return nil, errorx.New(errno.ErrProposalTeacherChangeEmpty, errorx.KV("teacherId", proposal.TeacherID))
+	}
+
+	teacher, err := s.findCorrectionTarget(ctx, proposal.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	before := teacherSnapshot(teacher)
+	after := applyTeacherChange(before, change)
+	change = teacherChangeBetween(before, after)
+	if isEmptyTeacherChange(change) {
+		return nil, errorx.New(errno.ErrProposalTeacherChangeEmpty, errorx.KV("teacherId", teacher.ID))
+	}
+
+	updated, err := updateTeacherRecord(ctx, s.TeacherRepo, teacher, after.Name, after.Title, after.Department)
+	if err != nil {
+		return nil, err
+	}
+	s.logProposalTeacherChange(ctx, proposal.ID, "教师更正提案：修改教师「"+updated.Name+"」", teacher, updated)
+
+	// 刷新提案中更正前后的快照为审批时的实际状态，用于展示对比与撤回
+	proposal.TeacherBefore = s.ProposalAssembler.ToProposalTeacherDB(before)
+	proposal.Teacher = s.ProposalAssembler.ToProposalTeacherDB(teacherSnapshot(updated))
+	proposal.UpdatedAt = time.Now()
+	if err = s.ProposalRepo.UpdateProposal(ctx, proposal); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateProposal] error: %v, proposalId: %s", err, proposal.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrProposalUpdateFailed, errorx.KV("proposalId", proposal.ID))
+	}
+	return change, nil
+}
+
+// revokeTeacherProposal 撤回已通过的教师更正提案，将被更正的字段恢复为审批前的值
+// 教师已被合并或这些字段在审批后又被修改过时不允许撤回
+func (s *ProposalService) revokeTeacherProposal(ctx context.Context, proposal *model.Proposal) error {
+	teacher, err := s.TeacherRepo.FindByID(ctx, proposal.TeacherID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, proposal.TeacherID)
+		return errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", proposal.TeacherID))
+	}
+	if teacher == nil || teacher.MergedInto != "" || proposal.TeacherBefore == nil || proposal.Teacher == nil {
+		return errorx.New(errno.ErrTeacherModifiedCannotRevoke)
+	}
+
+	before := s.ProposalAssembler.ToProposalTeacherVO(proposal.TeacherBefore)
+	after := s.ProposalAssembler.ToProposalTeacherVO(proposal.Teacher)
+	current := teacherSnapshot(teacher)
+	revert := teacherChangeBetween(after, before)
+	drifted := teacherChangeBetween(after, current)
+	for _, field := range teacherChangeFields(revert) {
+		for _, f := range teacherChangeFields(drifted) {
+			if field == f {
+				return errorx.New(errno.ErrTeacherModifiedCannotRevoke)
+			}
+		}
+	}
+	if isEmptyTeacherChange(revert) {
+		return nil
+	}
+
+	restored := applyTeacherChange(current, revert)
+	updated, err := updateTeacherRecord(ctx, s.TeacherRepo, teacher, restored.Name, restored.Title, restored.Department)
+	if err != nil {
+		return err
+	}
+	s.logProposalTeacherChange(ctx, proposal.ID, "撤回教师更正提案：恢复教师「"+updated.Name+"」", teacher, updated)
+	return nil
+}
+
+// logProposalTeacherChange 记录教师更正提案引起的教师变更日志，关联来源提案
+func (s *ProposalService) logProposalTeacherChange(ctx context.Context, proposalId string, content string, before, after *model.Teacher) {
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     before.ID,
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeTeacher),
+		Action:       consts.ActionTypeUpdateTeacher,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   proposalId,
+		Before:       lib.JSONF(before),
+		After:        lib.JSONF(after),
+	}); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, teacherId: %s", err, before.ID)
+	}
+}
+
+// recalcTeacherContribution 重新计算教师更正提案的贡献值得分（兜底，对比提交的修改与审批快照中实际生效的修改）
+func (s *ProposalService) recalcTeacherContribution(proposal *model.Proposal) int64 {
+	before := s.ProposalAssembler.ToProposalTeacherVO(proposal.TeacherBefore)
+	after := s.ProposalAssembler.ToProposalTeacherVO(proposal.Teacher)
+	if before == nil || after == nil {
+		return 0
+	}
+	proposed := s.ProposalAssembler.ToTeacherChangeVO(proposal.TeacherChange)
+	return calcTeacherChangeScore(proposed, teacherChangeBetween(before, after))
+}
+
+// teacherSnapshot 教师当前信息的快照，院系转换为名称
+func teacherSnapshot(teacher *model.Teacher) *dto.TeacherVO {
+	return &dto.TeacherVO{
+		ID:         teacher.ID,
+		Name:       teacher.Name,
+		Title:      teacher.Title,
+		Department: mapping.Data.GetDepartmentNameByID(teacher.Department),
+	}
+}
+
+// applyTeacherChange 返回应用修改后的教师副本，姓名不能改为空
+func applyTeacherChange(teacher *dto.TeacherVO, change *dto.TeacherChangeVO) *dto.TeacherVO {
+	result := *teacher
+	if change.Name != nil && strings.TrimSpace(*change.Name) != "" {
+		result.Name = strings.TrimSpace(*change.Name)
+	}
+	if change.Title != nil {
+		result.Title = strings.TrimSpace(*change.Title)
+	}
+	if change.Department != nil {
+		result.Department = strings.TrimSpace(*change.Department)
+	}
+	return &result
+}
+
+// teacherChangeBetween 计算从 from 到 to 的字段级修改，只包含有变化的字段
+func teacherChangeBetween(from, to *dto.TeacherVO) *dto.TeacherChangeVO {
+	change := &dto.TeacherChangeVO{}
+	if strings.TrimSpace(from.Name) != strings.TrimSpace(to.Name) {
+		change.Name = &to.Name
+	}
+	if strings.TrimSpace(from.Title) != strings.TrimSpace(to.Title) {
+		change.Title = &to.Title
+	}
+	if strings.TrimSpace(from.Department) != strings.TrimSpace(to.Department) {
+		change.Department = &to.Department
+	}
+	return change
+}
+
+// teacherChangeFields 修改涉及的字段名
+func teacherChangeFields(change *dto.TeacherChangeVO) []string {
+	if change == nil {
+		return nil
+	}
+	var fields []string
+	if change.Name != nil {
+		fields = append(fields, consts.ProposalChangeFieldName)
+	}
+	if change.Title != nil {
+		fields = append(fields, consts.ProposalChangeFieldTitle)
+	}
+	if change.Department != nil {
+		fields = append(fields, consts.ProposalChangeFieldDepartment)
+	}
+	return fields
+}
+
+func isEmptyTeacherChange(change *dto.TeacherChangeVO) bool {
+	return len(teacherChangeFields(change)) == 0
+}
+
+// calcTeacherChangeScore 对比用户提交的修改与最终生效的修改，每个被采纳且取值一致的字段加1分
+func calcTeacherChangeScore(proposed, final *dto.TeacherChangeVO) int64 {
+	if proposed == nil || final == nil {
+		return 0
+	}
+	kept := func(p, f *string) bool {
+		return p != nil && f != nil && strings.TrimSpace(*p) == strings.TrimSpace(*f)
+	}
+
+	var score int64
+	if kept(proposed.Name, final.Name) {
+		score++
+	}
+	if kept(proposed.Title, final.Title) {
+		score++
+	}
+	if kept(proposed.Department, final.Department) {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+)
+
+func derefOrNil(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// TestTeacherChangeBetween 测试计算两个教师之间的字段级修改
+func TestTeacherChangeBetween(t *testing.T) {
+	from := &dto.TeacherVO{ID: "t1", Name: "张三", Title: "讲师", Department: "数学科学学院"}
+	tests := []struct {
+		name           string
+		to             *dto.TeacherVO
+		wantName       any
+		wantTitle      any
+		wantDepartment any
+	}{
+		{
+			name: "没有变化",
+			to:   &dto.TeacherVO{ID: "t1", Name: "张三", Title: "讲师", Department: "数学科学学院"},
+		},
+		{
+			name: "只有首尾空白不同视为未变化",
+			to:   &dto.TeacherVO{ID: "t1", Name: " 张三", Title: "讲师 ", Department: "数学科学学院"},
+		},
+		{
+			name:      "只包含变化的字段",
+			to:        &dto.TeacherVO{ID: "t1", Name: "张三", Title: "副教授", Department: "数学科学学院"},
+			wantTitle: "副教授",
+		},
+		{
+			name:           "全部变化",
+			to:             &dto.TeacherVO{ID: "t1", Name: "张叁", Title: "教授", Department: "物理与天文学院"},
+			wantName:       "张叁",
+			wantTitle:      "教授",
+			wantDepartment: "物理与天文学院",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := teacherChangeBetween(from, tt.to)
+			if v := derefOrNil(got.Name); v != tt.wantName {
+				t.Errorf("Name = %v, want %v", v, tt.wantName)
+			}
+			if v := derefOrNil(got.Title); v != tt.wantTitle {
+				t.Errorf("Title = %v, want %v", v, tt.wantTitle)
+			}
+			if v := derefOrNil(got.Department); v != tt.wantDepartment {
+				t.Errorf("Department = %v, want %v", v, tt.wantDepartment)
+			}
+		})
+	}
+}
+
+// TestCalcTeacherChangeScore 测试教师修改提案的贡献值计算
+func TestCalcTeacherChangeScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposed *dto.TeacherChangeVO
+		final    *dto.TeacherChangeVO
+		want     int64
+	}{
+		{
+			name:     "修改为空",
+			proposed: &dto.TeacherChangeVO{Title: strPtr("教授")},
+			final:    nil,
+			want:     0,
+		},
+		{
+			name:     "全部采纳",
+			proposed: &dto.TeacherChangeVO{Title: strPtr("教授"), Department: strPtr("物理与天文学院")},
+			final:    &dto.TeacherChangeVO{Title: strPtr("教授"), Department: strPtr("物理与天文学院")},
+			want:     2,
+		},
+		{
+			name:     "首尾空白不同仍视为一致",
+			proposed: &dto.TeacherChangeVO{Name: strPtr(" 张叁 ")},
+			final:    &dto.TeacherChangeVO{Name: strPtr("张叁")},
+			want:     1,
+		},
+		{
+			name:     "管理员修正了取值",
+			proposed: &dto.TeacherChangeVO{Title: strPtr("教授"), Name: strPtr("张叁")},
+			final:    &dto.TeacherChangeVO{Title: strPtr("副教授"), Name: strPtr("张叁")},
+			want:     1,
+		},
+		{
+			name:     "最终未修改的字段不计分",
+			proposed: &dto.TeacherChangeVO{Title: strPtr("教授")},
+			final:    &dto.TeacherChangeVO{Department: strPtr("物理与天文学院")},
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calcTeacherChangeScore(tt.proposed, tt.final); got != tt.want {
+				t.Errorf("calcTeacherChangeScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTeacherChangeDrift 测试审批前教师已被改动时，最终生效的修改只包含仍有变化的字段
+func TestTeacherChangeDrift(t *testing.T) {
+	proposed := &dto.TeacherChangeVO{Title: strPtr("教授"), Department: strPtr("物理与天文学院")}
+
+	// 提交后职称已被其他提案改成相同的值
+	current := &dto.TeacherVO{ID: "t1", Name: "张三", Title: "教授", Department: "数学科学学院"}
+	after := *current
+	after.Title = *proposed.Title
+	after.Department = *proposed.Department
+	final := teacherChangeBetween(current, &after)
+
+	if final.Title != nil || final.Department == nil {
+		t.Errorf("final change = %+v, want only department", final)
+	}
+	if got := calcTeacherChangeScore(proposed, final); got != 1 {
+		t.Errorf("calcTeacherChangeScore() = %d, want 1", got)
+	}
+}
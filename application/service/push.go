@@ -0,0 +1,357 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/wechat"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var _ IPushService = (*PushService)(nil)
+
+type IPushService interface {
+	GetSubscribeSetting(ctx context.Context, req *dto.GetSubscribeSettingReq) (*dto.GetSubscribeSettingResp, error)
+	RecordSubscribe(ctx context.Context, req *dto.RecordSubscribeReq) (*dto.RecordSubscribeResp, error)
+
+	PushProposalResult(ctx context.Context, proposal *model.Proposal, notifyType string, remark string) error
+	ProcessPushTasks(ctx context.Context) int
+	StartPushWorker(ctx context.Context)
+}
+
+type PushService struct {
+	PushTaskRepo         *repo.PushTaskRepo
+	SubscribeConsentRepo *repo.SubscribeConsentRepo
+	UserRepo             *repo.UserRepo
+	WeChatCache          *cache.WeChatCache
+}
+
+var PushServiceSet = wire.NewSet(
+	wire.Struct(new(PushService), "*"),
+	wire.Bind(new(IPushService), new(*PushService)),
+)
+
+// subscribeTypes 支持订阅消息推送的通知类型
+var subscribeTypes = []string{
+	consts.NotificationTypeProposalApproved,
+	consts.NotificationTypeProposalRejected,
+	consts.NotificationTypeProposalNeedsInfo,
+}
+
+// subscribeTemplate 获取通知类型对应的订阅消息模板配置
+func subscribeTemplate(notifyType string) (config.SubscribeTemplate, bool) {
+	sub := config.GetConfig().WeApp.Subscribe
+	switch notifyType {
+	case consts.NotificationTypeProposalApproved:
+		return sub.ProposalApproved, true
+	case consts.NotificationTypeProposalRejected:
+		return sub.ProposalRejected, true
+	case consts.NotificationTypeProposalNeedsInfo:
+		return sub.ProposalNeedsInfo, true
+	}
+	return config.SubscribeTemplate{}, false
+}
+
+// GetSubscribeSetting 获取可订阅的消息模板及我的剩余接收次数
+func (s *PushService) GetSubscribeSetting(ctx context.Context, req *dto.GetSubscribeSettingReq) (*dto.GetSubscribeSettingResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	templates, err := s.buildTemplateVOs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetSubscribeSettingResp{
+		Resp:      dto.Success(),
+		Templates: templates,
+	}, nil
+}
+
+// RecordSubscribe 记录用户同意接收的订阅消息，每次同意增加一次接收次数
+func (s *PushService) RecordSubscribe(ctx context.Context, req *dto.RecordSubscribeReq) (*dto.RecordSubscribeResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 校验消息类型
+	typeIds := make([]int32, 0, len(req.Types))
+	for _, t := range req.Types {
+		tpl, ok := subscribeTemplate(t)
+		if !ok || tpl.TemplateID == "" {
+			return nil, errorx.New(errno.ErrPushTypeNotSupported, errorx.KV("type", t))
+		}
+		typeIds = append(typeIds, mapping.Data.GetNotificationTypeIDByName(t))
+	}
+
+	// 增加接收次数
+	for _, typeId := range typeIds {
+		if err := s.SubscribeConsentRepo.IncreaseQuota(ctx, userId, typeId, 1); err != nil {
+			logs.CtxErrorf(ctx, "[SubscribeConsentRepo] [IncreaseQuota] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrPushConsentSaveFailed, errorx.KV("userId", userId))
+		}
+	}
+
+	templates, err := s.buildTemplateVOs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecordSubscribeResp{
+		Resp:      dto.Success(),
+		Templates: templates,
+	}, nil
+}
+
+// buildTemplateVOs 组装已配置模板的消息类型及用户剩余接收次数
+func (s *PushService) buildTemplateVOs(ctx context.Context, userId string) ([]*dto.SubscribeTemplateVO, error) {
+	consents, err := s.SubscribeConsentRepo.FindManyByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[SubscribeConsentRepo] [FindManyByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrPushConsentFindFailed, errorx.KV("userId", userId))
+	}
+	quotas := make(map[int32]int64, len(consents))
+	for _, c := range consents {
+		quotas[c.Type] = c.Quota
+	}
+
+	vos := make([]*dto.SubscribeTemplateVO, 0, len(subscribeTypes))
+	for _, t := range subscribeTypes {
+		tpl, _ := subscribeTemplate(t)
+		if tpl.TemplateID == "" {
+			continue
+		}
+		vos = append(vos, &dto.SubscribeTemplateVO{
+			Type:       t,
+			TemplateID: tpl.TemplateID,
+			Quota:      quotas[mapping.Data.GetNotificationTypeIDByName(t)],
+		})
+	}
+	return vos, nil
+}
+
+// PushProposalResult 向提案作者推送审核结果订阅消息
+// 模板未配置或用户没有剩余接收次数时直接跳过，消息写入推送队列后由后台任务发送
+func (s *PushService) PushProposalResult(ctx context.Context, proposal *model.Proposal, notifyType string, remark string) error {
+	tpl, ok := subscribeTemplate(notifyType)
+	if !ok || tpl.TemplateID == "" {
+		return nil
+	}
+
+	// 先查询接收者openid，无法推送时不扣减接收次数；没有openid属于永久性失败，重试无意义
+	user, err := s.UserRepo.FindByID(ctx, proposal.UserID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [FindByID] error: %v, userId: %s", err, proposal.UserID)
+		return errorx.WrapByCode(err, errno.ErrUserFindFailed, errorx.KV("userId", proposal.UserID))
+	}
+	if user == nil || user.OpenID == "" {
+		logs.CtxWarnf(ctx, "[PushService] [PushProposalResult] openid not found, skip push, userId: %s", proposal.UserID)
+		return nil
+	}
+
+	result := "已通过"
+	switch notifyType {
+	case consts.NotificationTypeProposalRejected:
+		result = "未通过"
+	case consts.NotificationTypeProposalNeedsInfo:
+		result = "待补充"
+	}
+
+	// 先写入推送队列，任务在扣减接收次数前不可领取；同一事件的唯一索引保证补发时不会重复扣减
+	now := time.Now()
+	task := &model.PushTask{
+		ID:         primitive.NewObjectID().Hex(),
+		UserID:     proposal.UserID,
+		OpenID:     user.OpenID,
+		TemplateID: tpl.TemplateID,
+		Page:       config.GetConfig().WeApp.Subscribe.Page,
+		Data: map[string]string{
+			tpl.TitleKey:  wechat.TruncateThing(proposal.Title),
+			tpl.ResultKey: result,
+			tpl.RemarkKey: wechat.TruncateThing(remark),
+			tpl.TimeKey:   now.Format("2006-01-02 15:04"),
+		},
+		Status:      consts.PushTaskStatusPending,
+		NextRetryAt: now.Add(consts.PushTaskLease),
+		EventKey:    eventbus.DispatchKey(ctx),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	inserted, err := s.PushTaskRepo.Insert(ctx, task)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[PushTaskRepo] [Insert] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrPushTaskInsertFailed, errorx.KV("userId", proposal.UserID))
+	}
+	if !inserted {
+		return nil
+	}
+
+	// 扣减接收次数，扣减失败或没有剩余次数时撤销任务
+	consumed, err := s.SubscribeConsentRepo.ConsumeQuota(ctx, proposal.UserID, mapping.Data.GetNotificationTypeIDByName(notifyType))
+	if err != nil || !consumed {
+		if delErr := s.PushTaskRepo.DeleteByID(ctx, task.ID); delErr != nil {
+			logs.CtxErrorf(ctx, "[PushTaskRepo] [DeleteByID] error: %v, taskId: %s", delErr, task.ID)
+		}
+	}
+	if err != nil {
+		logs.CtxErrorf(ctx, "[SubscribeConsentRepo] [ConsumeQuota] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrPushConsentFindFailed, errorx.KV("userId", proposal.UserID))
+	}
+	if !consumed {
+		return nil
+	}
+
+	if err = s.PushTaskRepo.Activate(ctx, task.ID); err != nil {
+		// 任务在租约到期后仍会被领取，只是发送稍有延迟
+		logs.CtxErrorf(ctx, "[PushTaskRepo] [Activate] error: %v, taskId: %s", err, task.ID)
+	}
+	return nil
+}
+
+// StartPushWorker 定时发送推送队列中到期的任务，直到ctx结束
+func (s *PushService) StartPushWorker(ctx context.Context) {
+	ticker := time.NewTicker(consts.PushWorkerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.ProcessPushTasks(ctx)
+		}
+	}
+}
+
+// ProcessPushTasks 发送一批到期的推送任务，返回处理的任务数
+func (s *PushService) ProcessPushTasks(ctx context.Context) int {
+	processed := 0
+	for processed < consts.PushWorkerBatchSize {
+		task, err := s.PushTaskRepo.ClaimDue(ctx, time.Now(), consts.PushTaskLease)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[PushTaskRepo] [ClaimDue] error: %v", err)
+			break
+		}
+		if task == nil {
+			break
+		}
+		s.deliver(ctx, task)
+		processed++
+	}
+	return processed
+}
+
+// deliver 发送一条推送任务并记录结果，可重试的失败按指数退避设置下次重试时间
+func (s *PushService) deliver(ctx context.Context, task *model.PushTask) {
+	err := s.send(ctx, task)
+	if err == nil {
+		if err = s.PushTaskRepo.MarkSent(ctx, task.ID); err != nil {
+			logs.CtxErrorf(ctx, "[PushTaskRepo] [MarkSent] error: %v, taskId: %s", err, task.ID)
+		}
+		return
+	}
+
+	logs.CtxWarnf(ctx, "[PushService] [deliver] send failed: %v, taskId: %s, attempts: %d", err, task.ID, task.Attempts)
+	var apiErr *wechat.APIError
+	if (errors.As(err, &apiErr) && apiErr.IsPermanent()) || task.Attempts >= consts.PushMaxAttempts {
+		if err = s.PushTaskRepo.MarkFailed(ctx, task.ID, err.Error()); err != nil {
+			logs.CtxErrorf(ctx, "[PushTaskRepo] [MarkFailed] error: %v, taskId: %s", err, task.ID)
+		}
+		return
+	}
+	nextRetryAt := time.Now().Add(consts.PushRetryBaseInterval << (task.Attempts - 1))
+	if err = s.PushTaskRepo.MarkRetry(ctx, task.ID, nextRetryAt, err.Error()); err != nil {
+		logs.CtxErrorf(ctx, "[PushTaskRepo] [MarkRetry] error: %v, taskId: %s", err, task.ID)
+	}
+}
+
+// send 调用微信接口发送订阅消息，access_token 失效时刷新后重发一次
+func (s *PushService) send(ctx context.Context, task *model.PushTask) error {
+	data := make(map[string]wechat.SubscribeValue, len(task.Data))
+	for k, v := range task.Data {
+		data[k] = wechat.SubscribeValue{Value: v}
+	}
+	msg := &wechat.SubscribeMessage{
+		ToUser:           task.OpenID,
+		TemplateID:       task.TemplateID,
+		Page:             task.Page,
+		MiniProgramState: config.GetConfig().WeApp.Subscribe.MiniProgramState,
+		Lang:             "zh_CN",
+		Data:             data,
+	}
+	baseURL := config.GetConfig().WeApp.APIBaseURL
+
+	token, err := s.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	err = wechat.SendSubscribeMessage(ctx, baseURL, token, msg)
+	var apiErr *wechat.APIError
+	if errors.As(err, &apiErr) && apiErr.IsTokenInvalid() {
+		if delErr := s.WeChatCache.DelAccessToken(ctx); delErr != nil {
+			logs.CtxWarnf(ctx, "[WeChatCache] [DelAccessToken] error: %v", delErr)
+		}
+		if token, err = s.getAccessToken(ctx); err != nil {
+			return err
+		}
+		err = wechat.SendSubscribeMessage(ctx, baseURL, token, msg)
+	}
+	return err
+}
+
+// getAccessToken 获取小程序 access_token，优先读取缓存
+func (s *PushService) getAccessToken(ctx context.Context) (string, error) {
+	token, hit, err := s.WeChatCache.GetAccessToken(ctx)
+	if err != nil {
+		logs.CtxWarnf(ctx, "[WeChatCache] [GetAccessToken] error: %v", err)
+	}
+	if hit {
+		return token, nil
+	}
+
+	weApp := config.GetConfig().WeApp
+	token, expiresIn, err := wechat.GetAccessToken(ctx, weApp.APIBaseURL, weApp.AppID, weApp.AppSecret)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WeChat] [GetAccessToken] error: %v", err)
+		return "", errorx.WrapByCode(err, errno.ErrPushAccessTokenFailed)
+	}
+
+	ttl := time.Duration(expiresIn)*time.Second - consts.CacheWeChatTokenMargin
+	if ttl > 0 {
+		if err = s.WeChatCache.SetAccessToken(ctx, token, ttl); err != nil {
+			logs.CtxWarnf(ctx, "[WeChatCache] [SetAccessToken] error: %v", err)
+		}
+	}
+	return token, nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/recommend"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+)
+
+// GetRecommendations 根据用户的搜索历史、评价过课程的院系与类别、点赞相似用户的偏好推荐课程
+// 得分在本地计算并附带推荐理由；单个信号查询失败时跳过该信号，个性化结果不足时以热门课程补足，已评价过的课程不推荐
+func (s *CourseService) GetRecommendations(ctx context.Context, req *dto.GetCourseRecommendationsReq) (*dto.GetCourseRecommendationsResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = consts.RecommendDefaultLimit
+	}
+
+	// 已评价过的课程既是院系、类别偏好的来源，也从推荐中排除
+	reviewed, err := s.CommentRepo.FindCourseIDsByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [FindCourseIDsByUserID] error: %v, userId: %s", err, userId)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseRecommendFailed)
+	}
+	ranker := recommend.NewRanker()
+	ranker.Exclude(reviewed...)
+
+	s.recommendBySearch(ctx, ranker, userId)
+	s.recommendByAffinity(ctx, ranker, reviewed)
+	s.recommendBySimilarUsers(ctx, ranker, userId)
+	if ranker.Len() < limit {
+		s.recommendPopular(ctx, ranker, limit)
+	}
+
+	// 按得分顺序加载课程，候选中已删除的课程被跳过
+	items := ranker.Top(limit)
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	courses := []*model.Course{}
+	if len(ids) > 0 {
+		if courses, err = s.CourseRepo.FindByIDs(ctx, ids); err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrCourseRecommendFailed)
+		}
+	}
+	byID := make(map[string]*model.Course, len(courses))
+	for _, course := range courses {
+		byID[course.ID] = course
+	}
+	dbs := make([]*model.Course, 0, len(courses))
+	kept := make([]*recommend.Item, 0, len(courses))
+	for _, item := range items {
+		if course, ok := byID[item.ID]; ok {
+			dbs = append(dbs, course)
+			kept = append(kept, item)
+		}
+	}
+
+	vos, err := s.CourseAssembler.ToCourseVOArray(ctx, dbs)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "recommended courses"),
+		)
+	}
+	results := make([]*dto.RecommendedCourseVO, len(vos))
+	for i, vo := range vos {
+		reasons := make([]string, 0, len(kept[i].Reasons))
+		for _, reason := range kept[i].Reasons {
+			reasons = append(reasons, recommendReasonText(reason))
+		}
+		results[i] = &dto.RecommendedCourseVO{
+			Course:  vo,
+			Score:   math.Round(kept[i].Score*100) / 100,
+			Reasons: reasons,
+		}
+	}
+
+	return &dto.GetCourseRecommendationsResp{
+		Resp:    dto.Success(),
+		Courses: results,
+	}, nil
+}
+
+// recommendBySearch 召回与最近搜索词相关的课程，越近的搜索词、越相关的课程权重越高
+func (s *CourseService) recommendBySearch(ctx context.Context, ranker *recommend.Ranker, userId string) {
+	histories, err := s.SearchHistoryRepo.FindManyByUserID(ctx, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[SearchHistoryRepo] [FindManyByUserID] error: %v, userId: %s", err, userId)
+		return
+	}
+	if len(histories) > consts.RecommendSearchTop {
+		histories = histories[:consts.RecommendSearchTop]
+	}
+	for i, history := range histories {
+		ids, err := s.findCourseIDsByKeyword(ctx, history.Query, consts.RecommendCandidateLimit)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseService] [findCourseIDsByKeyword] error: %v, keyword: %s", err, history.Query)
+			continue
+		}
+		weight := consts.RecommendWeightSearch * recommend.Decay(i)
+		for j, id := range ids {
+			ranker.Add(id, weight*recommend.Decay(j), consts.RecommendReasonSearch, history.Query)
+		}
+	}
+}
+
+// recommendByAffinity 按评价过课程的院系和类别分布，召回同院系、同类别中评论最多的课程
+func (s *CourseService) recommendByAffinity(ctx context.Context, ranker *recommend.Ranker, reviewed []string) {
+	if len(reviewed) == 0 {
+		return
+	}
+	courses, err := s.CourseRepo.FindByIDs(ctx, reviewed)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v", err)
+		return
+	}
+	departments := make(map[int32]int)
+	categories := make(map[int32]int)
+	for _, course := range courses {
+		if course.Department != 0 {
+			departments[course.Department]++
+		}
+		if course.Category != 0 {
+			categories[course.Category]++
+		}
+	}
+
+	recall := func(filter *repo.CourseFilter, weight float64, kind, subject string) {
+		found, _, err := s.CourseRepo.Search(ctx, filter, consts.CourseSortComments,
+			&dto.PageParam{Page: 1, PageSize: consts.RecommendCandidateLimit})
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [Search] error: %v, %s: %s", err, kind, subject)
+			return
+		}
+		for j, course := range found {
+			ranker.Add(course.ID, weight*recommend.Decay(j), kind, subject)
+		}
+	}
+	// 偏好强度为该院系（类别）在已评价课程中的占比
+	for _, id := range topCounts(departments, consts.RecommendAffinityTop) {
+		share := float64(departments[id]) / float64(len(courses))
+		recall(&repo.CourseFilter{DepartmentID: id}, consts.RecommendWeightDepartment*share,
+			consts.RecommendReasonDepartment, mapping.Data.GetDepartmentNameByID(id))
+	}
+	for _, id := range topCounts(categories, consts.RecommendAffinityTop) {
+		share := float64(categories[id]) / float64(len(courses))
+		recall(&repo.CourseFilter{CategoryID: id}, consts.RecommendWeightCategory*share,
+			consts.RecommendReasonCategory, mapping.Data.GetCategoryNameByID(id))
+	}
+}
+
+// recommendBySimilarUsers 基于评论点赞的协同过滤：找出点赞集合与用户最相似的同学，
+// 召回他们点赞过的评论所属的课程，权重为相似度
+func (s *CourseService) recommendBySimilarUsers(ctx context.Context, ranker *recommend.Ranker, userId string) {
+	targetType := mapping.Data.GetLikeTargetTypeIDByName(consts.LikeTargetTypeComment)
+	liked, err := s.LikeRepo.FindTargetIDsByUserID(ctx, userId, targetType, consts.RecommendLikeLimit)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[LikeRepo] [FindTargetIDsByUserID] error: %v, userId: %s", err, userId)
+		return
+	}
+	if len(liked) == 0 {
+		return
+	}
+	users, err := s.LikeRepo.FindUserIDsByTargets(ctx, liked, targetType, userId, consts.RecommendNeighborLimit)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[LikeRepo] [FindUserIDsByTargets] error: %v, userId: %s", err, userId)
+		return
+	}
+	if len(users) == 0 {
+		return
+	}
+	sets, err := s.LikeRepo.FindTargetIDsByUserIDs(ctx, users, targetType)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[LikeRepo] [FindTargetIDsByUserIDs] error: %v, userId: %s", err, userId)
+		return
+	}
+	neighbors := recommend.Neighbors(liked, sets, consts.RecommendNeighborTop, 0)
+	if len(neighbors) == 0 {
+		return
+	}
+
+	own := make(map[string]bool, len(liked))
+	for _, id := range liked {
+		own[id] = true
+	}
+	seen := make(map[string]bool)
+	var commentIds []string
+	for _, neighbor := range neighbors {
+		for _, id := range sets[neighbor.ID] {
+			if !own[id] && !seen[id] {
+				seen[id] = true
+				commentIds = append(commentIds, id)
+			}
+		}
+	}
+	if len(commentIds) == 0 {
+		return
+	}
+	courseOf, err := s.CommentRepo.GetCourseIDsByIDs(ctx, commentIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [GetCourseIDsByIDs] error: %v", err)
+		return
+	}
+
+	// 同一相似用户对同一课程只计一次
+	for _, neighbor := range neighbors {
+		counted := make(map[string]bool)
+		for _, id := range sets[neighbor.ID] {
+			courseId := courseOf[id]
+			if own[id] || courseId == "" || counted[courseId] {
+				continue
+			}
+			counted[courseId] = true
+			ranker.Add(courseId, consts.RecommendWeightSimilar*neighbor.Similarity, consts.RecommendReasonSimilar, "")
+		}
+	}
+}
+
+// recommendPopular 以评论数最多的课程补足推荐，不影响已有候选的得分
+func (s *CourseService) recommendPopular(ctx context.Context, ranker *recommend.Ranker, limit int) {
+	found, _, err := s.CourseRepo.Search(ctx, &repo.CourseFilter{}, consts.CourseSortComments,
+		&dto.PageParam{Page: 1, PageSize: int64(limit + consts.RecommendCandidateLimit)})
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [Search] error: %v, popular", err)
+		return
+	}
+	for j, course := range found {
+		ranker.Fill(course.ID, consts.RecommendWeightPopular*recommend.Decay(j), consts.RecommendReasonPopular, "")
+	}
+}
+
+// findCourseIDsByKeyword 按关键词相关度查询前 n 门课程的ID，索引就绪时走内存索引
+func (s *CourseService) findCourseIDsByKeyword(ctx context.Context, keyword string, n int64) ([]string, error) {
+	if s.SearchIndexer.Ready() {
+		hits, _ := s.SearchIndexer.Search(keyword, consts.SearchDocTypeCourse, 1, n)
+		ids := make([]string, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.ID
+		}
+		return ids, nil
+	}
+	courses, _, err := s.CourseRepo.Search(ctx, &repo.CourseFilter{Keyword: keyword}, consts.CourseSortRelevance,
+		&dto.PageParam{Page: 1, PageSize: n})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(courses))
+	for i, course := range courses {
+		ids[i] = course.ID
+	}
+	return ids, nil
+}
+
+// topCounts 返回出现次数最多的前 n 个ID，次数相同按ID升序
+func topCounts(counts map[int32]int, n int) []int32 {
+	ids := make([]int32, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > n {
+		ids = ids[:n]
+	}
+	return ids
+}
+
+// recommendReasonText 将推荐理由转换为展示给用户的文案
+func recommendReasonText(reason *recommend.Reason) string {
+	switch reason.Kind {
+	case consts.RecommendReasonSearch:
+		return fmt.Sprintf("因为你搜索过「%s」", reason.Subject)
+	case consts.RecommendReasonDepartment:
+		return fmt.Sprintf("因为你评价过「%s」的课程", reason.Subject)
+	case consts.RecommendReasonCategory:
+		return fmt.Sprintf("因为你评价过「%s」类课程", reason.Subject)
+	case consts.RecommendReasonSimilar:
+		return "和你点赞相似的同学也喜欢"
+	default:
+		return "热门课程"
+	}
+}
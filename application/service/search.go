@@ -35,8 +35,9 @@ type ISearchService interface {
 }
 
 type SearchService struct {
-	CourseRepo  *repo.CourseRepo
-	TeacherRepo *repo.TeacherRepo
+	CourseRepo    *repo.CourseRepo
+	TeacherRepo   *repo.TeacherRepo
+	SearchIndexer *SearchIndexer
 }
 
 var SearchServiceSet = wire.NewSet(
@@ -61,6 +62,9 @@ func (s *SearchService) GetSearchSuggestions(ctx context.Context, req *dto.GetSe
 	tasks := []func(ctx context.Context) ([]*dto.SearchSuggestionsVO, error){
 		// Courses
 		func(ctx context.Context) ([]*dto.SearchSuggestionsVO, error) {
+			if s.SearchIndexer.Ready() {
+				return s.indexSuggestions(req, consts.SearchDocTypeCourse, consts.SuggestionTargetTypeCourse), nil
+			}
 			courses, _, err := s.CourseRepo.GetSuggestionsByName(ctx, req.Keyword, req.PageParam)
 			if err != nil {
 				logs.CtxErrorf(ctx, "[CourseRepo] [GetSuggestionsByName] error: %v", err)
@@ -78,6 +82,9 @@ func (s *SearchService) GetSearchSuggestions(ctx context.Context, req *dto.GetSe
 		},
 		// Teachers
 		func(ctx context.Context) ([]*dto.SearchSuggestionsVO, error) {
+			if s.SearchIndexer.Ready() {
+				return s.indexSuggestions(req, consts.SearchDocTypeTeacher, consts.SuggestionTargetTypeTeacher), nil
+			}
 			teachers, _, err := s.TeacherRepo.GetSuggestionsByName(ctx, req.Keyword, req.PageParam)
 			if err != nil {
 				logs.CtxErrorf(ctx, "[TeacherRepo] [GetSuggestionsByName] error: %v", err)
@@ -162,3 +169,18 @@ func (s *SearchService) GetSearchSuggestions(ctx context.Context, req *dto.GetSe
 		Suggestions: vos,
 	}, nil
 }
+
+// indexSuggestions 从内存全文索引获取指定类型的搜索建议，附带高亮
+func (s *SearchService) indexSuggestions(req *dto.GetSearchSuggestionsReq, docType, suggestionType string) []*dto.SearchSuggestionsVO {
+	pageNum, pageSize := req.PageParam.UnWrap()
+	hits, _ := s.SearchIndexer.Search(req.Keyword, docType, pageNum, pageSize)
+	var vo []*dto.SearchSuggestionsVO
+	for _, hit := range hits {
+		vo = append(vo, &dto.SearchSuggestionsVO{
+			Type:      suggestionType,
+			Name:      hit.Text,
+			Highlight: hit.Highlight,
+		})
+	}
+	return vo
+}
@@ -18,7 +18,9 @@ import (
 	"context"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
 	"github.com/Boyuan-IT-Club/go-kit/errorx"
@@ -30,11 +32,12 @@ var _ ISearchHistoryService = (*SearchHistoryService)(nil)
 
 type ISearchHistoryService interface {
 	GetSearchHistory(ctx context.Context) (*dto.GetSearchHistoriesResp, error)
-	LogSearch(ctx context.Context, query string) error
+	LogSearch(ctx context.Context, query, department string) error
 }
 
 type SearchHistoryService struct {
 	SearchHistoryRepo *repo.SearchHistoryRepo
+	EventBus          *eventbus.Bus
 }
 
 var SearchHistoryServiceSet = wire.NewSet(
@@ -74,8 +77,8 @@ func (s *SearchHistoryService) GetSearchHistory(ctx context.Context) (*dto.GetSe
 	}, nil
 }
 
-// LogSearch 记录搜索记录
-func (s *SearchHistoryService) LogSearch(ctx context.Context, query string) error {
+// LogSearch 记录搜索记录，并发布搜索事件用于统计热搜，department 为搜索时筛选的院系名称
+func (s *SearchHistoryService) LogSearch(ctx context.Context, query, department string) error {
 	// 鉴权
 	userId, ok := ctx.Value(consts.CtxUserID).(string)
 	if !ok || userId == "" {
@@ -103,5 +106,9 @@ func (s *SearchHistoryService) LogSearch(ctx context.Context, query string) erro
 		}
 	}
 
+	if err = s.EventBus.Publish(ctx, event.SearchLogged{UserID: userId, Query: query, Department: department}); err != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [Publish] error: %v, query: %s", err, query)
+	}
+
 	return nil
 }
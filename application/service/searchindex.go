@@ -0,0 +1,178 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/fulltext"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+// SearchIndexer 维护课程、教师和提案的内存全文索引
+// 启动时从 Mongo 全量构建，之后通过仓储写入回调增量更新；未开启或尚未构建完成时 Ready 返回 false，
+// 调用方应回退到 Mongo 查询。索引只感知本进程的写入，多实例部署时其他实例的写入会在重启后同步。
+type SearchIndexer struct {
+	CourseRepo   *repo.CourseRepo
+	TeacherRepo  *repo.TeacherRepo
+	ProposalRepo *repo.ProposalRepo
+	Index        fulltext.SearchIndex
+
+	enabled bool
+	fuzzy   bool
+	ready   atomic.Bool
+}
+
+var SearchIndexerSet = wire.NewSet(NewSearchIndexer)
+
+// NewSearchIndexer 创建内存全文索引，需调用 Start 构建
+func NewSearchIndexer(cfg *config.Config, courseRepo *repo.CourseRepo, teacherRepo *repo.TeacherRepo,
+	proposalRepo *repo.ProposalRepo) *SearchIndexer {
+	return &SearchIndexer{
+		CourseRepo:   courseRepo,
+		TeacherRepo:  teacherRepo,
+		ProposalRepo: proposalRepo,
+		Index:        fulltext.NewMemory(),
+		enabled:      cfg.SearchIndex.Enabled,
+		fuzzy:        cfg.SearchIndex.Fuzzy,
+	}
+}
+
+// Start 注册写入回调并在后台全量构建索引，未开启时为空操作
+func (s *SearchIndexer) Start() {
+	if !s.enabled {
+		return
+	}
+	// 先注册回调再构建，避免遗漏构建期间的写入
+	s.CourseRepo.OnChange(s.syncCourses)
+	s.TeacherRepo.OnChange(s.syncTeachers)
+	s.ProposalRepo.OnChange(s.syncProposals)
+
+	go func() {
+		start := time.Now()
+		if err := s.build(context.Background()); err != nil {
+			logs.Errorf("[SearchIndexer] [Start] build error: %v", err)
+			return
+		}
+		s.ready.Store(true)
+		logs.Infof("[SearchIndexer] [Start] indexed %d documents in %v", s.Index.Len(), time.Since(start))
+	}()
+}
+
+// Ready 索引是否可用
+func (s *SearchIndexer) Ready() bool {
+	return s != nil && s.ready.Load()
+}
+
+// Search 在指定类型的文档中检索，返回当前页结果与命中总数
+func (s *SearchIndexer) Search(keyword, docType string, pageNum, pageSize int64) ([]*fulltext.Hit, int64) {
+	hits, total := s.Index.Search(&fulltext.Query{
+		Text:   keyword,
+		Types:  []string{docType},
+		Offset: int((pageNum - 1) * pageSize),
+		Limit:  int(pageSize),
+		Fuzzy:  s.fuzzy,
+	})
+	return hits, int64(total)
+}
+
+// build 从三个集合全量导入未删除的文档
+func (s *SearchIndexer) build(ctx context.Context) error {
+	if err := s.CourseRepo.ForEach(ctx, &repo.CourseFilter{}, func(course *model.Course) error {
+		s.Index.Upsert(courseDocument(course))
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := s.TeacherRepo.ForEach(ctx, func(teacher *model.Teacher) error {
+		if teacher.MergedInto == "" {
+			s.Index.Upsert(teacherDocument(teacher))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return s.ProposalRepo.ForEach(ctx, func(proposal *model.Proposal) error {
+		s.Index.Upsert(proposalDocument(proposal))
+		return nil
+	})
+}
+
+// syncCourses 按数据库中的最新状态更新课程文档，已删除或合并的课程移出索引
+func (s *SearchIndexer) syncCourses(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		course, err := s.CourseRepo.FindByID(ctx, id)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v, courseId: %s", err, id)
+			continue
+		}
+		if course == nil || course.Deleted {
+			s.Index.Delete(consts.SearchDocTypeCourse, id)
+			continue
+		}
+		s.Index.Upsert(courseDocument(course))
+	}
+}
+
+// syncTeachers 按数据库中的最新状态更新教师文档，已合并的教师移出索引
+func (s *SearchIndexer) syncTeachers(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		teacher, err := s.TeacherRepo.FindByID(ctx, id)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, id)
+			continue
+		}
+		if teacher == nil || teacher.MergedInto != "" {
+			s.Index.Delete(consts.SearchDocTypeTeacher, id)
+			continue
+		}
+		s.Index.Upsert(teacherDocument(teacher))
+	}
+}
+
+// syncProposals 按数据库中的最新状态更新提案文档，已删除的提案移出索引
+func (s *SearchIndexer) syncProposals(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		proposal, err := s.ProposalRepo.FindByIDIncludeDeleted(ctx, id)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[ProposalRepo] [FindByIDIncludeDeleted] error: %v, proposalId: %s", err, id)
+			continue
+		}
+		if proposal == nil || proposal.Deleted {
+			s.Index.Delete(consts.SearchDocTypeProposal, id)
+			continue
+		}
+		s.Index.Upsert(proposalDocument(proposal))
+	}
+}
+
+func courseDocument(course *model.Course) fulltext.Document {
+	return fulltext.Document{Type: consts.SearchDocTypeCourse, ID: course.ID, Text: course.Name}
+}
+
+func teacherDocument(teacher *model.Teacher) fulltext.Document {
+	return fulltext.Document{Type: consts.SearchDocTypeTeacher, ID: teacher.ID, Text: teacher.Name}
+}
+
+func proposalDocument(proposal *model.Proposal) fulltext.Document {
+	return fulltext.Document{Type: consts.SearchDocTypeProposal, ID: proposal.ID, Text: proposal.Title}
+}
@@ -0,0 +1,504 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/event"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/trending"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+// EventSubscriber 订阅领域事件，承接变更日志、贡献值、计数与通知等附带操作
+// 影响数据一致性的订阅者同步执行，通知类订阅者异步执行
+type EventSubscriber struct {
+	EventBus            *eventbus.Bus
+	UserRepo            *repo.UserRepo
+	ProposalRepo        *repo.ProposalRepo
+	CourseRepo          *repo.CourseRepo
+	CommentRepo         *repo.CommentRepo
+	CommentCache        *cache.CommentCache
+	CourseCache         *cache.CourseCache
+	ProposalCache       *cache.ProposalCache
+	TrendingCache       *cache.TrendingCache
+	CourseAssembler     *assembler.CourseAssembler
+	ProposalAssembler   *assembler.ProposalAssembler
+	ChangeLogService    IChangeLogService
+	NotificationService INotificationService
+	PushService         IPushService
+	WebhookService      IWebhookService
+}
+
+var EventSubscriberSet = wire.NewSet(
+	wire.Struct(new(EventSubscriber), "*"),
+)
+
+// Register 向事件总线注册全部订阅者
+func (s *EventSubscriber) Register() {
+	bus := s.EventBus
+
+	// 提案创建
+	eventbus.Subscribe(bus, "changelog", eventbus.Sync, s.logProposalCreated)
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countProposalCreated)
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookProposalCreated)
+
+	// 提案通过
+	eventbus.Subscribe(bus, "contribution", eventbus.Sync, s.settleContribution)
+	eventbus.Subscribe(bus, "changelog", eventbus.Sync, s.logProposalApproved)
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countProposalApproved)
+	eventbus.Subscribe(bus, "notification", eventbus.Async, s.notifyProposalApproved)
+	eventbus.Subscribe(bus, "push", eventbus.Async, s.pushProposalApproved)
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookProposalApproved)
+
+	// 提案驳回
+	eventbus.Subscribe(bus, "changelog", eventbus.Sync, s.logProposalRejected)
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countProposalRejected)
+	eventbus.Subscribe(bus, "notification", eventbus.Async, s.notifyProposalRejected)
+	eventbus.Subscribe(bus, "push", eventbus.Async, s.pushProposalRejected)
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookProposalRejected)
+
+	// 提案审批撤回
+	eventbus.Subscribe(bus, "changelog", eventbus.Sync, s.logProposalRevoked)
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countProposalRevoked)
+	eventbus.Subscribe(bus, "notification", eventbus.Async, s.notifyProposalRevoked)
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookProposalRevoked)
+
+	// 提案待补充信息
+	eventbus.Subscribe(bus, "changelog", eventbus.Sync, s.logProposalNeedsInfo)
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countProposalNeedsInfo)
+	eventbus.Subscribe(bus, "notification", eventbus.Async, s.notifyProposalNeedsInfo)
+	eventbus.Subscribe(bus, "push", eventbus.Async, s.pushProposalNeedsInfo)
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookProposalNeedsInfo)
+
+	// 课程创建
+	eventbus.Subscribe(bus, "webhook", eventbus.Async, s.webhookCourseCreated)
+
+	// 吐槽发布
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countCommentCreated)
+	eventbus.Subscribe(bus, "coursestats", eventbus.Sync, s.invalidateCourseStats)
+	eventbus.Subscribe(bus, "trending", eventbus.Sync, s.trendCommentCreated)
+
+	// 点赞
+	eventbus.Subscribe(bus, "counter", eventbus.Sync, s.countLikeToggled)
+	eventbus.Subscribe(bus, "notification", eventbus.Async, s.notifyLikeToggled)
+	eventbus.Subscribe(bus, "trending", eventbus.Sync, s.trendLikeToggled)
+
+	// 课程浏览与搜索；热度累加不幂等，trending 订阅者均同步执行，避免 outbox 补发时重复计数
+	eventbus.Subscribe(bus, "trending", eventbus.Sync, s.trendCourseViewed)
+	eventbus.Subscribe(bus, "trending", eventbus.Sync, s.trendSearchLogged)
+}
+
+// withActor 将事件触发者写入ctx，异步订阅者的ctx不携带请求信息
+func withActor(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, consts.CtxUserID, userId)
+}
+
+// logProposalCreated 记录提案创建的变更日志
+func (s *EventSubscriber) logProposalCreated(ctx context.Context, e event.ProposalCreated) error {
+	_, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     e.Proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       consts.ActionTypeCreateProposal,
+		Content:      "创建提案",
+		UpdateSource: consts.UpdateSourceUser,
+		ProposalID:   e.Proposal.ID,
+	})
+	return err
+}
+
+// webhookProposalCreated 向订阅方投递提案创建事件
+func (s *EventSubscriber) webhookProposalCreated(ctx context.Context, e event.ProposalCreated) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventProposalCreated, map[string]any{
+		"proposalId": e.Proposal.ID,
+		"title":      e.Proposal.Title,
+		"userId":     e.Proposal.UserID,
+	})
+}
+
+// countProposalCreated 清除待审核提案数缓存
+func (s *EventSubscriber) countProposalCreated(ctx context.Context, e event.ProposalCreated) error {
+	return s.ProposalCache.DelPendingCount(ctx)
+}
+
+// settleContribution 结算提案创建者的贡献值
+func (s *EventSubscriber) settleContribution(ctx context.Context, e event.ProposalApproved) error {
+	var score int64
+	switch e.Proposal.Type {
+	case consts.ProposalTypeChange:
+		score = calcChangeContributionScore(s.ProposalAssembler.ToCourseChangeVO(e.Proposal.Change), e.FinalChange)
+	case consts.ProposalTypeTeacher:
+		score = calcTeacherChangeScore(s.ProposalAssembler.ToTeacherChangeVO(e.Proposal.TeacherChange), e.FinalTeacherChange)
+	default:
+		originalVO, err := s.CourseAssembler.ToProposalCourseVO(ctx, e.Proposal.Course)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[CourseAssembler] [ToProposalCourseVO] error: %v, proposalId: %s", err, e.Proposal.ID)
+			return err
+		}
+		score = calcContributionScore(originalVO, e.FinalCourse)
+	}
+	if score <= 0 {
+		return nil
+	}
+
+	// 原子增加用户贡献值
+	if err := s.UserRepo.IncrementContribution(ctx, e.Proposal.UserID, score); err != nil {
+		logs.CtxErrorf(ctx, "[UserRepo] [IncrementContribution] error: %v, userId: %s", err, e.Proposal.UserID)
+		return err
+	}
+
+	// 将得分写入提案记录
+	if err := s.ProposalRepo.UpdateContributionByID(ctx, e.Proposal.ID, score); err != nil {
+		logs.CtxErrorf(ctx, "[ProposalRepo] [UpdateContributionByID] error: %v, proposalId: %s", err, e.Proposal.ID)
+		return err
+	}
+	return nil
+}
+
+// logProposalApproved 记录提案通过的变更日志
+func (s *EventSubscriber) logProposalApproved(ctx context.Context, e event.ProposalApproved) error {
+	_, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     e.Proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       consts.ActionTypeApproveProposal,
+		Content:      "审批提案：通过",
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   e.Proposal.ID,
+	})
+	return err
+}
+
+// countProposalApproved 清除待审核提案数缓存
+func (s *EventSubscriber) countProposalApproved(ctx context.Context, e event.ProposalApproved) error {
+	return s.ProposalCache.DelPendingCount(ctx)
+}
+
+// notifyProposalApproved 通知提案作者审核通过
+func (s *EventSubscriber) notifyProposalApproved(ctx context.Context, e event.ProposalApproved) error {
+	return s.NotificationService.CreateNotification(withActor(ctx, e.ReviewerID), &dto.CreateNotificationReq{
+		UserID:   e.Proposal.UserID,
+		Type:     consts.NotificationTypeProposalApproved,
+		TargetID: e.Proposal.ID,
+		Content:  "你的提案「" + e.Proposal.Title + "」已通过审核",
+	})
+}
+
+// pushProposalApproved 推送审核通过的订阅消息
+func (s *EventSubscriber) pushProposalApproved(ctx context.Context, e event.ProposalApproved) error {
+	return s.PushService.PushProposalResult(ctx, e.Proposal, consts.NotificationTypeProposalApproved, "感谢你的贡献")
+}
+
+// webhookProposalApproved 向订阅方投递提案通过事件
+func (s *EventSubscriber) webhookProposalApproved(ctx context.Context, e event.ProposalApproved) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventProposalApproved, map[string]any{
+		"proposalId": e.Proposal.ID,
+		"title":      e.Proposal.Title,
+		"userId":     e.Proposal.UserID,
+		"reviewerId": e.ReviewerID,
+	})
+}
+
+// logProposalRejected 记录提案驳回的变更日志
+func (s *EventSubscriber) logProposalRejected(ctx context.Context, e event.ProposalRejected) error {
+	content := "审批提案：拒绝"
+	if e.Reason != "" {
+		content = e.Reason
+	}
+	_, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     e.Proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       consts.ActionTypeRejectProposal,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   e.Proposal.ID,
+	})
+	return err
+}
+
+// countProposalRejected 清除待审核提案数缓存
+func (s *EventSubscriber) countProposalRejected(ctx context.Context, e event.ProposalRejected) error {
+	return s.ProposalCache.DelPendingCount(ctx)
+}
+
+// notifyProposalRejected 通知提案作者审核未通过
+func (s *EventSubscriber) notifyProposalRejected(ctx context.Context, e event.ProposalRejected) error {
+	content := "你的提案「" + e.Proposal.Title + "」未通过审核"
+	if e.Reason != "" {
+		content += "，原因：" + e.Reason
+	}
+	return s.NotificationService.CreateNotification(withActor(ctx, e.ReviewerID), &dto.CreateNotificationReq{
+		UserID:   e.Proposal.UserID,
+		Type:     consts.NotificationTypeProposalRejected,
+		TargetID: e.Proposal.ID,
+		Content:  content,
+	})
+}
+
+// pushProposalRejected 推送审核未通过的订阅消息
+func (s *EventSubscriber) pushProposalRejected(ctx context.Context, e event.ProposalRejected) error {
+	remark := "可修改后重新提交"
+	if e.Reason != "" {
+		remark = e.Reason
+	}
+	return s.PushService.PushProposalResult(ctx, e.Proposal, consts.NotificationTypeProposalRejected, remark)
+}
+
+// webhookProposalRejected 向订阅方投递提案驳回事件
+func (s *EventSubscriber) webhookProposalRejected(ctx context.Context, e event.ProposalRejected) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventProposalRejected, map[string]any{
+		"proposalId": e.Proposal.ID,
+		"title":      e.Proposal.Title,
+		"userId":     e.Proposal.UserID,
+		"reviewerId": e.ReviewerID,
+		"reason":     e.Reason,
+	})
+}
+
+// logProposalRevoked 记录撤回审批的变更日志
+func (s *EventSubscriber) logProposalRevoked(ctx context.Context, e event.ProposalRevoked) error {
+	action, content := consts.ActionTypeRevokeApproveProposal, "撤回提案审批：通过→待审核"
+	if e.ActionType == consts.RevokeActionReject {
+		action, content = consts.ActionTypeRevokeRejectProposal, "撤回提案审批：拒绝→待审核"
+	}
+	_, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     e.Proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       action,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   e.Proposal.ID,
+	})
+	return err
+}
+
+// countProposalRevoked 清除待审核提案数缓存
+func (s *EventSubscriber) countProposalRevoked(ctx context.Context, e event.ProposalRevoked) error {
+	return s.ProposalCache.DelPendingCount(ctx)
+}
+
+// notifyProposalRevoked 通知提案作者审批结果已撤回
+func (s *EventSubscriber) notifyProposalRevoked(ctx context.Context, e event.ProposalRevoked) error {
+	return s.NotificationService.CreateNotification(withActor(ctx, e.ReviewerID), &dto.CreateNotificationReq{
+		UserID:   e.Proposal.UserID,
+		Type:     consts.NotificationTypeProposalRevoked,
+		TargetID: e.Proposal.ID,
+		Content:  "你的提案「" + e.Proposal.Title + "」的审批结果已被撤回，将重新审核",
+	})
+}
+
+// webhookProposalRevoked 向订阅方投递撤回审批事件
+func (s *EventSubscriber) webhookProposalRevoked(ctx context.Context, e event.ProposalRevoked) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventProposalRevoked, map[string]any{
+		"proposalId": e.Proposal.ID,
+		"title":      e.Proposal.Title,
+		"userId":     e.Proposal.UserID,
+		"reviewerId": e.ReviewerID,
+		"actionType": e.ActionType,
+	})
+}
+
+// logProposalNeedsInfo 记录要求补充信息的变更日志
+func (s *EventSubscriber) logProposalNeedsInfo(ctx context.Context, e event.ProposalNeedsInfo) error {
+	_, err := s.ChangeLogService.CreateChangeLog(ctx, &dto.CreateChangeLogReq{
+		TargetID:     e.Proposal.ID,
+		TargetType:   consts.TargetTypeProposal,
+		Action:       consts.ActionTypeRequestProposalInfo,
+		Content:      "要求补充信息：" + e.Question,
+		UpdateSource: consts.UpdateSourceAdmin,
+		ProposalID:   e.Proposal.ID,
+	})
+	return err
+}
+
+// countProposalNeedsInfo 清除待审核提案数缓存
+func (s *EventSubscriber) countProposalNeedsInfo(ctx context.Context, e event.ProposalNeedsInfo) error {
+	return s.ProposalCache.DelPendingCount(ctx)
+}
+
+// notifyProposalNeedsInfo 通知提案作者补充信息
+func (s *EventSubscriber) notifyProposalNeedsInfo(ctx context.Context, e event.ProposalNeedsInfo) error {
+	return s.NotificationService.CreateNotification(withActor(ctx, e.ReviewerID), &dto.CreateNotificationReq{
+		UserID:   e.Proposal.UserID,
+		Type:     consts.NotificationTypeProposalNeedsInfo,
+		TargetID: e.Proposal.ID,
+		Content:  "你的提案「" + e.Proposal.Title + "」需要补充信息：" + e.Question,
+	})
+}
+
+// pushProposalNeedsInfo 推送需要补充信息的订阅消息
+func (s *EventSubscriber) pushProposalNeedsInfo(ctx context.Context, e event.ProposalNeedsInfo) error {
+	return s.PushService.PushProposalResult(ctx, e.Proposal, consts.NotificationTypeProposalNeedsInfo, e.Question)
+}
+
+// webhookProposalNeedsInfo 向订阅方投递要求补充信息事件
+func (s *EventSubscriber) webhookProposalNeedsInfo(ctx context.Context, e event.ProposalNeedsInfo) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventProposalNeedsInfo, map[string]any{
+		"proposalId": e.Proposal.ID,
+		"title":      e.Proposal.Title,
+		"userId":     e.Proposal.UserID,
+		"reviewerId": e.ReviewerID,
+		"question":   e.Question,
+	})
+}
+
+// webhookCourseCreated 向订阅方投递课程创建事件
+func (s *EventSubscriber) webhookCourseCreated(ctx context.Context, e event.CourseCreated) error {
+	return s.WebhookService.Publish(ctx, consts.WebhookEventCourseCreated, map[string]any{
+		"courseId":   e.Course.ID,
+		"name":       e.Course.Name,
+		"code":       e.Course.Code,
+		"proposalId": e.ProposalID,
+	})
+}
+
+// countCommentCreated 清除吐槽总数缓存，下次查询时重新统计
+func (s *EventSubscriber) countCommentCreated(ctx context.Context, e event.CommentCreated) error {
+	return s.CommentCache.DelCount(ctx)
+}
+
+// invalidateCourseStats 清除评论所属课程的统计缓存
+func (s *EventSubscriber) invalidateCourseStats(ctx context.Context, e event.CommentCreated) error {
+	return s.CourseCache.DelStats(ctx, e.Comment.CourseID)
+}
+
+// countLikeToggled 同步更新提案文档的点赞数
+func (s *EventSubscriber) countLikeToggled(ctx context.Context, e event.LikeToggled) error {
+	if e.TargetType != consts.LikeTargetTypeProposal {
+		return nil
+	}
+	delta := int64(1)
+	if !e.Active {
+		delta = int64(-1)
+	}
+	return s.ProposalRepo.IncrementLikeCnt(ctx, e.TargetID, delta)
+}
+
+// notifyLikeToggled 通知被点赞目标的作者，取消点赞不通知，同一用户反复点赞同一目标只通知一次
+func (s *EventSubscriber) notifyLikeToggled(ctx context.Context, e event.LikeToggled) error {
+	if !e.Active {
+		return nil
+	}
+
+	var ownerId, notifyType, content string
+	switch e.TargetType {
+	case consts.LikeTargetTypeProposal:
+		proposal, err := s.ProposalRepo.FindByID(ctx, e.TargetID)
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			return nil
+		}
+		ownerId, notifyType = proposal.UserID, consts.NotificationTypeProposalLiked
+		content = "有人赞了你的提案「" + proposal.Title + "」"
+	case consts.LikeTargetTypeComment:
+		comment, err := s.CommentRepo.FindByID(ctx, e.TargetID)
+		if err != nil {
+			return err
+		}
+		if comment == nil {
+			return nil
+		}
+		ownerId, notifyType = comment.UserID, consts.NotificationTypeCommentLiked
+		content = "有人赞了你的吐槽"
+	default:
+		return nil
+	}
+
+	return s.NotificationService.CreateNotification(withActor(ctx, e.UserID), &dto.CreateNotificationReq{
+		UserID:   ownerId,
+		Type:     notifyType,
+		TargetID: e.TargetID,
+		Content:  content,
+		EventKey: "like:" + e.UserID + ":" + e.TargetID,
+	})
+}
+
+// trendCommentCreated 为吐槽所属课程累加热度
+func (s *EventSubscriber) trendCommentCreated(ctx context.Context, e event.CommentCreated) error {
+	return s.trendCourse(ctx, e.Comment.CourseID, consts.TrendingWeightComment)
+}
+
+// trendLikeToggled 为被点赞吐槽所属课程累加热度，取消点赞时扣回
+func (s *EventSubscriber) trendLikeToggled(ctx context.Context, e event.LikeToggled) error {
+	if e.TargetType != consts.LikeTargetTypeComment {
+		return nil
+	}
+	comment, err := s.CommentRepo.FindByID(ctx, e.TargetID)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return nil
+	}
+	delta := consts.TrendingWeightLike
+	if !e.Active {
+		delta = -delta
+	}
+	return s.trendCourse(ctx, comment.CourseID, delta)
+}
+
+// trendCourseViewed 为查看的课程累加热度，同一用户每个窗口只计一次
+func (s *EventSubscriber) trendCourseViewed(ctx context.Context, e event.CourseViewed) error {
+	first, err := s.TrendingCache.MarkOnce(ctx, consts.TrendingKindCourse, e.UserID, e.CourseID, time.Now())
+	if err != nil || !first {
+		return err
+	}
+	return s.trendCourse(ctx, e.CourseID, consts.TrendingWeightView)
+}
+
+// trendSearchLogged 为规范化后的搜索关键词累加热度，筛选了院系时同时计入该院系的榜单
+func (s *EventSubscriber) trendSearchLogged(ctx context.Context, e event.SearchLogged) error {
+	keyword := trending.Keyword(e.Query)
+	if keyword == "" {
+		return nil
+	}
+	now := time.Now()
+	first, err := s.TrendingCache.MarkOnce(ctx, consts.TrendingKindSearch, e.UserID, keyword, now)
+	if err != nil || !first {
+		return err
+	}
+	scopes := []string{consts.TrendingScopeAll}
+	if did := mapping.Data.GetDepartmentIDByName(e.Department); did != 0 {
+		scopes = append(scopes, strconv.Itoa(int(did)))
+	}
+	return s.TrendingCache.Incr(ctx, consts.TrendingKindSearch, keyword, consts.TrendingWeightSearch, scopes, now)
+}
+
+// trendCourse 为课程累加热度，同时计入课程所属院系的榜单
+func (s *EventSubscriber) trendCourse(ctx context.Context, courseId string, delta int64) error {
+	course, err := s.CourseRepo.FindByID(ctx, courseId)
+	if err != nil {
+		return err
+	}
+	if course == nil {
+		return nil
+	}
+	scopes := []string{consts.TrendingScopeAll}
+	if course.Department != 0 {
+		scopes = append(scopes, strconv.Itoa(int(course.Department)))
+	}
+	return s.TrendingCache.Incr(ctx, consts.TrendingKindCourse, course.ID, delta, scopes, time.Now())
+}
@@ -16,17 +16,20 @@ package service
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/homonym"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/lib"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
 	"github.com/Boyuan-IT-Club/go-kit/errorx"
 	"github.com/Boyuan-IT-Club/go-kit/logs"
 	"github.com/google/wire"
-	"github.com/zeromicro/go-zero/core/stores/monc"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -34,13 +37,22 @@ var _ ITeacherService = (*TeacherService)(nil)
 
 type ITeacherService interface {
 	CreateTeacher(ctx context.Context, req *dto.CreateTeacherReq) (*dto.CreateTeacherResp, error)
+	UpdateTeacher(ctx context.Context, req *dto.UpdateTeacherReq) (*dto.UpdateTeacherResp, error)
+	MergeTeachers(ctx context.Context, req *dto.MergeTeachersReq) (*dto.MergeTeachersResp, error)
 	GetTeacherSuggestions(ctx context.Context, req *dto.GetTeacherSuggestionsReq) (*dto.GetTeacherSuggestionsResp, error)
+	GetTeacher(ctx context.Context, req *dto.GetTeacherReq) (*dto.GetTeacherResp, error)
 }
 
 type TeacherService struct {
-	UserRepo         *repo.UserRepo
-	TeacherRepo      *repo.TeacherRepo
-	TeacherAssembler *assembler.TeacherAssembler
+	UserRepo           *repo.UserRepo
+	TeacherRepo        *repo.TeacherRepo
+	CourseRepo         *repo.CourseRepo
+	CourseOfferingRepo *repo.CourseOfferingRepo
+	CommentRepo        *repo.CommentRepo
+	TeacherAssembler   *assembler.TeacherAssembler
+	CourseAssembler    *assembler.CourseAssembler
+	CommentAssembler   *assembler.CommentAssembler
+	ChangeLogService   IChangeLogService
 }
 
 var TeacherServiceSet = wire.NewSet(
@@ -51,47 +63,159 @@ var TeacherServiceSet = wire.NewSet(
 // CreateTeacher 创建教师
 func (s *TeacherService) CreateTeacher(ctx context.Context, req *dto.CreateTeacherReq) (*dto.CreateTeacherResp, error) {
 	// 鉴权
-	userId, ok := ctx.Value(consts.CtxUserID).(string)
-	if !ok || userId == "" {
-		return nil, errorx.New(errno.ErrUserNotLogin)
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
 	}
-	if admin, err := s.UserRepo.IsAdminByID(ctx, userId); err != nil {
-		if errors.Is(err, monc.ErrNotFound) {
-			return nil, errorx.WrapByCode(err, errno.ErrUserNotFound,
-				errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
-		}
-		return nil, errorx.WrapByCode(err, errno.ErrUserFindFailed,
-			errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
-	} else if !admin {
-		return nil, errorx.New(errno.ErrUserNotAdmin, errorx.KV("id", userId))
+
+	// 同名教师中院系（院系未知时为职称）相同的视为重复
+	candidates, err := s.TeacherRepo.FindManyByName(ctx, req.Name)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherExistsFailed, errorx.KV("name", req.Name))
+	}
+	if identicalTeacher(candidates, req.Department, req.Title) != nil {
+		return nil, errorx.New(errno.ErrTeacherExist, errorx.KV("name", req.Name))
 	}
 
-	// 构造教师实体
-	vo := &dto.TeacherVO{
+	// 增加教师，通过防重检查后才登记新院系
+	now := time.Now()
+	teacher := &model.Teacher{
 		ID:         primitive.NewObjectID().Hex(),
 		Name:       req.Name,
 		Title:      req.Title,
-		Department: req.Department,
+		Department: mapping.Data.AutoRegisterDepartment(req.Department),
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
+	if err = s.TeacherRepo.Insert(ctx, teacher); err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [Insert] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherInsertFailed, errorx.KV("name", teacher.Name))
+	}
+	s.logTeacherChange(ctx, teacher.ID, consts.ActionTypeCreateTeacher,
+		"新建教师「"+teacher.Name+"」", nil, teacher)
 
-	// 转换为DB
-	teacher := s.TeacherAssembler.ToTeacherDB(ctx, vo)
+	return &dto.CreateTeacherResp{Resp: dto.Success(), TeacherVO: s.TeacherAssembler.ToTeacherVO(ctx, teacher)}, nil
+}
 
-	// 防重 TODO：名字 职称是否重复
-	if exist, err := s.TeacherRepo.IsExistByID(ctx, teacher.ID); err != nil {
-		logs.CtxErrorf(ctx, "[TeacherRepo] [IsExistByID] error: %v", err)
-		return nil, errorx.WrapByCode(err, errno.ErrTeacherExistsFailed, errorx.KV("name", teacher.Name))
-	} else if exist {
-		return nil, errorx.New(errno.ErrTeacherExist, errorx.KV("name", teacher.Name))
+// UpdateTeacher 修改教师的姓名、职称与院系，修改后不能与其他同名教师身份重复
+func (s *TeacherService) UpdateTeacher(ctx context.Context, req *dto.UpdateTeacherReq) (*dto.UpdateTeacherResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
 	}
 
-	// 增加教师
-	if err := s.TeacherRepo.Insert(ctx, teacher); err != nil {
-		logs.CtxErrorf(ctx, "[TeacherRepo] [Insert] error: %v", err)
-		return nil, errorx.WrapByCode(err, errno.ErrTeacherInsertFailed, errorx.KV("name", teacher.Name))
+	before, err := s.findTeacher(ctx, req.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	if before.MergedInto != "" {
+		return nil, errorx.New(errno.ErrTeacherMerged,
+			errorx.KV("teacherId", before.ID), errorx.KV("targetId", before.MergedInto))
 	}
 
-	return &dto.CreateTeacherResp{Resp: dto.Success(), TeacherVO: vo}, nil
+	after, err := updateTeacherRecord(ctx, s.TeacherRepo, before, req.Name, req.Title, req.Department)
+	if err != nil {
+		return nil, err
+	}
+	s.logTeacherChange(ctx, before.ID, consts.ActionTypeUpdateTeacher,
+		"修改教师「"+after.Name+"」", before, after)
+
+	return &dto.UpdateTeacherResp{Resp: dto.Success(), Teacher: s.TeacherAssembler.ToTeacherVO(ctx, after)}, nil
+}
+
+// MergeTeachers 将重复的教师合并到保留教师：课程与开设改由保留教师授课，重复教师记录重定向，DryRun 时只返回影响预览
+func (s *TeacherService) MergeTeachers(ctx context.Context, req *dto.MergeTeachersReq) (*dto.MergeTeachersResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验保留教师与重复教师
+	target, err := s.findTeacher(ctx, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.MergedInto != "" {
+		return nil, errorx.New(errno.ErrTeacherMerged,
+			errorx.KV("teacherId", target.ID), errorx.KV("targetId", target.MergedInto))
+	}
+	sources := make([]*model.Teacher, 0, len(req.SourceIDs))
+	sourceIds := make([]string, 0, len(req.SourceIDs))
+	seen := map[string]bool{req.TargetID: true}
+	for _, id := range req.SourceIDs {
+		if seen[id] {
+			return nil, errorx.New(errno.ErrTeacherMergeInvalid,
+				errorx.KV("reason", "duplicated or target teacher id "+id))
+		}
+		seen[id] = true
+		source, err := s.findTeacher(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if source.MergedInto != "" {
+			return nil, errorx.New(errno.ErrTeacherMerged,
+				errorx.KV("teacherId", source.ID), errorx.KV("targetId", source.MergedInto))
+		}
+		sources = append(sources, source)
+		sourceIds = append(sourceIds, id)
+	}
+
+	// 统计影响范围
+	courseCnt, err := s.CourseRepo.CountByTeacherIDs(ctx, sourceIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [CountByTeacherIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	offeringCnt, err := s.CourseOfferingRepo.CountByTeacherIDs(ctx, sourceIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [CountByTeacherIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	preview := &dto.TeacherMergePreviewVO{
+		TargetID:      req.TargetID,
+		SourceIDs:     sourceIds,
+		CourseCount:   courseCnt,
+		OfferingCount: offeringCnt,
+	}
+	if req.DryRun {
+		return &dto.MergeTeachersResp{
+			Resp:    dto.Success(),
+			Preview: preview,
+		}, nil
+	}
+
+	// 课程与开设改由保留教师授课
+	if _, err = s.CourseRepo.ReplaceTeacher(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [ReplaceTeacher] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	if _, err = s.CourseOfferingRepo.ReplaceTeacher(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[CourseOfferingRepo] [ReplaceTeacher] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+
+	// 记录重定向
+	if err = s.TeacherRepo.MergeInto(ctx, sourceIds, req.TargetID); err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [MergeInto] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherMergeFailed, errorx.KV("targetId", req.TargetID))
+	}
+	for _, source := range sources {
+		after := *source
+		after.MergedInto = req.TargetID
+		s.logTeacherChange(ctx, source.ID, consts.ActionTypeMergeTeacher,
+			"教师「"+source.Name+"」合并到教师「"+target.Name+"」", source, &after)
+	}
+	s.logTeacherChange(ctx, target.ID, consts.ActionTypeMergeTeacher,
+		"合并重复教师到「"+target.Name+"」", nil, nil)
+
+	return &dto.MergeTeachersResp{
+		Resp:    dto.Success(),
+		Preview: preview,
+		Teacher: s.TeacherAssembler.ToTeacherVO(ctx, target),
+	}, nil
 }
 
 // GetTeacherSuggestions 获取教师建议列表
@@ -116,3 +240,186 @@ func (s *TeacherService) GetTeacherSuggestions(ctx context.Context, req *dto.Get
 		Teachers: vos,
 	}, nil
 }
+
+// GetTeacher 获取教师主页（已合并的教师返回合并目标的主页）：基本信息、授课的全部课程，以及这些课程评论的数量、标签分布、评分汇总和分页的最近评论
+func (s *TeacherService) GetTeacher(ctx context.Context, req *dto.GetTeacherReq) (*dto.GetTeacherResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 查询教师，已合并的教师重定向到合并目标
+	teacher, err := resolveTeacher(ctx, s.TeacherRepo, req.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	if teacher == nil {
+		return nil, errorx.New(errno.ErrTeacherNotFound, errorx.KV("name", req.TeacherID))
+	}
+
+	// 查询授课课程，评论汇总覆盖全部课程，主页只展示最近的若干门
+	courseIds, err := s.CourseRepo.FindIDsByTeacherID(ctx, teacher.ID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindIDsByTeacherID] error: %v, teacherId: %s", err, teacher.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherProfileFailed, errorx.KV("teacherId", teacher.ID))
+	}
+	resp := &dto.GetTeacherResp{
+		Resp:           dto.Success(),
+		Teacher:        s.TeacherAssembler.ToTeacherVO(ctx, teacher),
+		Courses:        []*dto.CourseVO{},
+		CourseCount:    int64(len(courseIds)),
+		TagCount:       map[string]int64{},
+		RecentComments: []*dto.CommentVO{},
+	}
+	if len(courseIds) == 0 {
+		return resp, nil
+	}
+
+	shownIds := courseIds[:min(len(courseIds), consts.TeacherProfileCourseLimit)]
+	found, err := s.CourseRepo.FindByIDs(ctx, shownIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v, teacherId: %s", err, teacher.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherProfileFailed, errorx.KV("teacherId", teacher.ID))
+	}
+	byID := make(map[string]*model.Course, len(found))
+	for _, course := range found {
+		byID[course.ID] = course
+	}
+	courses := make([]*model.Course, 0, len(found))
+	for _, id := range shownIds {
+		if course, ok := byID[id]; ok {
+			courses = append(courses, course)
+		}
+	}
+	if resp.Courses, err = s.CourseAssembler.ToCourseVOArray(ctx, courses); err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "course vos"),
+		)
+	}
+
+	// 汇总全部课程的标签分布
+	tags, err := s.CommentRepo.GetTagDistributionByCourseIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [GetTagDistributionByCourseIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherProfileFailed, errorx.KV("teacherId", teacher.ID))
+	}
+	for _, dist := range tags {
+		for tag, cnt := range dist {
+			resp.TagCount[tag] += cnt
+		}
+	}
+
+	// 最近评论
+	comments, total, err := s.CommentRepo.FindManyByCourseIDs(ctx, req.PageParam, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [FindManyByCourseIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherProfileFailed, errorx.KV("teacherId", teacher.ID))
+	}
+	resp.CommentCount = total
+	if len(comments) > 0 {
+		if resp.RecentComments, err = s.CommentAssembler.ToMyCommentVOArray(ctx, comments, userId); err != nil {
+			logs.CtxErrorf(ctx, "[CommentAssembler] [ToMyCommentVOArray] error: %v", err)
+			return nil, errorx.WrapByCode(err, errno.ErrTeacherProfileFailed, errorx.KV("teacherId", teacher.ID))
+		}
+	}
+
+	return resp, nil
+}
+
+// findTeacher 查询教师（不跟随合并重定向），不存在时返回 ErrTeacherNotFound
+func (s *TeacherService) findTeacher(ctx context.Context, teacherId string) (*model.Teacher, error) {
+	teacher, err := s.TeacherRepo.FindByID(ctx, teacherId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, teacherId)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", teacherId))
+	}
+	if teacher == nil {
+		return nil, errorx.New(errno.ErrTeacherNotFound, errorx.KV("name", teacherId))
+	}
+	return teacher, nil
+}
+
+// logTeacherChange 记录管理员对教师的变更，失败只记录日志
+func (s *TeacherService) logTeacherChange(ctx context.Context, teacherId string, action int32, content string, before, after *model.Teacher) {
+	req := &dto.CreateChangeLogReq{
+		TargetID:     teacherId,
+		TargetType:   mapping.Data.GetChangeLogTargetTypeIDByName(consts.ChangeLogTargetTypeTeacher),
+		Action:       action,
+		Content:      content,
+		UpdateSource: consts.UpdateSourceAdmin,
+	}
+	if before != nil {
+		req.Before = lib.JSONF(before)
+	}
+	if after != nil {
+		req.After = lib.JSONF(after)
+	}
+	if _, err := s.ChangeLogService.CreateChangeLog(ctx, req); err != nil {
+		logs.CtxErrorf(ctx, "[ChangeLogService] [CreateChangeLog] error: %v, teacherId: %s", err, teacherId)
+	}
+}
+
+// resolveTeacher 查询教师，已合并的教师重定向到合并目标（合并时已保证重定向只有一跳），不存在时返回 nil
+func resolveTeacher(ctx context.Context, teacherRepo repo.ITeacherRepo, teacherId string) (*model.Teacher, error) {
+	teacher, err := teacherRepo.FindByID(ctx, teacherId)
+	if err == nil && teacher != nil && teacher.MergedInto != "" {
+		teacherId = teacher.MergedInto
+		teacher, err = teacherRepo.FindByID(ctx, teacherId)
+	}
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindByID] error: %v, teacherId: %s", err, teacherId)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherFindFailed, errorx.KV("name", teacherId))
+	}
+	return teacher, nil
+}
+
+// identicalTeacher 返回候选中与院系和职称确定为同一身份的教师，只查询不登记院系
+// 院系尚未登记时不可能与已有教师重复
+func identicalTeacher(candidates []*model.Teacher, department, title string) *model.Teacher {
+	departmentId := mapping.Data.GetDepartmentIDByName(department)
+	if departmentId == 0 && department != "" {
+		return nil
+	}
+	return homonym.Identical(candidates, departmentId, title)
+}
+
+// updateTeacherRecord 修改教师的姓名、职称与院系，修改后与其他教师身份重复时返回 ErrTeacherExist
+func updateTeacherRecord(ctx context.Context, teacherRepo repo.ITeacherRepo, before *model.Teacher, name, title, department string) (*model.Teacher, error) {
+	// 防重，排除自身
+	candidates, err := teacherRepo.FindManyByName(ctx, name)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [FindManyByName] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherExistsFailed, errorx.KV("name", name))
+	}
+	others := make([]*model.Teacher, 0, len(candidates))
+	for _, c := range candidates {
+		if c.ID != before.ID {
+			others = append(others, c)
+		}
+	}
+	if identicalTeacher(others, department, title) != nil {
+		return nil, errorx.New(errno.ErrTeacherExist, errorx.KV("name", name))
+	}
+	after := *before
+	after.Name = name
+	after.Title = title
+	after.Department = mapping.Data.AutoRegisterDepartment(department)
+	after.UpdatedAt = time.Now()
+
+	if err = teacherRepo.Update(ctx, &after); err != nil {
+		logs.CtxErrorf(ctx, "[TeacherRepo] [Update] error: %v, teacherId: %s", err, before.ID)
+		return nil, errorx.WrapByCode(err, errno.ErrTeacherUpdateFailed, errorx.KV("teacherId", before.ID))
+	}
+	return &after, nil
+}
+
+// teacherIDs 提取教师ID列表
+func teacherIDs(teachers []*model.Teacher) []string {
+	ids := make([]string, len(teachers))
+	for i, teacher := range teachers {
+		ids[i] = teacher.ID
+	}
+	return ids
+}
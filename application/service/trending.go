@@ -0,0 +1,172 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+var _ ITrendingService = (*TrendingService)(nil)
+
+type ITrendingService interface {
+	GetTrendingCourses(ctx context.Context, req *dto.GetTrendingCoursesReq) (*dto.GetTrendingCoursesResp, error)
+	GetTrendingSearches(ctx context.Context, req *dto.GetTrendingSearchesReq) (*dto.GetTrendingSearchesResp, error)
+}
+
+// TrendingService 热门课程与热门搜索，热度由 EventSubscriber 按吐槽、点赞、浏览和搜索事件写入 Redis
+type TrendingService struct {
+	CourseRepo      *repo.CourseRepo
+	CourseAssembler *assembler.CourseAssembler
+	TrendingCache   *cache.TrendingCache
+}
+
+var TrendingServiceSet = wire.NewSet(
+	wire.Struct(new(TrendingService), "*"),
+	wire.Bind(new(ITrendingService), new(*TrendingService)),
+)
+
+// GetTrendingCourses 获取近三天按时间衰减的热门课程，已删除（包括已合并）的课程不返回
+func (s *TrendingService) GetTrendingCourses(ctx context.Context, req *dto.GetTrendingCoursesReq) (*dto.GetTrendingCoursesResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	scope, err := trendingScope(req.Department)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = consts.TrendingDefaultLimit
+	}
+
+	// 多取一倍，弥补已删除的课程
+	pairs, err := s.TrendingCache.Top(ctx, consts.TrendingKindCourse, scope, limit*2, time.Now())
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TrendingCache] [Top] error: %v, scope: %s", err, scope)
+		return nil, errorx.WrapByCode(err, errno.ErrTrendingFindFailed, errorx.KV("kind", "courses"))
+	}
+	ids := make([]string, 0, len(pairs))
+	scores := make(map[string]float64, len(pairs))
+	for _, p := range pairs {
+		if p.Score <= 0 {
+			break
+		}
+		ids = append(ids, p.Key)
+		scores[p.Key] = p.Score
+	}
+	if len(ids) == 0 {
+		return &dto.GetTrendingCoursesResp{Resp: dto.Success(), Courses: []*dto.TrendingCourseVO{}}, nil
+	}
+
+	found, err := s.CourseRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrTrendingFindFailed, errorx.KV("kind", "courses"))
+	}
+	byID := make(map[string]*model.Course, len(found))
+	for _, course := range found {
+		byID[course.ID] = course
+	}
+	courses := make([]*model.Course, 0, limit)
+	for _, id := range ids {
+		if course, ok := byID[id]; ok && int64(len(courses)) < limit {
+			courses = append(courses, course)
+		}
+	}
+
+	vos, err := s.CourseAssembler.ToCourseVOArray(ctx, courses)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "course vos"),
+		)
+	}
+	results := make([]*dto.TrendingCourseVO, len(vos))
+	for i, vo := range vos {
+		results[i] = &dto.TrendingCourseVO{Course: vo, Score: math.Round(scores[vo.ID]*100) / 100}
+	}
+
+	return &dto.GetTrendingCoursesResp{
+		Resp:    dto.Success(),
+		Courses: results,
+	}, nil
+}
+
+// GetTrendingSearches 获取近三天按时间衰减的全站热门搜索关键词
+func (s *TrendingService) GetTrendingSearches(ctx context.Context, req *dto.GetTrendingSearchesReq) (*dto.GetTrendingSearchesResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	scope, err := trendingScope(req.Department)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = consts.TrendingDefaultLimit
+	}
+
+	pairs, err := s.TrendingCache.Top(ctx, consts.TrendingKindSearch, scope, limit, time.Now())
+	if err != nil {
+		logs.CtxErrorf(ctx, "[TrendingCache] [Top] error: %v, scope: %s", err, scope)
+		return nil, errorx.WrapByCode(err, errno.ErrTrendingFindFailed, errorx.KV("kind", "searches"))
+	}
+	results := make([]*dto.TrendingSearchVO, 0, len(pairs))
+	for _, p := range pairs {
+		if p.Score <= 0 {
+			break
+		}
+		results = append(results, &dto.TrendingSearchVO{Keyword: p.Key, Score: math.Round(p.Score*100) / 100})
+	}
+
+	return &dto.GetTrendingSearchesResp{
+		Resp:     dto.Success(),
+		Searches: results,
+	}, nil
+}
+
+// trendingScope 将院系名称转换为榜单范围，为空时为全站榜单
+func trendingScope(department string) (string, error) {
+	if department == "" {
+		return consts.TrendingScopeAll, nil
+	}
+	did := mapping.Data.GetDepartmentIDByName(department)
+	if did == 0 {
+		return "", errorx.New(errno.ErrTrendingInvalidParam,
+			errorx.KV("key", "department"), errorx.KV("value", department))
+	}
+	return strconv.Itoa(int(did)), nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/assembler"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+)
+
+var _ IWatchlistService = (*WatchlistService)(nil)
+
+type IWatchlistService interface {
+	AddWatch(ctx context.Context, req *dto.AddWatchReq) (*dto.AddWatchResp, error)
+	RemoveWatch(ctx context.Context, req *dto.RemoveWatchReq) (*dto.RemoveWatchResp, error)
+	MarkWatchSeen(ctx context.Context, req *dto.MarkWatchSeenReq) (*dto.MarkWatchSeenResp, error)
+	ListWatchlist(ctx context.Context, req *dto.ListWatchlistReq) (*dto.ListWatchlistResp, error)
+}
+
+type WatchlistService struct {
+	WatchlistRepo   *repo.WatchlistRepo
+	CourseRepo      *repo.CourseRepo
+	CommentRepo     *repo.CommentRepo
+	CourseAssembler *assembler.CourseAssembler
+}
+
+var WatchlistServiceSet = wire.NewSet(
+	wire.Struct(new(WatchlistService), "*"),
+	wire.Bind(new(IWatchlistService), new(*WatchlistService)),
+)
+
+// AddWatch 关注课程
+func (s *WatchlistService) AddWatch(ctx context.Context, req *dto.AddWatchReq) (*dto.AddWatchResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 校验课程存在
+	course, err := s.CourseRepo.FindByID(ctx, req.CourseID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseFindFailed,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", req.CourseID))
+	}
+	if course == nil || course.Deleted {
+		return nil, errorx.New(errno.ErrCourseNotFound,
+			errorx.KV("key", consts.CourseID), errorx.KV("value", req.CourseID))
+	}
+
+	// 写入关注记录
+	if err = s.WatchlistRepo.Upsert(ctx, userId, req.CourseID); err != nil {
+		logs.CtxErrorf(ctx, "[WatchlistRepo] [Upsert] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWatchlistAddFailed, errorx.KV("courseId", req.CourseID))
+	}
+
+	return &dto.AddWatchResp{
+		Resp:    dto.Success(),
+		Watched: true,
+	}, nil
+}
+
+// RemoveWatch 取消关注课程
+func (s *WatchlistService) RemoveWatch(ctx context.Context, req *dto.RemoveWatchReq) (*dto.RemoveWatchResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 删除关注记录
+	if err := s.WatchlistRepo.Delete(ctx, userId, req.CourseID); err != nil {
+		logs.CtxErrorf(ctx, "[WatchlistRepo] [Delete] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWatchlistRemoveFailed, errorx.KV("courseId", req.CourseID))
+	}
+
+	return &dto.RemoveWatchResp{
+		Resp:    dto.Success(),
+		Watched: false,
+	}, nil
+}
+
+// MarkWatchSeen 记录用户查看了关注的课程，清空该课程的未读评论数
+func (s *WatchlistService) MarkWatchSeen(ctx context.Context, req *dto.MarkWatchSeenReq) (*dto.MarkWatchSeenResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 更新最后查看时间
+	matched, err := s.WatchlistRepo.UpdateLastSeenAt(ctx, userId, req.CourseID, time.Now())
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WatchlistRepo] [UpdateLastSeenAt] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWatchlistUpdateFailed, errorx.KV("courseId", req.CourseID))
+	}
+	if !matched {
+		return nil, errorx.New(errno.ErrWatchlistNotWatched, errorx.KV("courseId", req.CourseID))
+	}
+
+	return &dto.MarkWatchSeenResp{Resp: dto.Success()}, nil
+}
+
+// ListWatchlist 分页获取我关注的课程，附带每门课程上次查看后的新增评论数
+func (s *WatchlistService) ListWatchlist(ctx context.Context, req *dto.ListWatchlistReq) (*dto.ListWatchlistResp, error) {
+	// 鉴权
+	userId, ok := ctx.Value(consts.CtxUserID).(string)
+	if !ok || userId == "" {
+		return nil, errorx.New(errno.ErrUserNotLogin)
+	}
+
+	// 查询关注记录
+	watches, total, err := s.WatchlistRepo.FindManyByUserID(ctx, req.PageParam, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WatchlistRepo] [FindManyByUserID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWatchlistFindFailed,
+			errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
+	}
+
+	// 批量查询课程，关注记录已过滤掉已删除的课程，查询期间被删除的课程不再展示
+	courseIds := make([]string, 0, len(watches))
+	for _, w := range watches {
+		courseIds = append(courseIds, w.CourseID)
+	}
+	found, err := s.CourseRepo.FindByIDs(ctx, courseIds)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseRepo] [FindByIDs] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWatchlistFindFailed,
+			errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
+	}
+	courseByID := make(map[string]*model.Course, len(found))
+	for _, course := range found {
+		courseByID[course.ID] = course
+	}
+	courses := make([]*model.Course, 0, len(watches))
+	validWatches := make([]*model.Watch, 0, len(watches))
+	sinceByCourse := make(map[string]time.Time, len(watches))
+	for _, w := range watches {
+		course, ok := courseByID[w.CourseID]
+		if !ok {
+			continue
+		}
+		courses = append(courses, course)
+		validWatches = append(validWatches, w)
+		sinceByCourse[w.CourseID] = w.LastSeenAt
+	}
+
+	// 转换为VO
+	courseVOs, err := s.CourseAssembler.ToCourseVOArray(ctx, courses)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CourseAssembler] [ToCourseVOArray] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCourseCvtFailed,
+			errorx.KV("src", "database courses"), errorx.KV("dst", "course vos"))
+	}
+
+	// 统计未读评论数
+	unread, err := s.CommentRepo.CountByCourseIDsSince(ctx, sinceByCourse, userId)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[CommentRepo] [CountByCourseIDsSince] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrCommentCountFailed)
+	}
+	vos := make([]*dto.WatchedCourseVO, 0, len(validWatches))
+	for i, w := range validWatches {
+		vos = append(vos, &dto.WatchedCourseVO{
+			Course:     courseVOs[i],
+			UnreadCnt:  unread[w.CourseID],
+			LastSeenAt: w.LastSeenAt,
+			WatchedAt:  w.CreatedAt,
+		})
+	}
+
+	return &dto.ListWatchlistResp{
+		Resp:    dto.Success(),
+		Total:   total,
+		Courses: vos,
+	}, nil
+}
@@ -0,0 +1,526 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/webhook"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/errno"
+	"github.com/Boyuan-IT-Club/go-kit/errorx"
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var _ IWebhookService = (*WebhookService)(nil)
+
+type IWebhookService interface {
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookReq) (*dto.CreateWebhookResp, error)
+	ListWebhooks(ctx context.Context, req *dto.ListWebhooksReq) (*dto.ListWebhooksResp, error)
+	UpdateWebhook(ctx context.Context, req *dto.UpdateWebhookReq) (*dto.UpdateWebhookResp, error)
+	DeleteWebhook(ctx context.Context, req *dto.DeleteWebhookReq) (*dto.DeleteWebhookResp, error)
+	ListWebhookDeliveries(ctx context.Context, req *dto.ListWebhookDeliveriesReq) (*dto.ListWebhookDeliveriesResp, error)
+	RedeliverWebhook(ctx context.Context, req *dto.RedeliverWebhookReq) (*dto.RedeliverWebhookResp, error)
+	ListWebhookAttempts(ctx context.Context, req *dto.ListWebhookAttemptsReq) (*dto.ListWebhookAttemptsResp, error)
+
+	Publish(ctx context.Context, event string, data map[string]any) error
+	ProcessWebhookDeliveries(ctx context.Context) int
+	StartWebhookWorker(ctx context.Context)
+}
+
+type WebhookService struct {
+	WebhookRepo         *repo.WebhookRepo
+	WebhookDeliveryRepo *repo.WebhookDeliveryRepo
+	WebhookAttemptRepo  *repo.WebhookAttemptRepo
+	UserRepo            *repo.UserRepo
+}
+
+var WebhookServiceSet = wire.NewSet(
+	wire.Struct(new(WebhookService), "*"),
+	wire.Bind(new(IWebhookService), new(*WebhookService)),
+)
+
+// webhookEvents 可订阅的事件类型
+var webhookEvents = []string{
+	consts.WebhookEventProposalCreated,
+	consts.WebhookEventProposalApproved,
+	consts.WebhookEventProposalRejected,
+	consts.WebhookEventProposalRevoked,
+	consts.WebhookEventProposalNeedsInfo,
+	consts.WebhookEventCourseCreated,
+}
+
+// webhookPayload 投递给订阅方的请求体
+type webhookPayload struct {
+	ID        string         `json:"id"`
+	Event     string         `json:"event"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Data      map[string]any `json:"data"`
+}
+
+// CreateWebhook 创建webhook，签名密钥仅在创建时返回一次
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *dto.CreateWebhookReq) (*dto.CreateWebhookResp, error) {
+	// 鉴权
+	userId, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验参数
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	w := &model.Webhook{
+		ID:          primitive.NewObjectID().Hex(),
+		URL:         req.URL,
+		Secret:      webhook.NewSecret(),
+		Events:      req.Events,
+		Description: req.Description,
+		Active:      true,
+		CreatedBy:   userId,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.WebhookRepo.Insert(ctx, w); err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [Insert] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookInsertFailed, errorx.KV("url", req.URL))
+	}
+
+	return &dto.CreateWebhookResp{
+		Resp:    dto.Success(),
+		Webhook: toWebhookVO(w),
+		Secret:  w.Secret,
+	}, nil
+}
+
+// ListWebhooks 分页获取webhook列表
+func (s *WebhookService) ListWebhooks(ctx context.Context, req *dto.ListWebhooksReq) (*dto.ListWebhooksResp, error) {
+	// 鉴权
+	userId, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, total, err := s.WebhookRepo.FindMany(ctx, req.PageParam)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [FindMany] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookFindFailed,
+			errorx.KV("key", consts.CtxUserID), errorx.KV("value", userId))
+	}
+
+	vos := make([]*dto.WebhookVO, len(webhooks))
+	for i, w := range webhooks {
+		vos[i] = toWebhookVO(w)
+	}
+
+	return &dto.ListWebhooksResp{
+		Resp:     dto.Success(),
+		Total:    total,
+		Webhooks: vos,
+	}, nil
+}
+
+// UpdateWebhook 修改webhook的地址、订阅事件、描述或启用状态
+func (s *WebhookService) UpdateWebhook(ctx context.Context, req *dto.UpdateWebhookReq) (*dto.UpdateWebhookResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := s.WebhookRepo.FindByID(ctx, req.WebhookID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [FindByID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookFindFailed,
+			errorx.KV("key", consts.CtxWebhookID), errorx.KV("value", req.WebhookID))
+	}
+	if w == nil {
+		return nil, errorx.New(errno.ErrWebhookNotFound, errorx.KV("webhookId", req.WebhookID))
+	}
+
+	// 合并修改字段
+	if req.URL != "" {
+		if err = validateWebhookURL(req.URL); err != nil {
+			return nil, err
+		}
+		w.URL = req.URL
+	}
+	if req.Events != nil {
+		if err = validateWebhookEvents(req.Events); err != nil {
+			return nil, err
+		}
+		w.Events = req.Events
+	}
+	if req.Description != nil {
+		w.Description = *req.Description
+	}
+	if req.Active != nil {
+		w.Active = *req.Active
+	}
+	w.UpdatedAt = time.Now()
+
+	if err = s.WebhookRepo.Update(ctx, w); err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [Update] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookUpdateFailed, errorx.KV("webhookId", req.WebhookID))
+	}
+
+	return &dto.UpdateWebhookResp{
+		Resp:    dto.Success(),
+		Webhook: toWebhookVO(w),
+	}, nil
+}
+
+// DeleteWebhook 删除webhook，尚未投递的事件将在投递时进入死信列表
+func (s *WebhookService) DeleteWebhook(ctx context.Context, req *dto.DeleteWebhookReq) (*dto.DeleteWebhookResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.WebhookRepo.SoftDeleteByID(ctx, req.WebhookID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [SoftDeleteByID] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookDeleteFailed, errorx.KV("webhookId", req.WebhookID))
+	}
+	if !deleted {
+		return nil, errorx.New(errno.ErrWebhookNotFound, errorx.KV("webhookId", req.WebhookID))
+	}
+
+	return &dto.DeleteWebhookResp{
+		Resp:    dto.Success(),
+		Deleted: true,
+	}, nil
+}
+
+// ListWebhookDeliveries 分页获取事件投递记录，status=dead 即死信列表
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, req *dto.ListWebhookDeliveriesReq) (*dto.ListWebhookDeliveriesResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	var status int32
+	if req.Status != "" {
+		if status = webhookDeliveryStatusID(req.Status); status == 0 {
+			return nil, errorx.New(errno.ErrWebhookInvalidStatus, errorx.KV("status", req.Status))
+		}
+	}
+
+	deliveries, total, err := s.WebhookDeliveryRepo.FindMany(ctx, req.PageParam, req.WebhookID, status)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [FindMany] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookDeliveryFindFailed,
+			errorx.KV("key", consts.CtxWebhookID), errorx.KV("value", req.WebhookID))
+	}
+
+	vos := make([]*dto.WebhookDeliveryVO, len(deliveries))
+	for i, d := range deliveries {
+		vos[i] = &dto.WebhookDeliveryVO{
+			ID:             d.ID,
+			WebhookID:      d.WebhookID,
+			Event:          d.Event,
+			Payload:        d.Payload,
+			Status:         webhookDeliveryStatusName(d.Status),
+			Attempts:       d.Attempts,
+			NextRetryAt:    d.NextRetryAt,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt,
+			UpdatedAt:      d.UpdatedAt,
+		}
+	}
+
+	return &dto.ListWebhookDeliveriesResp{
+		Resp:       dto.Success(),
+		Total:      total,
+		Deliveries: vos,
+	}, nil
+}
+
+// RedeliverWebhook 将死信列表中的投递重新放回队列
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, req *dto.RedeliverWebhookReq) (*dto.RedeliverWebhookResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.WebhookDeliveryRepo.Requeue(ctx, req.DeliveryID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [Requeue] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookDeliveryFindFailed,
+			errorx.KV("key", consts.CtxDeliveryID), errorx.KV("value", req.DeliveryID))
+	}
+	if !requeued {
+		return nil, errorx.New(errno.ErrWebhookDeliveryNotDead, errorx.KV("deliveryId", req.DeliveryID))
+	}
+
+	return &dto.RedeliverWebhookResp{
+		Resp:     dto.Success(),
+		Requeued: true,
+	}, nil
+}
+
+// ListWebhookAttempts 分页获取投递尝试日志
+func (s *WebhookService) ListWebhookAttempts(ctx context.Context, req *dto.ListWebhookAttemptsReq) (*dto.ListWebhookAttemptsResp, error) {
+	// 鉴权
+	_, err := requireAdmin(ctx, s.UserRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, total, err := s.WebhookAttemptRepo.FindMany(ctx, req.PageParam, req.WebhookID, req.DeliveryID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookAttemptRepo] [FindMany] error: %v", err)
+		return nil, errorx.WrapByCode(err, errno.ErrWebhookDeliveryFindFailed,
+			errorx.KV("key", consts.CtxDeliveryID), errorx.KV("value", req.DeliveryID))
+	}
+
+	vos := make([]*dto.WebhookAttemptVO, len(attempts))
+	for i, a := range attempts {
+		vos[i] = &dto.WebhookAttemptVO{
+			ID:         a.ID,
+			DeliveryID: a.DeliveryID,
+			WebhookID:  a.WebhookID,
+			Event:      a.Event,
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMs: a.DurationMs,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+
+	return &dto.ListWebhookAttemptsResp{
+		Resp:     dto.Success(),
+		Total:    total,
+		Attempts: vos,
+	}, nil
+}
+
+// Publish 为订阅了该事件的每个webhook写入一条待投递记录，由后台任务异步投递
+// 由异步事件触发时同一事件补发不会重复写入
+func (s *WebhookService) Publish(ctx context.Context, event string, data map[string]any) error {
+	webhooks, err := s.WebhookRepo.FindActiveByEvent(ctx, event)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [FindActiveByEvent] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrWebhookPublishFailed, errorx.KV("event", event))
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	deliveries := make([]*model.WebhookDelivery, 0, len(webhooks))
+	for _, w := range webhooks {
+		id := primitive.NewObjectID().Hex()
+		payload, err := json.Marshal(&webhookPayload{ID: id, Event: event, CreatedAt: now, Data: data})
+		if err != nil {
+			return errorx.WrapByCode(err, errno.ErrWebhookPublishFailed, errorx.KV("event", event))
+		}
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			ID:          id,
+			WebhookID:   w.ID,
+			Event:       event,
+			Payload:     string(payload),
+			Status:      consts.WebhookDeliveryStatusPending,
+			NextRetryAt: now,
+			EventKey:    eventbus.DispatchKey(ctx),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	if err = s.WebhookDeliveryRepo.InsertMany(ctx, deliveries); err != nil {
+		logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [InsertMany] error: %v", err)
+		return errorx.WrapByCode(err, errno.ErrWebhookPublishFailed, errorx.KV("event", event))
+	}
+	return nil
+}
+
+// StartWebhookWorker 定时投递到期的webhook事件，直到ctx结束
+func (s *WebhookService) StartWebhookWorker(ctx context.Context) {
+	ticker := time.NewTicker(consts.WebhookWorkerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.ProcessWebhookDeliveries(ctx)
+		}
+	}
+}
+
+// ProcessWebhookDeliveries 投递一批到期的事件，返回处理的投递数
+func (s *WebhookService) ProcessWebhookDeliveries(ctx context.Context) int {
+	processed := 0
+	for processed < consts.WebhookWorkerBatchSize {
+		delivery, err := s.WebhookDeliveryRepo.ClaimDue(ctx, time.Now(), consts.WebhookDeliveryLease)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [ClaimDue] error: %v", err)
+			break
+		}
+		if delivery == nil {
+			break
+		}
+		s.deliver(ctx, delivery)
+		processed++
+	}
+	return processed
+}
+
+// deliver 投递一条事件并记录尝试日志，失败按指数退避重试，重试耗尽或webhook已停用时进入死信列表
+func (s *WebhookService) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	w, err := s.WebhookRepo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "[WebhookRepo] [FindByID] error: %v, webhookId: %s", err, delivery.WebhookID)
+		s.retryOrDead(ctx, delivery, 0, err.Error(), false)
+		return
+	}
+	if w == nil || !w.Active {
+		s.retryOrDead(ctx, delivery, 0, "webhook deleted or inactive", true)
+		return
+	}
+
+	start := time.Now()
+	statusCode, err := webhook.Post(ctx, w.URL, w.Secret, delivery.Event, delivery.ID, []byte(delivery.Payload))
+	attempt := &model.WebhookAttempt{
+		ID:         primitive.NewObjectID().Hex(),
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		Event:      delivery.Event,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: time.Since(start).Milliseconds(),
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	if insertErr := s.WebhookAttemptRepo.Insert(ctx, attempt); insertErr != nil {
+		logs.CtxErrorf(ctx, "[WebhookAttemptRepo] [Insert] error: %v, deliveryId: %s", insertErr, delivery.ID)
+	}
+
+	if err == nil {
+		if err = s.WebhookDeliveryRepo.MarkSucceeded(ctx, delivery.ID, statusCode); err != nil {
+			logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [MarkSucceeded] error: %v, deliveryId: %s", err, delivery.ID)
+		}
+		return
+	}
+	logs.CtxWarnf(ctx, "[WebhookService] [deliver] post failed: %v, deliveryId: %s, attempts: %d", err, delivery.ID, delivery.Attempts)
+	s.retryOrDead(ctx, delivery, statusCode, err.Error(), false)
+}
+
+// retryOrDead 设置下次重试时间，重试次数耗尽或 dead 为 true 时移入死信列表
+func (s *WebhookService) retryOrDead(ctx context.Context, delivery *model.WebhookDelivery, statusCode int, lastErr string, dead bool) {
+	if dead || delivery.Attempts >= consts.WebhookMaxAttempts {
+		if err := s.WebhookDeliveryRepo.MarkDead(ctx, delivery.ID, statusCode, lastErr); err != nil {
+			logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [MarkDead] error: %v, deliveryId: %s", err, delivery.ID)
+		}
+		return
+	}
+	nextRetryAt := time.Now().Add(consts.WebhookRetryBaseInterval << (delivery.Attempts - 1))
+	if err := s.WebhookDeliveryRepo.MarkRetry(ctx, delivery.ID, nextRetryAt, statusCode, lastErr); err != nil {
+		logs.CtxErrorf(ctx, "[WebhookDeliveryRepo] [MarkRetry] error: %v, deliveryId: %s", err, delivery.ID)
+	}
+}
+
+// validateWebhookURL 校验webhook地址为 http/https 绝对地址
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errorx.New(errno.ErrWebhookInvalidURL, errorx.KV("url", raw))
+	}
+	return nil
+}
+
+// validateWebhookEvents 校验订阅的事件类型
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return errorx.New(errno.ErrWebhookInvalidEvent, errorx.KV("event", ""))
+	}
+	for _, e := range events {
+		if e == consts.WebhookEventAll {
+			continue
+		}
+		valid := false
+		for _, known := range webhookEvents {
+			if e == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return errorx.New(errno.ErrWebhookInvalidEvent, errorx.KV("event", e))
+		}
+	}
+	return nil
+}
+
+// webhookDeliveryStatusID 投递状态名称转换为ID，未知状态返回0
+func webhookDeliveryStatusID(name string) int32 {
+	switch name {
+	case "pending":
+		return consts.WebhookDeliveryStatusPending
+	case "succeeded":
+		return consts.WebhookDeliveryStatusSucceeded
+	case "dead":
+		return consts.WebhookDeliveryStatusDead
+	}
+	return 0
+}
+
+// webhookDeliveryStatusName 投递状态ID转换为名称
+func webhookDeliveryStatusName(id int32) string {
+	switch id {
+	case consts.WebhookDeliveryStatusPending:
+		return "pending"
+	case consts.WebhookDeliveryStatusSucceeded:
+		return "succeeded"
+	case consts.WebhookDeliveryStatusDead:
+		return "dead"
+	}
+	return ""
+}
+
+// toWebhookVO 转换为webhook VO，不包含签名密钥
+func toWebhookVO(w *model.Webhook) *dto.WebhookVO {
+	return &dto.WebhookVO{
+		ID:          w.ID,
+		URL:         w.URL,
+		Events:      w.Events,
+		Description: w.Description,
+		Active:      w.Active,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// backfillsearchkeys 为存量课程、教师和提案回填拼音、首字母和规范化名称等搜索键，可重复执行
+// 搜索键格式变化（如拼音后缀改为全拼和首字母后缀）后需要重新执行
+//
+//	go run ./cmd/backfillsearchkeys
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+)
+
+func main() {
+	ctx := context.Background()
+	cfg, err := config.NewConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	courses, err := repo.NewCourseRepo(cfg).RebuildSearchKeys(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebuild course search keys: %v (updated %d)\n", err, courses)
+		os.Exit(1)
+	}
+	teachers, err := repo.NewTeacherRepo(cfg).RebuildSearchKeys(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebuild teacher search keys: %v (updated %d)\n", err, teachers)
+		os.Exit(1)
+	}
+	proposals, err := repo.NewProposalRepo(cfg).RebuildSearchKeys(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebuild proposal search keys: %v (updated %d)\n", err, proposals)
+		os.Exit(1)
+	}
+	fmt.Printf("updated %d courses, %d teachers, %d proposals\n", courses, teachers, proposals)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// importcourses 从命令行批量导入课程目录，默认只打印差异预览，加 -apply 后才写入
+//
+//	go run ./cmd/importcourses -file courses.xlsx -admin <管理员用户ID> [-apply]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/provider"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+)
+
+func main() {
+	file := flag.String("file", "", "课程目录文件（.csv 或 .xlsx）")
+	admin := flag.String("admin", "", "执行导入的管理员用户ID，用于鉴权与变更日志")
+	apply := flag.Bool("apply", false, "应用差异，不指定时只预览")
+	flag.Parse()
+	if *file == "" || *admin == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	provider.Init()
+	ctx := context.WithValue(context.Background(), consts.CtxUserID, *admin)
+	resp, err := provider.Get().CourseService.ImportCourses(ctx, &dto.ImportCoursesReq{
+		DryRun:   !*apply,
+		FileName: filepath.Base(*file),
+		Data:     data,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(resp.Diff); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
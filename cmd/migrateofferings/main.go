@@ -0,0 +1,119 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// migrateofferings 为尚无开设记录的存量课程生成开设：按课程当前的每个校区各生成一条，教师沿用课程的教师，
+// 学期默认按课程创建时间推算，可用 -semester 统一指定；已有开设（包括已删除的）的课程会被跳过，可重复执行
+// 默认只打印将要生成的数量，加 -apply 后才写入
+//
+//	go run ./cmd/migrateofferings [-semester 2024-2025-1] [-apply]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/semester"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func main() {
+	sem := flag.String("semester", "", "统一指定开设学期，如 2024-2025-1，不指定时按课程创建时间推算")
+	apply := flag.Bool("apply", false, "写入开设，不指定时只预览")
+	flag.Parse()
+	if *sem != "" && !semester.Valid(*sem) {
+		fmt.Fprintf(os.Stderr, "invalid semester: %q\n", *sem)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	cfg, err := config.NewConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load config: %v\n", err)
+		os.Exit(1)
+	}
+	loc, err := time.LoadLocation(consts.StatsTimezone)
+	if err != nil {
+		loc = time.Local
+	}
+
+	courseRepo := repo.NewCourseRepo(cfg)
+	offeringRepo := repo.NewCourseOfferingRepo(cfg)
+	var courses, skipped, created int64
+	err = courseRepo.ForEach(ctx, &repo.CourseFilter{}, func(course *model.Course) error {
+		courses++
+		cnt, err := offeringRepo.CountByCourseID(ctx, course.ID)
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			skipped++
+			return nil
+		}
+		for _, offering := range offeringsOf(course, *sem, loc) {
+			if *apply {
+				if err = offeringRepo.Insert(ctx, offering); err != nil {
+					return err
+				}
+			}
+			created++
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate offerings: %v (created %d)\n", err, created)
+		os.Exit(1)
+	}
+
+	verb := "would create"
+	if *apply {
+		verb = "created"
+	}
+	fmt.Printf("scanned %d courses, skipped %d already migrated, %s %d offerings\n", courses, skipped, verb, created)
+}
+
+// offeringsOf 按课程的校区生成开设，没有校区时生成一条校区未知的开设
+func offeringsOf(course *model.Course, sem string, loc *time.Location) []*model.CourseOffering {
+	now := time.Now()
+	if sem == "" {
+		created := course.CreatedAt
+		if created.IsZero() {
+			created = now
+		}
+		sem = semester.Of(created.In(loc))
+	}
+	campuses := course.Campuses
+	if len(campuses) == 0 {
+		campuses = []int32{0}
+	}
+	offerings := make([]*model.CourseOffering, 0, len(campuses))
+	for _, campus := range campuses {
+		offerings = append(offerings, &model.CourseOffering{
+			ID:         primitive.NewObjectID().Hex(),
+			CourseID:   course.ID,
+			Semester:   sem,
+			TeacherIDs: append([]string{}, course.TeacherIDs...),
+			Campus:     campus,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	return offerings
+}
@@ -32,6 +32,7 @@ const (
 type ICommentCache interface {
 	GetCount(ctx context.Context) (int64, bool, error)
 	SetCount(ctx context.Context, count int64, ttl time.Duration) error
+	DelCount(ctx context.Context) error
 }
 
 type CommentCache struct {
@@ -64,3 +65,9 @@ func (c *CommentCache) GetCount(ctx context.Context) (int64, bool, error) {
 func (c *CommentCache) SetCount(ctx context.Context, count int64, ttl time.Duration) error {
 	return c.cache.SetexCtx(ctx, CommentCountCacheKey, strconv.FormatInt(count, 10), int(ttl.Seconds()))
 }
+
+// DelCount 清除评论总数缓存
+func (c *CommentCache) DelCount(ctx context.Context) error {
+	_, err := c.cache.DelCtx(ctx, CommentCountCacheKey)
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+var _ ICourseCache = (*CourseCache)(nil)
+
+const (
+	CourseStatsCacheKey = consts.CacheCourseKeyPrefix + "stats:"
+)
+
+type ICourseCache interface {
+	GetStats(ctx context.Context, courseId string) (*model.CourseStats, bool, error)
+	SetStats(ctx context.Context, stats *model.CourseStats, ttl time.Duration) error
+	DelStats(ctx context.Context, courseIds ...string) error
+}
+
+type CourseCache struct {
+	cache *redis.Redis
+}
+
+func NewCourseCache(cfg *config.Config) *CourseCache {
+	cache := redis.MustNewRedis(*cfg.Redis)
+	return &CourseCache{cache: cache}
+}
+
+// GetStats 获取课程统计缓存
+// 返回值：stats, isHit, error
+func (c *CourseCache) GetStats(ctx context.Context, courseId string) (*model.CourseStats, bool, error) {
+	key := CourseStatsCacheKey + courseId
+	val, err := c.cache.GetCtx(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if val == "" {
+		return nil, false, nil
+	}
+	stats := &model.CourseStats{}
+	if err = json.Unmarshal([]byte(val), stats); err != nil {
+		_, _ = c.cache.DelCtx(ctx, key)
+		return nil, false, err
+	}
+	return stats, true, nil
+}
+
+// SetStats 设置课程统计缓存
+func (c *CourseCache) SetStats(ctx context.Context, stats *model.CourseStats, ttl time.Duration) error {
+	val, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return c.cache.SetexCtx(ctx, CourseStatsCacheKey+stats.CourseID, string(val), int(ttl.Seconds()))
+}
+
+// DelStats 清除课程统计缓存
+func (c *CourseCache) DelStats(ctx context.Context, courseIds ...string) error {
+	if len(courseIds) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(courseIds))
+	for _, id := range courseIds {
+		keys = append(keys, CourseStatsCacheKey+id)
+	}
+	_, err := c.cache.DelCtx(ctx, keys...)
+	return err
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+var _ IProposalCache = (*ProposalCache)(nil)
+
+const (
+	ProposalPendingCountCacheKey = "meowpick:proposal:pending:count"
+)
+
+type IProposalCache interface {
+	GetPendingCount(ctx context.Context) (int64, bool, error)
+	SetPendingCount(ctx context.Context, count int64, ttl time.Duration) error
+	DelPendingCount(ctx context.Context) error
+}
+
+type ProposalCache struct {
+	cache *redis.Redis
+}
+
+func NewProposalCache(cfg *config.Config) *ProposalCache {
+	cache := redis.MustNewRedis(*cfg.Redis)
+	return &ProposalCache{cache: cache}
+}
+
+// GetPendingCount 获取待审核提案数缓存
+func (c *ProposalCache) GetPendingCount(ctx context.Context) (int64, bool, error) {
+	countStr, err := c.cache.GetCtx(ctx, ProposalPendingCountCacheKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if countStr == "" {
+		return 0, false, nil
+	}
+	count, err := strconv.ParseInt(countStr, 10, 64)
+	if err != nil {
+		_, _ = c.cache.DelCtx(ctx, ProposalPendingCountCacheKey)
+		return 0, false, err
+	}
+	return count, true, nil
+}
+
+// SetPendingCount 设置待审核提案数缓存
+func (c *ProposalCache) SetPendingCount(ctx context.Context, count int64, ttl time.Duration) error {
+	return c.cache.SetexCtx(ctx, ProposalPendingCountCacheKey, strconv.FormatInt(count, 10), int(ttl.Seconds()))
+}
+
+// DelPendingCount 清除待审核提案数缓存
+func (c *ProposalCache) DelPendingCount(ctx context.Context) error {
+	_, err := c.cache.DelCtx(ctx, ProposalPendingCountCacheKey)
+	return err
+}
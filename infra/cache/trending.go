@@ -0,0 +1,106 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/trending"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+var _ ITrendingCache = (*TrendingCache)(nil)
+
+const (
+	TrendingMarkCacheKey = consts.CacheTrendingKeyPrefix + "mark:"
+)
+
+type ITrendingCache interface {
+	Incr(ctx context.Context, kind, member string, delta int64, scopes []string, now time.Time) error
+	Top(ctx context.Context, kind, scope string, limit int64, now time.Time) ([]redis.FloatPair, error)
+	MarkOnce(ctx context.Context, kind, userId, member string, now time.Time) (bool, error)
+}
+
+type TrendingCache struct {
+	cache *redis.Redis
+	decay trending.Decay
+}
+
+func NewTrendingCache(cfg *config.Config) *TrendingCache {
+	cache := redis.MustNewRedis(*cfg.Redis)
+	return &TrendingCache{cache: cache, decay: trending.Decay{
+		Window:   consts.TrendingWindow,
+		Windows:  consts.TrendingWindows,
+		HalfLife: consts.TrendingHalfLife,
+	}}
+}
+
+// trendingKey 榜单的键前缀，花括号作为集群 hash tag 保证同一榜单的桶落在同一节点上
+func trendingKey(kind, scope string) string {
+	return consts.CacheTrendingKeyPrefix + "{" + kind + ":" + scope + "}:"
+}
+
+// Incr 在当前窗口的桶中为成员累加热度，scopes 为需要同时累加的榜单
+func (c *TrendingCache) Incr(ctx context.Context, kind, member string, delta int64, scopes []string, now time.Time) error {
+	bucket := strconv.FormatInt(c.decay.Bucket(now), 10)
+	ttl := c.decay.TTL()
+	return c.cache.PipelinedCtx(ctx, func(p redis.Pipeliner) error {
+		for _, scope := range scopes {
+			key := trendingKey(kind, scope) + bucket
+			p.ZIncrBy(ctx, key, float64(delta), member)
+			p.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+}
+
+// Top 返回榜单热度最高的成员，按热度降序
+// 各桶按年龄衰减后合并，合并结果缓存 consts.CacheTrendingTopTTL
+func (c *TrendingCache) Top(ctx context.Context, kind, scope string, limit int64, now time.Time) ([]redis.FloatPair, error) {
+	prefix := trendingKey(kind, scope)
+	dest := prefix + "top"
+	exists, err := c.cache.ExistsCtx(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		buckets := c.decay.Buckets(now)
+		keys := make([]string, len(buckets))
+		for i, b := range buckets {
+			keys[i] = prefix + strconv.FormatInt(b, 10)
+		}
+		if _, err = c.cache.ZunionstoreCtx(ctx, dest, &redis.ZStore{
+			Keys:      keys,
+			Weights:   c.decay.Weights(),
+			Aggregate: "SUM",
+		}); err != nil {
+			return nil, err
+		}
+		if err = c.cache.ExpireCtx(ctx, dest, int(consts.CacheTrendingTopTTL.Seconds())); err != nil {
+			return nil, err
+		}
+	}
+	return c.cache.ZrevrangeWithScoresByFloatCtx(ctx, dest, 0, limit-1)
+}
+
+// MarkOnce 标记用户在当前窗口对成员的行为，返回是否为首次
+func (c *TrendingCache) MarkOnce(ctx context.Context, kind, userId, member string, now time.Time) (bool, error) {
+	key := TrendingMarkCacheKey + kind + ":" + strconv.FormatInt(c.decay.Bucket(now), 10) + ":" + userId + ":" + member
+	return c.cache.SetnxExCtx(ctx, key, "1", int(c.decay.Window.Seconds()))
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+var _ IWeChatCache = (*WeChatCache)(nil)
+
+const (
+	WeChatAccessTokenCacheKey = consts.CacheWeChatKeyPrefix + "accesstoken"
+)
+
+type IWeChatCache interface {
+	GetAccessToken(ctx context.Context) (string, bool, error)
+	SetAccessToken(ctx context.Context, token string, ttl time.Duration) error
+	DelAccessToken(ctx context.Context) error
+}
+
+type WeChatCache struct {
+	cache *redis.Redis
+}
+
+func NewWeChatCache(cfg *config.Config) *WeChatCache {
+	cache := redis.MustNewRedis(*cfg.Redis)
+	return &WeChatCache{cache: cache}
+}
+
+// GetAccessToken 获取缓存的小程序 access_token
+// 返回值：token, isHit, error
+func (c *WeChatCache) GetAccessToken(ctx context.Context) (string, bool, error) {
+	token, err := c.cache.GetCtx(ctx, WeChatAccessTokenCacheKey)
+	if err != nil {
+		return "", false, err
+	}
+	return token, token != "", nil
+}
+
+// SetAccessToken 缓存小程序 access_token
+func (c *WeChatCache) SetAccessToken(ctx context.Context, token string, ttl time.Duration) error {
+	return c.cache.SetexCtx(ctx, WeChatAccessTokenCacheKey, token, int(ttl.Seconds()))
+}
+
+// DelAccessToken 删除失效的 access_token
+func (c *WeChatCache) DelAccessToken(ctx context.Context) error {
+	_, err := c.cache.DelCtx(ctx, WeChatAccessTokenCacheKey)
+	return err
+}
@@ -32,8 +32,47 @@ type Auth struct {
 }
 
 type WeApp struct {
-	AppID     string
-	AppSecret string
+	AppID      string
+	AppSecret  string
+	APIBaseURL string `json:",default=https://api.weixin.qq.com"` // 微信接口地址，测试时可指向本地桩服务
+	Subscribe  Subscribe
+}
+
+// Subscribe 订阅消息配置，模板ID为空时不推送对应消息
+type Subscribe struct {
+	Page              string `json:",optional"`       // 点击消息后跳转的小程序页面
+	MiniProgramState  string `json:",default=formal"` // developer/trial/formal
+	ProposalApproved  SubscribeTemplate
+	ProposalRejected  SubscribeTemplate
+	ProposalNeedsInfo SubscribeTemplate
+}
+
+// SubscribeTemplate 订阅消息模板及其字段名
+type SubscribeTemplate struct {
+	TemplateID string `json:",optional"`
+	TitleKey   string `json:",default=thing1"`  // 提案标题
+	ResultKey  string `json:",default=phrase2"` // 审核结果
+	RemarkKey  string `json:",default=thing3"`  // 备注
+	TimeKey    string `json:",default=time4"`   // 审核时间
+}
+
+// EventBus 领域事件总线配置
+type EventBus struct {
+	Workers   int  `json:",default=4"`    // 异步订阅者的 worker 数量
+	QueueSize int  `json:",default=1024"` // 异步队列长度
+	Outbox    bool `json:",optional"`     // 开启后异步事件先写入 Mongo，进程崩溃后由后台任务补发
+}
+
+// SearchIndex 内存全文索引配置
+type SearchIndex struct {
+	Enabled bool `json:",optional"` // 开启后搜索建议与课程模糊搜索优先使用内存索引，未就绪时回退到 Mongo
+	Fuzzy   bool `json:",optional"` // 开启模糊匹配，允许拼音拼写错误和缺失少量中文片段
+}
+
+// Schedule 课表配置
+type Schedule struct {
+	PeriodTimes    []string          `json:",optional"` // 每节课的起止时间，如 "08:00-08:45"，为空时使用默认作息
+	SemesterStarts map[string]string `json:",optional"` // 学期第一周周一的日期，如 "2024-2025-1": "2024-09-02"，未配置时按默认规则推算
 }
 
 type Config struct {
@@ -48,6 +87,9 @@ type Config struct {
 	Cache         cache.CacheConf
 	Redis         *redis.RedisConf
 	WeApp         WeApp
+	EventBus      EventBus
+	SearchIndex   SearchIndex
+	Schedule      Schedule
 	AdminGrantKey string
 }
 
@@ -29,5 +29,7 @@ type ChangeLog struct {
 	UserID       string    `bson:"userId"                json:"-"`
 	IP           string    `bson:"ip,omitempty"          json:"-"`
 	UserAgent    string    `bson:"userAgent,omitempty"   json:"-"`
+	Before       string    `bson:"before,omitempty"      json:"-"` // 变更前的快照(JSON)
+	After        string    `bson:"after,omitempty"       json:"-"` // 变更后的快照(JSON)
 	UpdatedAt    time.Time `bson:"updatedAt"             json:"-"`
 }
@@ -19,11 +19,12 @@ import (
 )
 
 type Comment struct {
-	ID       string   `bson:"_id,omitempty"    json:"id"`
-	UserID   string   `bson:"userId"           json:"userId"`
-	CourseID string   `bson:"courseId"         json:"courseId"`
-	Content  string   `bson:"content"          json:"content"`
-	Tags     []string `bson:"tags"             json:"tags"`
+	ID         string   `bson:"_id,omitempty"    json:"id"`
+	UserID     string   `bson:"userId"           json:"userId"`
+	CourseID   string   `bson:"courseId"         json:"courseId"`
+	Content    string   `bson:"content"          json:"content"`
+	Tags       []string `bson:"tags"             json:"tags"`
+	OfferingID string   `bson:"offeringId,omitempty" json:"offeringId,omitempty"` // 评论针对的课程开设（学期、教师），可为空
 	// Edited   bool               `bson:"edited"           json:"edited"`
 	Deleted   bool      `bson:"deleted"          json:"-"` // 软删除标记通常不在API中返回
 	CreatedAt time.Time `bson:"createdAt"        json:"createdAt"`
@@ -19,15 +19,18 @@ import (
 )
 
 type Course struct {
-	ID         string    `bson:"_id,omitempty"        json:"id"`
-	Name       string    `bson:"name"                 json:"name"`
-	Code       string    `bson:"code"                 json:"code"`
-	TeacherIDs []string  `bson:"teacherIds"           json:"teacherIds"`
-	Department int32     `bson:"department"           json:"department"`
-	Category   int32     `bson:"category"             json:"category"`
-	Campuses   []int32   `bson:"campuses"             json:"campuses"`
-	CreatedAt  time.Time `bson:"createdAt"            json:"createdAt"`
-	UpdatedAt  time.Time `bson:"updatedAt"            json:"updatedAt"`
-	Deleted    bool      `bson:"deleted"              json:"deleted"`
-	ProposalID string    `bson:"proposalId,omitempty" json:"proposalId,omitempty"` // 来源提案ID，通过提案审批创建时写入
+	ID                string      `bson:"_id,omitempty"        json:"id"`
+	Name              string      `bson:"name"                 json:"name"`
+	Code              string      `bson:"code"                 json:"code"`
+	TeacherIDs        []string    `bson:"teacherIds"           json:"teacherIds"`
+	Department        int32       `bson:"department"           json:"department"`
+	Category          int32       `bson:"category"             json:"category"`
+	Campuses          []int32     `bson:"campuses"             json:"campuses"`
+	CreatedAt         time.Time   `bson:"createdAt"            json:"createdAt"`
+	UpdatedAt         time.Time   `bson:"updatedAt"            json:"updatedAt"`
+	Deleted           bool        `bson:"deleted"              json:"deleted"`
+	ProposalID        string      `bson:"proposalId,omitempty" json:"proposalId,omitempty"`               // 来源提案ID，通过提案审批创建时写入
+	MergedInto        string      `bson:"mergedInto,omitempty" json:"mergedInto,omitempty"`               // 被合并到的课程ID，旧ID据此重定向
+	MergedProposalIDs []string    `bson:"mergedProposalIds,omitempty" json:"mergedProposalIds,omitempty"` // 合并时从重复课程迁移来的来源提案ID
+	SearchKeys        *SearchKeys `bson:"searchKeys,omitempty" json:"-"`                                  // 拼音等搜索键，由 repo 在写入时维护
 }
@@ -0,0 +1,50 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// CourseOffering 课程在某学期的一次开设，记录当学期的授课教师、校区和容量
+// 课程本身（名称、代码、类别）保存在 Course 中，按学期变化的信息保存在这里，互不覆盖
+type CourseOffering struct {
+	ID         string      `bson:"_id,omitempty"      json:"id"`
+	CourseID   string      `bson:"courseId"           json:"courseId"`
+	Semester   string      `bson:"semester"           json:"semester"` // 如 "2024-2025-1"
+	TeacherIDs []string    `bson:"teacherIds"         json:"teacherIds"`
+	Campus     int32       `bson:"campus"             json:"campus"`
+	Capacity   int32       `bson:"capacity,omitempty" json:"capacity,omitempty"` // 0 表示未知
+	Slots      []*TimeSlot `bson:"slots,omitempty"    json:"slots,omitempty"`    // 每周上课时间
+	CreatedAt  time.Time   `bson:"createdAt"          json:"createdAt"`
+	UpdatedAt  time.Time   `bson:"updatedAt"          json:"updatedAt"`
+	Deleted    bool        `bson:"deleted"            json:"deleted"`
+}
+
+// TimeSlot 每周的一个上课时间段，Day 为星期（1 表示周一），节次为闭区间
+type TimeSlot struct {
+	Day         int32        `bson:"day"                json:"day"`
+	StartPeriod int32        `bson:"startPeriod"        json:"startPeriod"`
+	EndPeriod   int32        `bson:"endPeriod"          json:"endPeriod"`
+	Weeks       []*WeekRange `bson:"weeks"              json:"weeks"`
+	Location    string       `bson:"location,omitempty" json:"location,omitempty"`
+}
+
+// WeekRange 上课周次范围（闭区间），Parity 为 0 每周、1 单周、2 双周
+type WeekRange struct {
+	From   int32 `bson:"from"             json:"from"`
+	To     int32 `bson:"to"               json:"to"`
+	Parity int32 `bson:"parity,omitempty" json:"parity,omitempty"`
+}
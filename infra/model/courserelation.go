@@ -0,0 +1,35 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// CourseRelation 两门课程之间的有向关系，等价关系不区分方向，查询时两个方向都要考虑
+type CourseRelation struct {
+	ID           string    `bson:"_id,omitempty"          json:"id"`
+	FromID       string    `bson:"fromId"                 json:"fromId"`
+	ToID         string    `bson:"toId"                   json:"toId"`
+	Type         string    `bson:"type"                   json:"type"`                   // prerequisite/equivalent/followup
+	Status       int32     `bson:"status"                 json:"status"`                 // 1: 待审核，2: 已生效，3: 已拒绝
+	Note         string    `bson:"note,omitempty"         json:"note,omitempty"`         // 提议理由
+	UserID       string    `bson:"userId"                 json:"userId"`                 // 提议或创建的用户ID
+	ReviewerID   string    `bson:"reviewerId,omitempty"   json:"reviewerId,omitempty"`   // 审核的管理员ID
+	RejectReason string    `bson:"rejectReason,omitempty" json:"rejectReason,omitempty"` // 拒绝理由
+	CreatedAt    time.Time `bson:"createdAt"              json:"createdAt"`
+	UpdatedAt    time.Time `bson:"updatedAt"              json:"updatedAt"`
+	Deleted      bool      `bson:"deleted"                json:"deleted"`
+}
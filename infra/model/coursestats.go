@@ -0,0 +1,28 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// CourseStats 课程详情统计，由评论与点赞聚合得到，整体缓存在 Redis 中
+type CourseStats struct {
+	CourseID     string           `json:"courseId"`
+	CommentCount int64            `json:"commentCount"`
+	LikeCount    int64            `json:"likeCount"`    // 课程下全部评论获得的点赞数
+	TagCount     map[string]int64 `json:"tagCount"`     // 全部标签分布
+	MonthlyCount map[string]int64 `json:"monthlyCount"` // "2025-03" -> 评论数
+	TopCommentID string           `json:"topCommentId"` // 点赞最多的评论，没有点赞时为空
+	ComputedAt   time.Time        `json:"computedAt"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// EventOutbox 领域事件的一次异步分发记录，用于进程崩溃后补发
+type EventOutbox struct {
+	ID          string    `bson:"_id,omitempty"  json:"id"`
+	Event       string    `bson:"event"          json:"event"`
+	Subscriber  string    `bson:"subscriber"     json:"subscriber"`
+	Payload     string    `bson:"payload"        json:"payload"`
+	Status      int32     `bson:"status"         json:"status"`
+	Attempts    int32     `bson:"attempts"       json:"attempts"`
+	NextRetryAt time.Time `bson:"nextRetryAt"    json:"nextRetryAt"`
+	LastError   string    `bson:"lastError"      json:"lastError"`
+	CreatedAt   time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
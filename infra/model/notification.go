@@ -0,0 +1,43 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// Notification 站内通知，UserID 为接收者，ActorID 为触发者
+// EventKey 为异步事件的分发键，同一事件补发时据此去重
+type Notification struct {
+	ID        string    `bson:"_id,omitempty"  json:"id"`
+	UserID    string    `bson:"userId"         json:"userId"`
+	ActorID   string    `bson:"actorId"        json:"actorId"`
+	Type      int32     `bson:"type"           json:"type"`
+	TargetID  string    `bson:"targetId"       json:"targetId"`
+	Content   string    `bson:"content"        json:"content"`
+	Read      bool      `bson:"read"           json:"read"`
+	EventKey  string    `bson:"eventKey,omitempty" json:"-"`
+	CreatedAt time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
+
+// NotificationSetting 用户通知偏好，MutedTypes 中的通知类型不会再产生通知
+type NotificationSetting struct {
+	ID         string    `bson:"_id,omitempty"  json:"id"`
+	UserID     string    `bson:"userId"         json:"userId"`
+	MutedTypes []int32   `bson:"mutedTypes"     json:"mutedTypes"`
+	CreatedAt  time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt  time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
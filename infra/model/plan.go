@@ -0,0 +1,29 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// SemesterPlan 用户某学期的选课计划，每个用户每学期一份
+type SemesterPlan struct {
+	ID          string    `bson:"_id,omitempty" json:"id"`
+	UserID      string    `bson:"userId"        json:"userId"`
+	Semester    string    `bson:"semester"      json:"semester"`
+	OfferingIDs []string  `bson:"offeringIds"   json:"offeringIds"` // 按加入顺序
+	CreatedAt   time.Time `bson:"createdAt"     json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt"     json:"updatedAt"`
+}
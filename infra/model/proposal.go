@@ -27,12 +27,23 @@ type Proposal struct {
 	DeletedAt    time.Time       `bson:"deletedAt,omitempty"    json:"deletedAt,omitempty"` // 删除时间
 	Status       int32           `bson:"status"                 json:"status"`              // 提案的状态，1: 待审核，2: 通过，3: 拒绝
 	LikeCnt      int64           `bson:"likeCnt"                json:"likeCnt"`             // 点赞数
-	Course       *ProposalCourse `bson:"course"                 json:"course"`              // 课程信息，包含教师的ID（未创建不需要ID）
+	Course       *ProposalCourse `bson:"course"                 json:"course"`              // 课程信息，包含教师的ID（未创建不需要ID）；修改提案为应用修改后的课程
 	ShowUsername bool            `bson:"showUsername"           json:"showUsername"`        // 是否展示用户名
 	Contribution int64           `bson:"contribution,omitempty" json:"contribution,omitempty"`
 	RejectReason string          `bson:"rejectReason"           json:"rejectReason"`        // 拒绝理由
 	CreatedAt    time.Time       `bson:"createdAt"              json:"createdAt"`
 	UpdatedAt    time.Time       `bson:"updatedAt"              json:"updatedAt"`           // 最近一次的审批时间
+	SearchKeys   *SearchKeys     `bson:"searchKeys,omitempty"   json:"-"`                   // 标题的搜索键
+
+	Type     string                `bson:"type,omitempty"     json:"type,omitempty"`     // 提案类型，空表示新增课程
+	CourseID string                `bson:"courseId,omitempty" json:"courseId,omitempty"` // 修改提案针对的现有课程ID
+	Change   *ProposalCourseChange `bson:"change,omitempty"   json:"change,omitempty"`   // 修改提案提交的字段级修改
+	Before   *ProposalCourse       `bson:"before,omitempty"   json:"before,omitempty"`   // 修改前课程的快照，提交时生成，审批通过时刷新
+
+	TeacherID     string                 `bson:"teacherId,omitempty"     json:"teacherId,omitempty"`     // 教师更正提案针对的教师ID
+	Teacher       *ProposalTeacher       `bson:"teacher,omitempty"       json:"teacher,omitempty"`       // 应用更正后的教师
+	TeacherChange *ProposalTeacherChange `bson:"teacherChange,omitempty" json:"teacherChange,omitempty"` // 教师更正提案提交的字段级修改
+	TeacherBefore *ProposalTeacher       `bson:"teacherBefore,omitempty" json:"teacherBefore,omitempty"` // 更正前教师的快照，提交时生成，审批通过时刷新
 }
 
 type ProposalCourse struct {
@@ -45,6 +56,24 @@ type ProposalCourse struct {
 	Deleted    bool               `bson:"deleted"            json:"deleted"`
 }
 
+// ProposalCourseChange 修改提案对现有课程的字段级修改，空值表示该字段不变
+type ProposalCourseChange struct {
+	Name       *string            `bson:"name,omitempty"       json:"name,omitempty"`
+	Code       *string            `bson:"code,omitempty"       json:"code,omitempty"`
+	Department *string            `bson:"department,omitempty" json:"department,omitempty"`
+	Category   *string            `bson:"category,omitempty"   json:"category,omitempty"`
+	Campuses   []string           `bson:"campuses,omitempty"   json:"campuses,omitempty"`
+	Teachers   []*ProposalTeacher `bson:"teachers,omitempty"   json:"teachers,omitempty"`
+	Retired    bool               `bson:"retired,omitempty"    json:"retired,omitempty"` // 课程不再开设，为 true 时忽略其他字段
+}
+
+// ProposalTeacherChange 教师更正提案的字段级修改，空值表示该字段不变
+type ProposalTeacherChange struct {
+	Name       *string `bson:"name,omitempty"       json:"name,omitempty"`
+	Title      *string `bson:"title,omitempty"      json:"title,omitempty"`
+	Department *string `bson:"department,omitempty" json:"department,omitempty"`
+}
+
 type ProposalTeacher struct {
 	Name       string `bson:"name"                 json:"name"`
 	Department string `bson:"department"           json:"department"`
@@ -0,0 +1,33 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// ProposalComment 提案下的讨论，回复统一挂在顶层评论下
+type ProposalComment struct {
+	ID            string    `bson:"_id,omitempty"           json:"id"`
+	ProposalID    string    `bson:"proposalId"              json:"proposalId"`
+	UserID        string    `bson:"userId"                  json:"userId"`
+	ParentID      string    `bson:"parentId,omitempty"      json:"parentId,omitempty"`      // 所属顶层评论ID，为空表示顶层评论
+	ReplyToUserID string    `bson:"replyToUserId,omitempty" json:"replyToUserId,omitempty"` // 被回复的用户ID
+	Content       string    `bson:"content"                 json:"content"`
+	Internal      bool      `bson:"internal"                json:"internal"` // 管理员内部备注，提案作者及普通用户不可见
+	CreatedAt     time.Time `bson:"createdAt"               json:"createdAt"`
+	UpdatedAt     time.Time `bson:"updatedAt"               json:"updatedAt"`
+	Deleted       bool      `bson:"deleted"                 json:"deleted"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// SubscribeConsent 用户对某类订阅消息的授权，小程序订阅消息为一次性授权，每次同意增加一次可发送额度
+type SubscribeConsent struct {
+	ID        string    `bson:"_id,omitempty"  json:"id"`
+	UserID    string    `bson:"userId"         json:"userId"`
+	Type      int32     `bson:"type"           json:"type"` // 通知类型
+	Quota     int64     `bson:"quota"          json:"quota"`
+	CreatedAt time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
+
+// PushTask 待发送的订阅消息，发送失败后按退避时间重试
+type PushTask struct {
+	ID          string            `bson:"_id,omitempty"  json:"id"`
+	UserID      string            `bson:"userId"         json:"userId"`
+	OpenID      string            `bson:"openId"         json:"openId"`
+	TemplateID  string            `bson:"templateId"     json:"templateId"`
+	Page        string            `bson:"page"           json:"page"`
+	Data        map[string]string `bson:"data"           json:"data"`
+	Status      int32             `bson:"status"         json:"status"`
+	Attempts    int32             `bson:"attempts"       json:"attempts"`
+	NextRetryAt time.Time         `bson:"nextRetryAt"    json:"nextRetryAt"`
+	LastError   string            `bson:"lastError"      json:"lastError"`
+	EventKey    string            `bson:"eventKey,omitempty" json:"-"`
+	CreatedAt   time.Time         `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt   time.Time         `bson:"updatedAt"      json:"updatedAt"`
+}
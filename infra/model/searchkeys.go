@@ -0,0 +1,25 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// SearchKeys 中文名称的搜索键，用于拼音、首字母与规范化名称匹配
+// Grams 和 Suffixes 建有多键索引，关键词查询先经索引定位候选再做精确匹配
+type SearchKeys struct {
+	Pinyin     string   `bson:"pinyin"     json:"pinyin"`     // 以空格分隔的全拼音节，如 "gao deng shu xue"
+	Initials   string   `bson:"initials"   json:"initials"`   // 各音节首字母，如 "gdsx"
+	Normalized string   `bson:"normalized" json:"normalized"` // 小写、全角转半角并去掉空白和标点后的名称
+	Grams      []string `bson:"grams"      json:"grams"`      // 规范化名称的一元和二元切分，如 "高","高等","等",...
+	Suffixes   []string `bson:"suffixes"   json:"suffixes"`   // 从每个音节开始的全拼和首字母后缀，如 "shuxue"、"sx"，用于前缀索引匹配名称中间的拼音
+}
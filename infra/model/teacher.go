@@ -19,10 +19,12 @@ import (
 )
 
 type Teacher struct {
-	ID         string    `bson:"_id,omitempty"  json:"id"`
-	Name       string    `bson:"name"           json:"name"`
-	Title      string    `bson:"title"          json:"title"`
-	Department int32     `bson:"department"     json:"department"`
-	CreatedAt  time.Time `bson:"createdAt"      json:"createdAt"`
-	UpdatedAt  time.Time `bson:"updatedAt"      json:"updatedAt"`
+	ID         string      `bson:"_id,omitempty"  json:"id"`
+	Name       string      `bson:"name"           json:"name"`
+	Title      string      `bson:"title"          json:"title"`
+	Department int32       `bson:"department"     json:"department"`
+	SearchKeys *SearchKeys `bson:"searchKeys,omitempty" json:"-"`                    // 拼音等搜索键，由 repo 在写入时维护
+	MergedInto string      `bson:"mergedInto,omitempty" json:"mergedInto,omitempty"` // 被合并到的教师ID，旧ID据此重定向
+	CreatedAt  time.Time   `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt  time.Time   `bson:"updatedAt"      json:"updatedAt"`
 }
@@ -0,0 +1,29 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// Watch 用户关注课程记录，LastSeenAt 为用户最后一次查看该课程的时间
+type Watch struct {
+	ID         string    `bson:"_id,omitempty"  json:"id"`
+	UserID     string    `bson:"userId"         json:"userId"`
+	CourseID   string    `bson:"courseId"       json:"courseId"`
+	LastSeenAt time.Time `bson:"lastSeenAt"     json:"lastSeenAt"`
+	CreatedAt  time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt  time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// Webhook 管理员配置的事件订阅
+type Webhook struct {
+	ID          string    `bson:"_id,omitempty"  json:"id"`
+	URL         string    `bson:"url"            json:"url"`
+	Secret      string    `bson:"secret"         json:"-"`
+	Events      []string  `bson:"events"         json:"events"` // 订阅的事件类型，"*" 表示全部
+	Description string    `bson:"description"    json:"description"`
+	Active      bool      `bson:"active"         json:"active"`
+	CreatedBy   string    `bson:"createdBy"      json:"createdBy"`
+	Deleted     bool      `bson:"deleted"        json:"-"`
+	CreatedAt   time.Time `bson:"createdAt"      json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt"      json:"updatedAt"`
+}
+
+// WebhookDelivery 一次事件投递，失败后按退避时间重试，重试耗尽进入死信列表
+type WebhookDelivery struct {
+	ID             string    `bson:"_id,omitempty"   json:"id"`
+	WebhookID      string    `bson:"webhookId"       json:"webhookId"`
+	Event          string    `bson:"event"           json:"event"`
+	Payload        string    `bson:"payload"         json:"payload"`
+	Status         int32     `bson:"status"          json:"status"`
+	Attempts       int32     `bson:"attempts"        json:"attempts"`
+	NextRetryAt    time.Time `bson:"nextRetryAt"     json:"nextRetryAt"`
+	LastStatusCode int       `bson:"lastStatusCode"  json:"lastStatusCode"`
+	LastError      string    `bson:"lastError"       json:"lastError"`
+	EventKey       string    `bson:"eventKey,omitempty" json:"-"`
+	CreatedAt      time.Time `bson:"createdAt"       json:"createdAt"`
+	UpdatedAt      time.Time `bson:"updatedAt"       json:"updatedAt"`
+}
+
+// WebhookAttempt 单次投递尝试记录
+type WebhookAttempt struct {
+	ID         string    `bson:"_id,omitempty"  json:"id"`
+	DeliveryID string    `bson:"deliveryId"     json:"deliveryId"`
+	WebhookID  string    `bson:"webhookId"      json:"webhookId"`
+	Event      string    `bson:"event"          json:"event"`
+	Attempt    int32     `bson:"attempt"        json:"attempt"`
+	StatusCode int       `bson:"statusCode"     json:"statusCode"`
+	Error      string    `bson:"error"          json:"error"`
+	DurationMs int64     `bson:"durationMs"     json:"durationMs"`
+	CreatedAt  time.Time `bson:"createdAt"      json:"createdAt"`
+}
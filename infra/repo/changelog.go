@@ -17,6 +17,7 @@ package repo
 import (
 	"context"
 	"errors"
+	"regexp"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
@@ -69,7 +70,7 @@ func (r *ChangeLogRepo) FindManyByTypeOrKeyword(ctx context.Context, targetType
 	}
 
 	if keyword != "" {
-		regex := primitive.Regex{Pattern: keyword, Options: "i"}
+		regex := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
 		filter[consts.Content] = regex
 	}
 
@@ -16,6 +16,8 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
@@ -25,6 +27,7 @@ import (
 	"github.com/zeromicro/go-zero/core/stores/monc"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var _ ICommentRepo = (*CommentRepo)(nil)
@@ -35,11 +38,21 @@ const (
 
 type ICommentRepo interface {
 	Insert(ctx context.Context, c *model.Comment) error
+	FindByID(ctx context.Context, id string) (*model.Comment, error)
 	Count(ctx context.Context) (int64, error)
 	GetTagsByCourseID(ctx context.Context, courseId string) (map[string]int64, error)
+	CountByCourseIDsSince(ctx context.Context, sinceByCourse map[string]time.Time, excludeUserId string) (map[string]int64, error)
+	FindIDsByCourseIDs(ctx context.Context, courseIds []string) ([]string, error)
+	MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error)
+	CountByCourseIDs(ctx context.Context, courseIds []string) (map[string]int64, error)
+	GetTagDistributionByCourseIDs(ctx context.Context, courseIds []string) (map[string]map[string]int64, error)
+	GetCourseStats(ctx context.Context, courseId string) (*model.CourseStats, []string, error)
+	FindCourseIDsByUserID(ctx context.Context, userId string) ([]string, error)
+	GetCourseIDsByIDs(ctx context.Context, ids []string) (map[string]string, error)
 
 	FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string) ([]*model.Comment, int64, error)
 	FindManyByCourseID(ctx context.Context, param *dto.PageParam, courseId string) ([]*model.Comment, int64, error)
+	FindManyByCourseIDs(ctx context.Context, param *dto.PageParam, courseIds []string) ([]*model.Comment, int64, error)
 }
 
 type CommentRepo struct {
@@ -48,6 +61,9 @@ type CommentRepo struct {
 
 func NewCommentRepo(cfg *config.Config) *CommentRepo {
 	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, CommentCollectionName, cfg.Cache)
+	ensureIndexes(conn, CommentCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.CourseID, Value: 1}, {Key: consts.Deleted, Value: 1}, {Key: consts.CreatedAt, Value: -1}}},
+	})
 	return &CommentRepo{conn: conn}
 }
 
@@ -57,6 +73,18 @@ func (r *CommentRepo) Insert(ctx context.Context, c *model.Comment) error {
 	return err
 }
 
+// FindByID 根据ID查询评论
+func (r *CommentRepo) FindByID(ctx context.Context, id string) (*model.Comment, error) {
+	comment := &model.Comment{}
+	if err := r.conn.FindOneNoCache(ctx, comment, bson.M{consts.ID: id}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
 // Count 统计评论总数
 func (r *CommentRepo) Count(ctx context.Context) (int64, error) {
 	return r.conn.CountDocuments(ctx, bson.M{consts.Deleted: bson.M{"$ne": true}})
@@ -100,6 +128,37 @@ func (r *CommentRepo) GetTagsByCourseID(ctx context.Context, courseId string) (m
 	return results, nil
 }
 
+// CountByCourseIDsSince 批量统计多个课程各自在某时间之后新增的评论数，不计入excludeUserId自己发布的评论，返回 课程ID -> 数量
+func (r *CommentRepo) CountByCourseIDsSince(ctx context.Context, sinceByCourse map[string]time.Time, excludeUserId string) (map[string]int64, error) {
+	results := make(map[string]int64, len(sinceByCourse))
+	if len(sinceByCourse) == 0 {
+		return results, nil
+	}
+	conds := make(bson.A, 0, len(sinceByCourse))
+	for courseId, since := range sinceByCourse {
+		conds = append(conds, bson.M{consts.CourseID: courseId, consts.CreatedAt: bson.M{"$gt": since}})
+	}
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{
+			"$or":          conds,
+			consts.UserID:  bson.M{"$ne": excludeUserId},
+			consts.Deleted: bson.M{"$ne": true},
+		}}},
+		{{"$group", bson.M{consts.ID: "$" + consts.CourseID, consts.Count: bson.M{"$sum": 1}}}},
+	}
+	var counts []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := r.conn.Aggregate(ctx, &counts, pipeline); err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		results[c.ID] = c.Count
+	}
+	return results, nil
+}
+
 // FindManyByUserID 根据用户ID分页查询用户所有评论
 func (r *CommentRepo) FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string) ([]*model.Comment, int64, error) {
 	comments := []*model.Comment{}
@@ -131,3 +190,215 @@ func (r *CommentRepo) FindManyByCourseID(ctx context.Context, param *dto.PagePar
 	}
 	return comments, total, nil
 }
+
+// FindManyByCourseIDs 分页查询多个课程下未删除的评论，按发布时间倒序
+func (r *CommentRepo) FindManyByCourseIDs(ctx context.Context, param *dto.PageParam, courseIds []string) ([]*model.Comment, int64, error) {
+	comments := []*model.Comment{}
+	filter := bson.M{consts.CourseID: bson.M{"$in": courseIds}, consts.Deleted: bson.M{"$ne": true}}
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &comments, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// FindIDsByCourseIDs 查询多个课程下所有未删除评论的ID
+func (r *CommentRepo) FindIDsByCourseIDs(ctx context.Context, courseIds []string) ([]string, error) {
+	comments := []*model.Comment{}
+	if err := r.conn.Find(ctx, &comments,
+		bson.M{consts.CourseID: bson.M{"$in": courseIds}, consts.Deleted: bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{consts.ID: 1}),
+	); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
+
+// MoveCourse 将多个课程下的评论（包括已删除的）迁移到目标课程，返回迁移数量
+func (r *CommentRepo) MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error) {
+	res, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.CourseID: bson.M{"$in": fromCourseIds}},
+		bson.M{"$set": bson.M{consts.CourseID: toCourseId}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// ReplaceOffering 将关联到多个开设的评论（包括已删除的）改为关联目标开设，返回修改数量
+func (r *CommentRepo) ReplaceOffering(ctx context.Context, fromOfferingIds []string, toOfferingId string) (int64, error) {
+	res, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.OfferingID: bson.M{"$in": fromOfferingIds}},
+		bson.M{"$set": bson.M{consts.OfferingID: toOfferingId}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CountByCourseIDs 批量统计多个课程的未删除评论数
+func (r *CommentRepo) CountByCourseIDs(ctx context.Context, courseIds []string) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{consts.CourseID: bson.M{"$in": courseIds}, consts.Deleted: bson.M{"$ne": true}}}},
+		{{"$group", bson.M{consts.ID: "$" + consts.CourseID, consts.Count: bson.M{"$sum": 1}}}},
+	}
+	var counts []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := r.conn.Aggregate(ctx, &counts, pipeline); err != nil {
+		return nil, err
+	}
+	results := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		results[c.ID] = c.Count
+	}
+	return results, nil
+}
+
+// GetTagDistributionByCourseIDs 批量统计多个课程的全部标签分布，返回 课程ID -> 标签 -> 次数
+func (r *CommentRepo) GetTagDistributionByCourseIDs(ctx context.Context, courseIds []string) (map[string]map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{
+			consts.CourseID: bson.M{"$in": courseIds},
+			consts.Deleted:  bson.M{"$ne": true},
+			consts.Tags:     bson.M{"$ne": nil},
+		}}},
+		{{"$unwind", "$" + consts.Tags}},
+		{{"$match", bson.M{consts.Tags: bson.M{"$ne": ""}}}},
+		{{"$group", bson.M{
+			consts.ID:    bson.M{"course": "$" + consts.CourseID, "tag": "$" + consts.Tags},
+			consts.Count: bson.M{"$sum": 1},
+		}}},
+	}
+	var tags []struct {
+		ID struct {
+			Course string `bson:"course"`
+			Tag    string `bson:"tag"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := r.conn.Aggregate(ctx, &tags, pipeline); err != nil {
+		return nil, err
+	}
+	results := make(map[string]map[string]int64)
+	for _, t := range tags {
+		if results[t.ID.Course] == nil {
+			results[t.ID.Course] = make(map[string]int64)
+		}
+		results[t.ID.Course][t.ID.Tag] = t.Count
+	}
+	return results, nil
+}
+
+// GetCourseStats 统计课程未删除评论的数量、标签分布和按月评论量，同时返回评论ID用于统计点赞
+// 月份按 consts.StatsTimezone 时区划分
+func (r *CommentRepo) GetCourseStats(ctx context.Context, courseId string) (*model.CourseStats, []string, error) {
+	type bucket struct {
+		ID    any   `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{consts.CourseID: courseId, consts.Deleted: bson.M{"$ne": true}}}},
+		{{"$facet", bson.M{
+			"ids": bson.A{bson.M{"$project": bson.M{consts.ID: 1}}},
+			"tags": bson.A{
+				bson.M{"$unwind": "$" + consts.Tags},
+				bson.M{"$match": bson.M{consts.Tags: bson.M{"$ne": ""}}},
+				bson.M{"$group": bson.M{consts.ID: "$" + consts.Tags, consts.Count: bson.M{"$sum": 1}}},
+			},
+			"months": bson.A{
+				bson.M{"$group": bson.M{
+					consts.ID: bson.M{"$dateToString": bson.M{
+						"format": "%Y-%m", "date": "$" + consts.CreatedAt, "timezone": consts.StatsTimezone,
+					}},
+					consts.Count: bson.M{"$sum": 1},
+				}},
+			},
+		}}},
+	}
+	var results []struct {
+		IDs []struct {
+			ID string `bson:"_id"`
+		} `bson:"ids"`
+		Tags   []bucket `bson:"tags"`
+		Months []bucket `bson:"months"`
+	}
+	if err := r.conn.Aggregate(ctx, &results, pipeline); err != nil {
+		return nil, nil, err
+	}
+
+	stats := &model.CourseStats{
+		CourseID:     courseId,
+		TagCount:     map[string]int64{},
+		MonthlyCount: map[string]int64{},
+		ComputedAt:   time.Now(),
+	}
+	if len(results) == 0 {
+		return stats, nil, nil
+	}
+	res := results[0]
+	ids := make([]string, 0, len(res.IDs))
+	for _, c := range res.IDs {
+		ids = append(ids, c.ID)
+	}
+	stats.CommentCount = int64(len(ids))
+	for _, b := range res.Tags {
+		if tag, ok := b.ID.(string); ok {
+			stats.TagCount[tag] = b.Count
+		}
+	}
+	for _, b := range res.Months {
+		if month, ok := b.ID.(string); ok {
+			stats.MonthlyCount[month] = b.Count
+		}
+	}
+	return stats, ids, nil
+}
+
+// FindCourseIDsByUserID 查询用户评价过的课程ID（去重），按最近评价时间倒序
+func (r *CommentRepo) FindCourseIDsByUserID(ctx context.Context, userId string) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{consts.UserID: userId, consts.Deleted: bson.M{"$ne": true}}}},
+		{{"$group", bson.M{consts.ID: "$" + consts.CourseID, "last": bson.M{"$max": "$" + consts.CreatedAt}}}},
+		{{"$sort", bson.D{{"last", -1}, {consts.ID, 1}}}},
+	}
+	var courses []struct {
+		ID string `bson:"_id"`
+	}
+	if err := r.conn.Aggregate(ctx, &courses, pipeline); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(courses))
+	for i, c := range courses {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
+
+// GetCourseIDsByIDs 批量查询未删除评论所属的课程，返回评论ID -> 课程ID
+func (r *CommentRepo) GetCourseIDsByIDs(ctx context.Context, ids []string) (map[string]string, error) {
+	comments := []*model.Comment{}
+	if err := r.conn.Find(ctx, &comments,
+		bson.M{consts.ID: bson.M{"$in": ids}, consts.Deleted: bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{consts.ID: 1, consts.CourseID: 1}),
+	); err != nil {
+		return nil, err
+	}
+	results := make(map[string]string, len(comments))
+	for _, c := range comments {
+		results[c.ID] = c.CourseID
+	}
+	return results, nil
+}
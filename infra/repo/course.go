@@ -17,16 +17,21 @@ package repo
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/zeromicro/go-zero/core/stores/monc"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var _ ICourseRepo = (*CourseRepo)(nil)
@@ -39,7 +44,8 @@ type ICourseRepo interface {
 	FindByID(ctx context.Context, id string) (*model.Course, error)
 	FindManyByName(ctx context.Context, name string, param *dto.PageParam) ([]*model.Course, int64, error)
 	FindManyByNameLike(ctx context.Context, name string, param *dto.PageParam) ([]*model.Course, int64, error)
-	FindManyByTeacherID(ctx context.Context, teacherId string, param *dto.PageParam) ([]*model.Course, int64, error)
+	FindManyByTeacherIDs(ctx context.Context, teacherIds []string, param *dto.PageParam) ([]*model.Course, int64, error)
+	FindIDsByTeacherID(ctx context.Context, teacherId string) ([]string, error)
 	FindManyByCategoryID(ctx context.Context, categoryId int32, param *dto.PageParam) ([]*model.Course, int64, error)
 	FindManyByDepartmentID(ctx context.Context, departmentId int32, param *dto.PageParam) ([]*model.Course, int64, error)
 
@@ -56,19 +62,54 @@ type ICourseRepo interface {
 	SoftDeleteByID(ctx context.Context, courseID string) error
 	Insert(ctx context.Context, course *model.Course) error
 	UpdateCourse(ctx context.Context, course *model.Course) error
+	MergeInto(ctx context.Context, courseIDs []string, targetID string, proposalIDs []string) error
+	IsMergeTarget(ctx context.Context, courseID string) (bool, error)
+	UnlinkProposal(ctx context.Context, courseID, proposalID string) error
+	CountByTeacherIDs(ctx context.Context, teacherIds []string) (int64, error)
+	ReplaceTeacher(ctx context.Context, fromTeacherIds []string, toTeacherId string) (int64, error)
+	ForEach(ctx context.Context, filter *CourseFilter, fn func(*model.Course) error) error
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Course, error)
+	FindIDsByExactKeyword(ctx context.Context, keyword string, limit int64) ([]string, error)
+	Search(ctx context.Context, filter *CourseFilter, sort string, param *dto.PageParam) ([]*model.Course, int64, error)
+	RebuildSearchKeys(ctx context.Context) (int64, error)
+}
+
+// CourseFilter 课程筛选条件，零值字段不参与筛选，多个条件之间为“且”
+type CourseFilter struct {
+	Keyword      string   // 匹配课程名称、拼音、首字母或代码，相关度排序依据
+	TeacherIDs   []string // 由其中任一教师授课，用于同名教师
+	CategoryID   int32
+	DepartmentID int32
+	CampusIDs    []int32  // 开设在其中任一校区
+	Tags         []string // 课程评论需覆盖全部标签，仅 Search 支持
+	ExtraIDs     []string // 与关键词条件取并集的课程ID，如关键词命中课程的等价课程，仅在 Keyword 非空时生效
 }
 
 type CourseRepo struct {
 	conn *monc.Model
+	changeHooks
 }
 
 // Insert 插入一个新的课程
 func (r *CourseRepo) Insert(ctx context.Context, course *model.Course) error {
-	_, err := r.conn.InsertOneNoCache(ctx, course)
-	return err
+	course.SearchKeys = searchkey.Build(course.Name)
+	if _, err := r.conn.InsertOneNoCache(ctx, course); err != nil {
+		return err
+	}
+	r.notify(ctx, course.ID)
+	return nil
 }
 func NewCourseRepo(cfg *config.Config) *CourseRepo {
 	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, CourseCollectionName, cfg.Cache)
+	ensureIndexes(conn, CourseCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.Name, Value: 1}, {Key: consts.Code, Value: 1}}},
+		{Keys: bson.D{{Key: consts.Department, Value: 1}, {Key: consts.Category, Value: 1}}},
+		{Keys: bson.D{{Key: consts.Category, Value: 1}, {Key: consts.Campuses, Value: 1}}},
+		{Keys: bson.D{{Key: consts.TeacherIDs, Value: 1}}},
+		{Keys: bson.D{{Key: consts.CreatedAt, Value: -1}}},
+		{Keys: bson.D{{Key: consts.Code, Value: 1}}},
+	})
+	ensureIndexes(conn, CourseCollectionName, searchKeyIndexes)
 	return &CourseRepo{conn: conn}
 }
 
@@ -101,25 +142,16 @@ func (r *CourseRepo) FindManyByName(ctx context.Context, name string, param *dto
 	return courses, total, nil
 }
 
-// FindManyByNameLike 根据课程名称分页模糊查询课程
+// FindManyByNameLike 根据课程名称、拼音或首字母分页模糊查询未删除的课程，前缀匹配排在子串匹配之前
 func (r *CourseRepo) FindManyByNameLike(ctx context.Context, name string, param *dto.PageParam) ([]*model.Course, int64, error) {
-	courses := []*model.Course{}
-	filter := bson.M{consts.Name: bson.M{"$regex": primitive.Regex{Pattern: name, Options: "i"}}}
-	if err := r.conn.Find(ctx, &courses, filter, page.FindPageOption(param)); err != nil {
-		return nil, 0, err
-	}
-
-	total, err := r.conn.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
-	}
-	return courses, total, nil
+	return aggregatePage[model.Course](ctx, r.conn,
+		keywordSearchPipeline(bson.M{consts.Deleted: bson.M{"$ne": true}}, consts.Name, name), param)
 }
 
-// FindManyByTeacherID 根据教师ID分页查询其教授的课程
-func (r *CourseRepo) FindManyByTeacherID(ctx context.Context, teacherId string, param *dto.PageParam) ([]*model.Course, int64, error) {
+// FindManyByTeacherIDs 根据教师ID列表分页查询其中任一教师教授的未删除课程
+func (r *CourseRepo) FindManyByTeacherIDs(ctx context.Context, teacherIds []string, param *dto.PageParam) ([]*model.Course, int64, error) {
 	courses := []*model.Course{}
-	filter := bson.M{consts.TeacherIDs: teacherId}
+	filter := bson.M{consts.TeacherIDs: bson.M{"$in": teacherIds}, consts.Deleted: bson.M{"$ne": true}}
 	if err := r.conn.Find(ctx, &courses, filter,
 		page.FindPageOption(param).SetSort(bson.D{
 			{consts.CreatedAt, -1},
@@ -136,10 +168,26 @@ func (r *CourseRepo) FindManyByTeacherID(ctx context.Context, teacherId string,
 	return courses, total, nil
 }
 
-// FindManyByCategoryID 根据课程分类ID分页查询课程
+// FindIDsByTeacherID 查询教师授课的全部未删除课程ID（已合并的课程也已删除），按创建时间倒序
+func (r *CourseRepo) FindIDsByTeacherID(ctx context.Context, teacherId string) ([]string, error) {
+	courses := []*model.Course{}
+	filter := bson.M{consts.TeacherIDs: teacherId, consts.Deleted: bson.M{"$ne": true}}
+	if err := r.conn.Find(ctx, &courses, filter,
+		options.Find().SetProjection(bson.M{consts.ID: 1}).SetSort(bson.D{{consts.CreatedAt, -1}, {consts.ID, 1}}),
+	); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(courses))
+	for _, course := range courses {
+		ids = append(ids, course.ID)
+	}
+	return ids, nil
+}
+
+// FindManyByCategoryID 根据课程分类ID分页查询未删除的课程
 func (r *CourseRepo) FindManyByCategoryID(ctx context.Context, categoryId int32, param *dto.PageParam) ([]*model.Course, int64, error) {
 	courses := []*model.Course{}
-	filter := bson.M{consts.Category: categoryId}
+	filter := bson.M{consts.Category: categoryId, consts.Deleted: bson.M{"$ne": true}}
 	if err := r.conn.Find(ctx, &courses, filter,
 		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
 	); err != nil {
@@ -153,10 +201,10 @@ func (r *CourseRepo) FindManyByCategoryID(ctx context.Context, categoryId int32,
 	return courses, total, nil
 }
 
-// FindManyByDepartmentID 根据开课院系ID分页查询课程
+// FindManyByDepartmentID 根据开课院系ID分页查询未删除的课程
 func (r *CourseRepo) FindManyByDepartmentID(ctx context.Context, departmentId int32, param *dto.PageParam) ([]*model.Course, int64, error) {
 	courses := []*model.Course{}
-	filter := bson.M{consts.Department: departmentId}
+	filter := bson.M{consts.Department: departmentId, consts.Deleted: bson.M{"$ne": true}}
 	if err := r.conn.Find(ctx, &courses, filter,
 		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
 	); err != nil {
@@ -215,27 +263,20 @@ func (r *CourseRepo) GetCampusesByName(ctx context.Context, name string) ([]int3
 	return ids, nil
 }
 
-// GetSuggestionsByName 根据课程名称模糊分页查询课程
+// GetSuggestionsByName 根据课程名称、拼音或首字母模糊分页查询课程，前缀匹配排在子串匹配之前
 func (r *CourseRepo) GetSuggestionsByName(ctx context.Context, name string, param *dto.PageParam) ([]*model.Course, int64, error) {
-	courses := []*model.Course{}
-	filter := bson.M{consts.Name: bson.M{"$regex": primitive.Regex{Pattern: name, Options: "i"}}}
-	
-	if err := r.conn.Find(ctx, &courses, filter, page.FindPageOption(param)); err != nil {
-		return nil, 0, err
-	}
-	
-	total, err := r.conn.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
-	}
-	
-	return courses, total, nil
+	return aggregatePage[model.Course](ctx, r.conn,
+		keywordSearchPipeline(bson.M{consts.Deleted: bson.M{"$ne": true}}, consts.Name, name), param)
 }
 
-// GetSuggestionsByCode 根据课程代码模糊分页查询课程
+// GetSuggestionsByCode 根据课程代码前缀分页查询课程，输入按字面量转义
 func (r *CourseRepo) GetSuggestionsByCode(ctx context.Context, code string, param *dto.PageParam) ([]*model.Course, int64, error) {
 	courses := []*model.Course{}
-	filter := bson.M{consts.Code: bson.M{"$regex": primitive.Regex{Pattern: code, Options: "i"}}}
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > searchkey.MaxQueryLen {
+		return courses, 0, nil
+	}
+	filter := bson.M{consts.Code: bson.M{"$regex": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(code), Options: "i"}}}
 	
 	if err := r.conn.Find(ctx, &courses, filter, page.FindPageOption(param)); err != nil {
 		return nil, 0, err
@@ -284,12 +325,20 @@ func (r *CourseRepo) FindByNameAndCode(ctx context.Context, name, code string) (
 	return courses, nil
 }
 
+// proposalLinkConds 课程关联来源提案的条件：提案创建的课程，或合并时迁移了该提案的保留课程
+func proposalLinkConds(proposalID string) bson.A {
+	return bson.A{
+		bson.M{consts.ProposalID: proposalID},
+		bson.M{consts.MergedProposalIDs: proposalID},
+	}
+}
+
 // FindByProposalID 根据来源提案ID查询未删除的课程，未找到时返回 nil
 func (r *CourseRepo) FindByProposalID(ctx context.Context, proposalID string) (*model.Course, error) {
 	course := &model.Course{}
 	if err := r.conn.FindOneNoCache(ctx, course, bson.M{
-		consts.ProposalID: proposalID,
-		consts.Deleted:    bson.M{"$ne": true},
+		"$or":          proposalLinkConds(proposalID),
+		consts.Deleted: bson.M{"$ne": true},
 	}); err != nil {
 		if errors.Is(err, monc.ErrNotFound) {
 			return nil, nil
@@ -308,14 +357,17 @@ func (r *CourseRepo) SoftDeleteByID(ctx context.Context, courseID string) error
 			consts.UpdatedAt: time.Now(),
 		},
 	}
-	_, err := r.conn.UpdateOneNoCache(ctx, filter, update)
-	return err
+	if _, err := r.conn.UpdateOneNoCache(ctx, filter, update); err != nil {
+		return err
+	}
+	r.notify(ctx, courseID)
+	return nil
 }
 
 // FindByProposalIDIncludeDeleted 根据来源提案ID查询关联的正式课程（包含已软删除的课程），用于审批恢复与贡献值重算
 func (r *CourseRepo) FindByProposalIDIncludeDeleted(ctx context.Context, proposalID string) (*model.Course, error) {
 	course := &model.Course{}
-	if err := r.conn.FindOneNoCache(ctx, course, bson.M{consts.ProposalID: proposalID}); err != nil {
+	if err := r.conn.FindOneNoCache(ctx, course, bson.M{"$or": proposalLinkConds(proposalID)}); err != nil {
 		if errors.Is(err, monc.ErrNotFound) {
 			return nil, nil
 		}
@@ -337,9 +389,300 @@ func (r *CourseRepo) UpdateCourse(ctx context.Context, course *model.Course) err
 			consts.Campuses:   course.Campuses,
 			consts.Deleted:    false,
 			consts.ProposalID: course.ProposalID,
+			consts.SearchKeys: searchkey.Build(course.Name),
 			consts.UpdatedAt:  time.Now(),
 		},
 	}
-	_, err := r.conn.UpdateOneNoCache(ctx, filter, update)
+	if _, err := r.conn.UpdateOneNoCache(ctx, filter, update); err != nil {
+		return err
+	}
+	r.notify(ctx, course.ID)
+	return nil
+}
+
+// MergeInto 将课程软删除并记录合并目标，旧ID通过 mergedInto 重定向
+// 重复课程的来源提案迁移到保留课程的 mergedProposalIds，提案仍能找到对应的正式课程
+func (r *CourseRepo) MergeInto(ctx context.Context, courseIDs []string, targetID string, proposalIDs []string) error {
+	now := time.Now()
+	if len(proposalIDs) > 0 {
+		if _, err := r.conn.UpdateOneNoCache(ctx,
+			bson.M{consts.ID: targetID},
+			bson.M{
+				"$addToSet": bson.M{consts.MergedProposalIDs: bson.M{"$each": proposalIDs}},
+				"$set":      bson.M{consts.UpdatedAt: now},
+			},
+		); err != nil {
+			return err
+		}
+	}
+	_, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.ID: bson.M{"$in": courseIDs}},
+		bson.M{
+			"$set": bson.M{
+				consts.Deleted:    true,
+				consts.MergedInto: targetID,
+				consts.UpdatedAt:  now,
+			},
+			"$unset": bson.M{consts.ProposalID: "", consts.MergedProposalIDs: ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	r.notify(ctx, courseIDs...)
+	return nil
+}
+
+// IsMergeTarget 查询是否有其他课程合并到了该课程
+func (r *CourseRepo) IsMergeTarget(ctx context.Context, courseID string) (bool, error) {
+	cnt, err := r.conn.CountDocuments(ctx, bson.M{consts.MergedInto: courseID})
+	if err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// UnlinkProposal 解除课程与来源提案的关联（包括合并迁移来的来源提案），课程本身保持不变
+func (r *CourseRepo) UnlinkProposal(ctx context.Context, courseID, proposalID string) error {
+	now := time.Now()
+	if _, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: courseID, consts.ProposalID: proposalID},
+		bson.M{"$unset": bson.M{consts.ProposalID: ""}, "$set": bson.M{consts.UpdatedAt: now}},
+	); err != nil {
+		return err
+	}
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: courseID},
+		bson.M{"$pull": bson.M{consts.MergedProposalIDs: proposalID}, "$set": bson.M{consts.UpdatedAt: now}},
+	)
 	return err
 }
+
+// CountByTeacherIDs 统计由其中任一教师授课的课程数量（包括已删除的）
+func (r *CourseRepo) CountByTeacherIDs(ctx context.Context, teacherIds []string) (int64, error) {
+	return r.conn.CountDocuments(ctx, bson.M{consts.TeacherIDs: bson.M{"$in": teacherIds}})
+}
+
+// ReplaceTeacher 将课程（包括已删除的）教师列表中的来源教师替换为目标教师，返回涉及的课程数量
+func (r *CourseRepo) ReplaceTeacher(ctx context.Context, fromTeacherIds []string, toTeacherId string) (int64, error) {
+	var ids []string
+	cur, err := r.conn.Collection.Find(ctx, bson.M{consts.TeacherIDs: bson.M{"$in": fromTeacherIds}},
+		options.Find().SetProjection(bson.M{consts.ID: 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		course := &model.Course{}
+		if err = cur.Decode(course); err != nil {
+			return 0, err
+		}
+		ids = append(ids, course.ID)
+	}
+	if err = cur.Err(); err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	// 同一更新中不能对同一字段既 $addToSet 又 $pull，分两步执行
+	filter := bson.M{consts.ID: bson.M{"$in": ids}}
+	if _, err = r.conn.UpdateManyNoCache(ctx, filter, bson.M{
+		"$addToSet": bson.M{consts.TeacherIDs: toTeacherId},
+		"$set":      bson.M{consts.UpdatedAt: time.Now()},
+	}); err != nil {
+		return 0, err
+	}
+	if _, err = r.conn.UpdateManyNoCache(ctx, filter, bson.M{
+		"$pull": bson.M{consts.TeacherIDs: bson.M{"$in": fromTeacherIds}},
+	}); err != nil {
+		return 0, err
+	}
+	r.notify(ctx, ids...)
+	return int64(len(ids)), nil
+}
+
+// ForEach 使用游标按ID顺序遍历未删除的课程，fn 返回错误时停止遍历
+func (r *CourseRepo) ForEach(ctx context.Context, filter *CourseFilter, fn func(*model.Course) error) error {
+	cur, err := r.conn.Collection.Find(ctx, buildCourseMatch(filter), options.Find().SetSort(bson.M{consts.ID: 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		course := &model.Course{}
+		if err = cur.Decode(course); err != nil {
+			return err
+		}
+		if err = fn(course); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// FindByIDs 根据课程ID列表批量查询未删除的课程，不保证顺序
+func (r *CourseRepo) FindByIDs(ctx context.Context, ids []string) ([]*model.Course, error) {
+	courses := []*model.Course{}
+	filter := bson.M{consts.ID: bson.M{"$in": ids}, consts.Deleted: bson.M{"$ne": true}}
+	if err := r.conn.Find(ctx, &courses, filter); err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// FindIDsByExactKeyword 查询名称与关键词相同或代码与关键词相同（忽略大小写）的未删除课程ID
+func (r *CourseRepo) FindIDsByExactKeyword(ctx context.Context, keyword string, limit int64) ([]string, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return []string{}, nil
+	}
+	code := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(keyword) + "$", Options: "i"}
+	filter := bson.M{
+		"$or":          bson.A{bson.M{consts.Name: keyword}, bson.M{consts.Code: bson.M{"$regex": code}}},
+		consts.Deleted: bson.M{"$ne": true},
+	}
+	courses := []*model.Course{}
+	if err := r.conn.Find(ctx, &courses, filter,
+		options.Find().SetProjection(bson.M{consts.ID: 1}).SetLimit(limit),
+	); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(courses))
+	for _, course := range courses {
+		ids = append(ids, course.ID)
+	}
+	return ids, nil
+}
+
+// Search 按组合条件分页搜索未删除的课程
+// 评论数、口碑排序和标签筛选需要关联评论集合统计，其余条件直接命中课程集合的索引
+func (r *CourseRepo) Search(ctx context.Context, filter *CourseFilter, sort string, param *dto.PageParam) ([]*model.Course, int64, error) {
+	pipeline := mongo.Pipeline{{{"$match", buildCourseMatch(filter)}}}
+
+	if len(filter.Tags) > 0 || sort == consts.CourseSortComments || sort == consts.CourseSortRating {
+		pipeline = append(pipeline, courseStatsStages(sort == consts.CourseSortRating)...)
+		if len(filter.Tags) > 0 {
+			pipeline = append(pipeline, bson.D{{"$match", bson.M{"stats.tags": bson.M{"$all": filter.Tags}}}})
+		}
+	}
+
+	var sortDoc bson.D
+	switch sort {
+	case consts.CourseSortComments:
+		sortDoc = bson.D{{"stats.commentCnt", -1}, {consts.ID, -1}}
+	case consts.CourseSortRating:
+		sortDoc = bson.D{{"stats.likeCnt", -1}, {"stats.commentCnt", -1}, {consts.ID, -1}}
+	case consts.CourseSortNewest:
+		sortDoc = bson.D{{consts.CreatedAt, -1}, {consts.ID, -1}}
+	default:
+		// 相关度：名称完全匹配 > 名称或拼音前缀匹配 > 子串匹配，同分按名称排序
+		if filter.Keyword == "" {
+			sortDoc = bson.D{{consts.ID, 1}}
+			break
+		}
+		pipeline = append(pipeline, bson.D{{"$addFields", bson.M{"relevance": keywordRelevance(consts.Name, filter.Keyword)}}})
+		sortDoc = bson.D{{"relevance", -1}, {consts.Name, 1}, {consts.ID, 1}}
+	}
+
+	pipeline = append(pipeline, bson.D{{"$sort", sortDoc}})
+	return aggregatePage[model.Course](ctx, r.conn, pipeline, param)
+}
+
+// buildCourseMatch 将筛选条件转换为课程集合的查询条件，Tags 需要关联评论，不在此处理
+func buildCourseMatch(filter *CourseFilter) bson.M {
+	query := bson.M{consts.Deleted: bson.M{"$ne": true}}
+	if filter.Keyword != "" {
+		code := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(strings.TrimSpace(filter.Keyword)), Options: "i"}
+		conds := append(keywordConds(filter.Keyword), bson.M{consts.Code: bson.M{"$regex": code}})
+		if len(filter.ExtraIDs) > 0 {
+			conds = append(conds, bson.M{consts.ID: bson.M{"$in": filter.ExtraIDs}})
+		}
+		query["$or"] = conds
+	}
+	if len(filter.TeacherIDs) > 0 {
+		query[consts.TeacherIDs] = bson.M{"$in": filter.TeacherIDs}
+	}
+	if filter.CategoryID != 0 {
+		query[consts.Category] = filter.CategoryID
+	}
+	if filter.DepartmentID != 0 {
+		query[consts.Department] = filter.DepartmentID
+	}
+	if len(filter.CampusIDs) > 0 {
+		query[consts.Campuses] = bson.M{"$in": filter.CampusIDs}
+	}
+	return query
+}
+
+// courseStatsStages 关联评论集合，为每门课程计算 stats{commentCnt, tags}，withLikes 时再按评论关联点赞集合计算 likeCnt
+func courseStatsStages(withLikes bool) []bson.D {
+	comments := bson.A{
+		bson.M{"$match": bson.M{
+			"$expr":        bson.M{"$eq": bson.A{"$" + consts.CourseID, "$$cid"}},
+			consts.Deleted: bson.M{"$ne": true},
+		}},
+	}
+	group := bson.M{
+		consts.ID:    nil,
+		"commentCnt": bson.M{"$sum": 1},
+		"tags":       bson.M{"$push": "$" + consts.Tags},
+	}
+	project := bson.M{
+		"commentCnt": 1,
+		"tags": bson.M{"$reduce": bson.M{
+			"input":        "$tags",
+			"initialValue": bson.A{},
+			"in":           bson.M{"$setUnion": bson.A{"$$value", bson.M{"$ifNull": bson.A{"$$this", bson.A{}}}}},
+		}},
+	}
+	if withLikes {
+		// 点赞挂在评论上，已取消的点赞不计入
+		comments = append(comments, bson.M{"$lookup": bson.M{
+			"from":         LikeCollectionName,
+			"localField":   consts.ID,
+			"foreignField": consts.TargetID,
+			"as":           "likes",
+		}})
+		group["likeCnt"] = bson.M{"$sum": bson.M{"$size": bson.M{"$filter": bson.M{
+			"input": "$likes",
+			"cond":  bson.M{"$ne": bson.A{"$$this." + consts.Active, false}},
+		}}}}
+		project["likeCnt"] = 1
+	}
+	comments = append(comments, bson.M{"$group": group}, bson.M{"$project": project})
+
+	return []bson.D{
+		{{"$lookup", bson.M{
+			"from":     CommentCollectionName,
+			"let":      bson.M{"cid": "$" + consts.ID},
+			"pipeline": comments,
+			"as":       "stats",
+		}}},
+		{{"$addFields", bson.M{"stats": bson.M{"$ifNull": bson.A{
+			bson.M{"$arrayElemAt": bson.A{"$stats", 0}},
+			bson.M{"commentCnt": 0, "likeCnt": 0, "tags": bson.A{}},
+		}}}}},
+	}
+}
+
+// RebuildSearchKeys 重新生成所有课程（包括已删除的）的搜索键，返回更新的数量，用于存量数据回填
+func (r *CourseRepo) RebuildSearchKeys(ctx context.Context) (int64, error) {
+	cur, err := r.conn.Collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{consts.Name: 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var updated int64
+	for cur.Next(ctx) {
+		course := &model.Course{}
+		if err = cur.Decode(course); err != nil {
+			return updated, err
+		}
+		if _, err = r.conn.UpdateOneNoCache(ctx, bson.M{consts.ID: course.ID},
+			bson.M{"$set": bson.M{consts.SearchKeys: searchkey.Build(course.Name)}}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, cur.Err()
+}
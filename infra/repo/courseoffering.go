@@ -0,0 +1,202 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ ICourseOfferingRepo = (*CourseOfferingRepo)(nil)
+
+const (
+	CourseOfferingCollectionName = "courseoffering"
+)
+
+type ICourseOfferingRepo interface {
+	Insert(ctx context.Context, offering *model.CourseOffering) error
+	FindByID(ctx context.Context, id string) (*model.CourseOffering, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*model.CourseOffering, error)
+	FindManyByCourseID(ctx context.Context, courseId, semester string) ([]*model.CourseOffering, error)
+	IsDuplicate(ctx context.Context, offering *model.CourseOffering) (bool, error)
+	CountByCourseID(ctx context.Context, courseId string) (int64, error)
+	Update(ctx context.Context, offering *model.CourseOffering) error
+	SoftDeleteByID(ctx context.Context, id string) error
+	MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error)
+	CountByTeacherIDs(ctx context.Context, teacherIds []string) (int64, error)
+	ReplaceTeacher(ctx context.Context, fromTeacherIds []string, toTeacherId string) (int64, error)
+}
+
+type CourseOfferingRepo struct {
+	conn *monc.Model
+}
+
+func NewCourseOfferingRepo(cfg *config.Config) *CourseOfferingRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, CourseOfferingCollectionName, cfg.Cache)
+	ensureIndexes(conn, CourseOfferingCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.CourseID, Value: 1}, {Key: consts.Semester, Value: -1}}},
+		{Keys: bson.D{{Key: consts.TeacherIDs, Value: 1}, {Key: consts.Semester, Value: -1}}},
+	})
+	return &CourseOfferingRepo{conn: conn}
+}
+
+// Insert 插入一条课程开设
+func (r *CourseOfferingRepo) Insert(ctx context.Context, offering *model.CourseOffering) error {
+	_, err := r.conn.InsertOneNoCache(ctx, offering)
+	return err
+}
+
+// FindByID 根据ID查询课程开设（包含已删除的）
+func (r *CourseOfferingRepo) FindByID(ctx context.Context, id string) (*model.CourseOffering, error) {
+	offering := &model.CourseOffering{}
+	if err := r.conn.FindOneNoCache(ctx, offering, bson.M{consts.ID: id}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return offering, nil
+}
+
+// FindByIDs 根据ID列表批量查询未删除的课程开设，不保证顺序
+func (r *CourseOfferingRepo) FindByIDs(ctx context.Context, ids []string) ([]*model.CourseOffering, error) {
+	offerings := []*model.CourseOffering{}
+	filter := bson.M{consts.ID: bson.M{"$in": ids}, consts.Deleted: bson.M{"$ne": true}}
+	if err := r.conn.Find(ctx, &offerings, filter); err != nil {
+		return nil, err
+	}
+	return offerings, nil
+}
+
+// FindManyByCourseID 查询课程未删除的开设，按学期倒序、校区升序；semester 为空时不按学期筛选
+func (r *CourseOfferingRepo) FindManyByCourseID(ctx context.Context, courseId, semester string) ([]*model.CourseOffering, error) {
+	offerings := []*model.CourseOffering{}
+	filter := bson.M{consts.CourseID: courseId, consts.Deleted: bson.M{"$ne": true}}
+	if semester != "" {
+		filter[consts.Semester] = semester
+	}
+	if err := r.conn.Find(ctx, &offerings, filter,
+		options.Find().SetSort(bson.D{{consts.Semester, -1}, {consts.Campus, 1}, {consts.ID, 1}}),
+	); err != nil {
+		return nil, err
+	}
+	return offerings, nil
+}
+
+// FindManyByCourseIDs 批量查询多门课程未删除的开设，排序同 FindManyByCourseID
+func (r *CourseOfferingRepo) FindManyByCourseIDs(ctx context.Context, courseIds []string) ([]*model.CourseOffering, error) {
+	offerings := []*model.CourseOffering{}
+	if len(courseIds) == 0 {
+		return offerings, nil
+	}
+	filter := bson.M{consts.CourseID: bson.M{"$in": courseIds}, consts.Deleted: bson.M{"$ne": true}}
+	if err := r.conn.Find(ctx, &offerings, filter,
+		options.Find().SetSort(bson.D{{consts.Semester, -1}, {consts.Campus, 1}, {consts.ID, 1}}),
+	); err != nil {
+		return nil, err
+	}
+	return offerings, nil
+}
+
+// IsDuplicate 判断同一课程在同一学期、同一校区是否已有其他未删除的开设
+func (r *CourseOfferingRepo) IsDuplicate(ctx context.Context, offering *model.CourseOffering) (bool, error) {
+	filter := bson.M{
+		consts.CourseID: offering.CourseID,
+		consts.Semester: offering.Semester,
+		consts.Campus:   offering.Campus,
+		consts.Deleted:  bson.M{"$ne": true},
+	}
+	if offering.ID != "" {
+		filter[consts.ID] = bson.M{"$ne": offering.ID}
+	}
+	cnt, err := r.conn.CountDocuments(ctx, filter)
+	return cnt > 0, err
+}
+
+// CountByCourseID 统计课程的开设数（包括已删除的），用于判断是否已迁移
+func (r *CourseOfferingRepo) CountByCourseID(ctx context.Context, courseId string) (int64, error) {
+	return r.conn.CountDocuments(ctx, bson.M{consts.CourseID: courseId})
+}
+
+// Update 更新课程开设的学期、教师、校区、容量和上课时间
+func (r *CourseOfferingRepo) Update(ctx context.Context, offering *model.CourseOffering) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: offering.ID},
+		bson.M{"$set": bson.M{
+			consts.Semester:   offering.Semester,
+			consts.TeacherIDs: offering.TeacherIDs,
+			consts.Campus:     offering.Campus,
+			consts.Capacity:   offering.Capacity,
+			consts.Slots:      offering.Slots,
+			consts.UpdatedAt:  time.Now(),
+		}},
+	)
+	return err
+}
+
+// SoftDeleteByID 软删除课程开设，已关联的评论保留开设ID
+func (r *CourseOfferingRepo) SoftDeleteByID(ctx context.Context, id string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Deleted: true, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MoveCourse 将多个课程的开设（包括已删除的）迁移到目标课程，返回迁移数量
+func (r *CourseOfferingRepo) MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error) {
+	res, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.CourseID: bson.M{"$in": fromCourseIds}},
+		bson.M{"$set": bson.M{consts.CourseID: toCourseId, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CountByTeacherIDs 统计由其中任一教师授课的开设数量（包括已删除的）
+func (r *CourseOfferingRepo) CountByTeacherIDs(ctx context.Context, teacherIds []string) (int64, error) {
+	return r.conn.CountDocuments(ctx, bson.M{consts.TeacherIDs: bson.M{"$in": teacherIds}})
+}
+
+// ReplaceTeacher 将开设（包括已删除的）教师列表中的来源教师替换为目标教师，返回涉及的开设数量
+func (r *CourseOfferingRepo) ReplaceTeacher(ctx context.Context, fromTeacherIds []string, toTeacherId string) (int64, error) {
+	filter := bson.M{consts.TeacherIDs: bson.M{"$in": fromTeacherIds}}
+	res, err := r.conn.UpdateManyNoCache(ctx, filter, bson.M{
+		"$addToSet": bson.M{consts.TeacherIDs: toTeacherId},
+		"$set":      bson.M{consts.UpdatedAt: time.Now()},
+	})
+	if err != nil {
+		return 0, err
+	}
+	// 第一步已加入目标教师，按目标教师定位再移除来源教师
+	if _, err = r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.TeacherIDs: toTeacherId},
+		bson.M{"$pull": bson.M{consts.TeacherIDs: bson.M{"$in": fromTeacherIds}}},
+	); err != nil {
+		return 0, err
+	}
+	return res.MatchedCount, nil
+}
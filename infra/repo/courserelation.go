@@ -0,0 +1,212 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ ICourseRelationRepo = (*CourseRelationRepo)(nil)
+
+const (
+	CourseRelationCollectionName = "courserelation"
+)
+
+type ICourseRelationRepo interface {
+	Insert(ctx context.Context, relation *model.CourseRelation) error
+	FindByID(ctx context.Context, id string) (*model.CourseRelation, error)
+	FindManyByCourseID(ctx context.Context, courseId string, statuses []int32) ([]*model.CourseRelation, error)
+	FindManyByStatus(ctx context.Context, status int32, param *dto.PageParam) ([]*model.CourseRelation, int64, error)
+	FindActive(ctx context.Context, relationType, fromId, toId string) (*model.CourseRelation, error)
+	FindToIDs(ctx context.Context, relationType string, fromIds []string) ([]string, error)
+	FindFromIDs(ctx context.Context, relationType string, toIds []string) ([]string, error)
+	Review(ctx context.Context, id string, status int32, reviewerId, reason string) (bool, error)
+	SoftDeleteByID(ctx context.Context, id string) error
+	MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error)
+}
+
+type CourseRelationRepo struct {
+	conn *monc.Model
+}
+
+func NewCourseRelationRepo(cfg *config.Config) *CourseRelationRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, CourseRelationCollectionName, cfg.Cache)
+	ensureIndexes(conn, CourseRelationCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.FromID, Value: 1}, {Key: consts.Type, Value: 1}, {Key: consts.Status, Value: 1}}},
+		{Keys: bson.D{{Key: consts.ToID, Value: 1}, {Key: consts.Type, Value: 1}, {Key: consts.Status, Value: 1}}},
+		{Keys: bson.D{{Key: consts.Status, Value: 1}, {Key: consts.CreatedAt, Value: -1}}},
+	})
+	return &CourseRelationRepo{conn: conn}
+}
+
+// Insert 插入一条课程关系
+func (r *CourseRelationRepo) Insert(ctx context.Context, relation *model.CourseRelation) error {
+	_, err := r.conn.InsertOneNoCache(ctx, relation)
+	return err
+}
+
+// FindByID 根据ID查询课程关系（包含已删除的），不存在时返回nil
+func (r *CourseRelationRepo) FindByID(ctx context.Context, id string) (*model.CourseRelation, error) {
+	relation := &model.CourseRelation{}
+	if err := r.conn.FindOneNoCache(ctx, relation, bson.M{consts.ID: id}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return relation, nil
+}
+
+// FindManyByCourseID 查询课程作为任一端的未删除关系，按创建时间升序
+func (r *CourseRelationRepo) FindManyByCourseID(ctx context.Context, courseId string, statuses []int32) ([]*model.CourseRelation, error) {
+	relations := []*model.CourseRelation{}
+	filter := bson.M{
+		"$or":          bson.A{bson.M{consts.FromID: courseId}, bson.M{consts.ToID: courseId}},
+		consts.Status:  bson.M{"$in": statuses},
+		consts.Deleted: bson.M{"$ne": true},
+	}
+	if err := r.conn.Find(ctx, &relations, filter, options.Find().SetSort(page.DSort(consts.CreatedAt, 1))); err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// FindManyByStatus 分页查询指定状态的未删除关系，按创建时间倒序
+func (r *CourseRelationRepo) FindManyByStatus(ctx context.Context, status int32, param *dto.PageParam) ([]*model.CourseRelation, int64, error) {
+	relations := []*model.CourseRelation{}
+	filter := bson.M{consts.Status: status, consts.Deleted: bson.M{"$ne": true}}
+
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &relations, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return relations, total, nil
+}
+
+// FindActive 查询从 fromId 到 toId 的指定类型且未被拒绝、未删除的关系，不存在时返回nil
+func (r *CourseRelationRepo) FindActive(ctx context.Context, relationType, fromId, toId string) (*model.CourseRelation, error) {
+	relation := &model.CourseRelation{}
+	filter := bson.M{
+		consts.FromID:  fromId,
+		consts.ToID:    toId,
+		consts.Type:    relationType,
+		consts.Status:  bson.M{"$ne": consts.CourseRelationStatusRejected},
+		consts.Deleted: bson.M{"$ne": true},
+	}
+	if err := r.conn.FindOneNoCache(ctx, relation, filter); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return relation, nil
+}
+
+// FindToIDs 查询从 fromIds 出发的已生效关系指向的课程ID，可能重复
+func (r *CourseRelationRepo) FindToIDs(ctx context.Context, relationType string, fromIds []string) ([]string, error) {
+	return r.findEnds(ctx, relationType, consts.FromID, consts.ToID, fromIds)
+}
+
+// FindFromIDs 查询指向 toIds 的已生效关系的起点课程ID，可能重复
+func (r *CourseRelationRepo) FindFromIDs(ctx context.Context, relationType string, toIds []string) ([]string, error) {
+	return r.findEnds(ctx, relationType, consts.ToID, consts.FromID, toIds)
+}
+
+func (r *CourseRelationRepo) findEnds(ctx context.Context, relationType, byField, endField string, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return []string{}, nil
+	}
+	relations := []*model.CourseRelation{}
+	filter := bson.M{
+		byField:        bson.M{"$in": ids},
+		consts.Type:    relationType,
+		consts.Status:  consts.CourseRelationStatusApproved,
+		consts.Deleted: bson.M{"$ne": true},
+	}
+	if err := r.conn.Find(ctx, &relations, filter,
+		options.Find().SetProjection(bson.M{consts.FromID: 1, consts.ToID: 1}),
+	); err != nil {
+		return nil, err
+	}
+	ends := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		if endField == consts.ToID {
+			ends = append(ends, relation.ToID)
+		} else {
+			ends = append(ends, relation.FromID)
+		}
+	}
+	return ends, nil
+}
+
+// Review 审核待审核的关系，关系已不是待审核状态时返回false
+func (r *CourseRelationRepo) Review(ctx context.Context, id string, status int32, reviewerId, reason string) (bool, error) {
+	res, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id, consts.Status: consts.CourseRelationStatusPending, consts.Deleted: bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			consts.Status:       status,
+			consts.ReviewerID:   reviewerId,
+			consts.RejectReason: reason,
+			consts.UpdatedAt:    time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// SoftDeleteByID 软删除课程关系
+func (r *CourseRelationRepo) SoftDeleteByID(ctx context.Context, id string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Deleted: true, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MoveCourse 将多个课程作为任一端的关系（包括已删除的）改为指向目标课程，返回修改数量
+// 迁移后可能出现两端相同的关系，查询方需要忽略
+func (r *CourseRelationRepo) MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error) {
+	var moved int64
+	for _, field := range []string{consts.FromID, consts.ToID} {
+		res, err := r.conn.UpdateManyNoCache(ctx,
+			bson.M{field: bson.M{"$in": fromCourseIds}},
+			bson.M{"$set": bson.M{field: toCourseId, consts.UpdatedAt: time.Now()}},
+		)
+		if err != nil {
+			return moved, err
+		}
+		moved += res.ModifiedCount
+	}
+	return moved, nil
+}
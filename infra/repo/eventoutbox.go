@@ -0,0 +1,115 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ eventbus.Outbox = (*EventOutboxRepo)(nil)
+
+const (
+	EventOutboxCollectionName = "eventoutbox"
+)
+
+type EventOutboxRepo struct {
+	conn *monc.Model
+}
+
+func NewEventOutboxRepo(cfg *config.Config) *EventOutboxRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, EventOutboxCollectionName, cfg.Cache)
+	return &EventOutboxRepo{conn: conn}
+}
+
+// Save 保存待分发记录，lease 时间内由进程内 worker 处理，不会被 ClaimDue 领取
+func (r *EventOutboxRepo) Save(ctx context.Context, rec *eventbus.Record, lease time.Duration) error {
+	now := time.Now()
+	_, err := r.conn.InsertOneNoCache(ctx, &model.EventOutbox{
+		ID:          rec.ID,
+		Event:       rec.Event,
+		Subscriber:  rec.Subscriber,
+		Payload:     string(rec.Payload),
+		Status:      consts.EventOutboxStatusPending,
+		Attempts:    rec.Attempts,
+		NextRetryAt: now.Add(lease),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	return err
+}
+
+// ClaimDue 领取一条到期的待分发记录，领取时累加尝试次数并顺延下次可领取时间
+// 没有到期记录时返回nil
+func (r *EventOutboxRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*eventbus.Record, error) {
+	o := &model.EventOutbox{}
+	err := r.conn.FindOneAndUpdateNoCache(ctx, o,
+		bson.M{consts.Status: consts.EventOutboxStatusPending, consts.NextRetryAt: bson.M{"$lte": now}},
+		bson.M{
+			"$inc": bson.M{consts.Attempts: 1},
+			"$set": bson.M{consts.NextRetryAt: now.Add(lease), consts.UpdatedAt: now},
+		},
+		options.FindOneAndUpdate().SetSort(bson.M{consts.NextRetryAt: 1}).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &eventbus.Record{
+		ID:         o.ID,
+		Event:      o.Event,
+		Subscriber: o.Subscriber,
+		Payload:    []byte(o.Payload),
+		Attempts:   o.Attempts,
+	}, nil
+}
+
+// MarkDone 标记记录分发成功
+func (r *EventOutboxRepo) MarkDone(ctx context.Context, id string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Status: consts.EventOutboxStatusDone, consts.LastError: "", consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MarkRetry 记录失败原因并设置下次重试时间
+func (r *EventOutboxRepo) MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.NextRetryAt: nextRetryAt, consts.LastError: lastErr, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MarkFailed 标记记录最终失败，不再重试
+func (r *EventOutboxRepo) MarkFailed(ctx context.Context, id string, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Status: consts.EventOutboxStatusFailed, consts.LastError: lastErr, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"sync"
+)
+
+// ChangeHook 文档写入成功后的回调，ids 为内容或删除状态发生变化的文档ID，用于同步内存索引等派生数据
+// 回调在写入请求的协程中同步执行，应避免耗时操作
+type ChangeHook func(ctx context.Context, ids ...string)
+
+// changeHooks 嵌入仓储，提供写入回调的注册与通知
+type changeHooks struct {
+	mu    sync.RWMutex
+	hooks []ChangeHook
+}
+
+// OnChange 注册写入回调
+func (h *changeHooks) OnChange(hook ChangeHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// notify 依次调用已注册的回调
+func (h *changeHooks) notify(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	h.mu.RLock()
+	hooks := h.hooks
+	h.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(ctx, ids...)
+	}
+}
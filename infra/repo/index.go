@@ -0,0 +1,33 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ensureIndexes 在启动时创建集合索引，索引已存在时为空操作；失败只记录日志，不影响服务启动
+func ensureIndexes(conn *monc.Model, collection string, indexes []mongo.IndexModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := conn.Collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		logs.Errorf("[monc] [CreateIndexes] collection: %s, error: %v", collection, err)
+	}
+}
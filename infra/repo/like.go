@@ -41,6 +41,9 @@ type ILikeRepo interface {
 
 	GetLikesByUserIDAndTargets(ctx context.Context, userId string, targetIds []string, targetType int32) (map[string]bool, error)
 	CountByTargets(ctx context.Context, targetIds []string, targetType int32) (map[string]int64, error)
+	FindTargetIDsByUserID(ctx context.Context, userId string, targetType int32, limit int64) ([]string, error)
+	FindUserIDsByTargets(ctx context.Context, targetIds []string, targetType int32, excludeUserId string, limit int64) ([]string, error)
+	FindTargetIDsByUserIDs(ctx context.Context, userIds []string, targetType int32) (map[string][]string, error)
 }
 
 type LikeRepo struct {
@@ -50,6 +53,10 @@ type LikeRepo struct {
 
 func NewLikeRepo(cfg *config.Config) *LikeRepo {
 	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, LikeCollectionName, cfg.Cache)
+	ensureIndexes(conn, LikeCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.UserID, Value: 1}, {Key: consts.TargetType, Value: 1}, {Key: consts.UpdatedAt, Value: -1}}},
+		{Keys: bson.D{{Key: consts.TargetID, Value: 1}, {Key: consts.TargetType, Value: 1}}},
+	})
 	return &LikeRepo{conn: conn}
 }
 
@@ -152,3 +159,77 @@ func (r *LikeRepo) CountByTargets(ctx context.Context, targetIds []string, targe
 	}
 	return results, nil
 }
+
+// FindTargetIDsByUserID 查询用户最近点赞的某类目标ID，按点赞时间倒序，limit <= 0 时不限数量
+func (r *LikeRepo) FindTargetIDsByUserID(ctx context.Context, userId string, targetType int32, limit int64) ([]string, error) {
+	var likes []struct {
+		TargetID string `bson:"targetId"`
+	}
+	opts := options.Find().
+		SetProjection(bson.M{consts.TargetID: 1}).
+		SetSort(bson.D{{consts.UpdatedAt, -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	if err := r.conn.Find(ctx, &likes, bson.M{
+		consts.UserID:     userId,
+		consts.Active:     bson.M{"$ne": false},
+		consts.TargetType: targetType,
+	}, opts); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(likes))
+	for i, like := range likes {
+		ids[i] = like.TargetID
+	}
+	return ids, nil
+}
+
+// FindUserIDsByTargets 查询点赞过任一目标的其他用户，按共同点赞数倒序，最多返回 limit 个
+func (r *LikeRepo) FindUserIDsByTargets(ctx context.Context, targetIds []string, targetType int32, excludeUserId string, limit int64) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{
+			{consts.TargetID, bson.D{{"$in", targetIds}}},
+			{consts.Active, bson.D{{"$ne", false}}},
+			{consts.TargetType, targetType},
+			{consts.UserID, bson.D{{"$ne", excludeUserId}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", "$userId"},
+			{"count", bson.D{{"$sum", 1}}},
+		}}},
+		{{"$sort", bson.D{{"count", -1}, {"_id", 1}}}},
+		{{"$limit", limit}},
+	}
+	var users []struct {
+		ID string `bson:"_id"`
+	}
+	if err := r.conn.Aggregate(ctx, &users, pipeline); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids, nil
+}
+
+// FindTargetIDsByUserIDs 批量查询多个用户点赞的某类目标ID，返回用户id->目标id列表映射
+func (r *LikeRepo) FindTargetIDsByUserIDs(ctx context.Context, userIds []string, targetType int32) (map[string][]string, error) {
+	var likes []struct {
+		UserID   string `bson:"userId"`
+		TargetID string `bson:"targetId"`
+	}
+	if err := r.conn.Find(ctx, &likes, bson.M{
+		consts.UserID:     bson.M{"$in": userIds},
+		consts.Active:     bson.M{"$ne": false},
+		consts.TargetType: targetType,
+	}, options.Find().SetProjection(bson.M{consts.UserID: 1, consts.TargetID: 1})); err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string, len(userIds))
+	for _, like := range likes {
+		results[like.UserID] = append(results[like.UserID], like.TargetID)
+	}
+	return results, nil
+}
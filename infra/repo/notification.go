@@ -0,0 +1,118 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ INotificationRepo = (*NotificationRepo)(nil)
+
+const (
+	NotificationCollectionName = "notification"
+)
+
+type INotificationRepo interface {
+	Insert(ctx context.Context, n *model.Notification) error
+	CountUnreadByUserID(ctx context.Context, userId string) (int64, error)
+	MarkReadByID(ctx context.Context, userId, id string) (bool, error)
+	MarkAllReadByUserID(ctx context.Context, userId string) (int64, error)
+
+	FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string, unreadOnly bool) ([]*model.Notification, int64, error)
+}
+
+type NotificationRepo struct {
+	conn *monc.Model
+}
+
+func NewNotificationRepo(cfg *config.Config) *NotificationRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, NotificationCollectionName, cfg.Cache)
+	ensureIndexes(conn, NotificationCollectionName, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: consts.EventKey, Value: 1}, {Key: consts.UserID, Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{consts.EventKey: bson.M{"$exists": true}}),
+		},
+	})
+	return &NotificationRepo{conn: conn}
+}
+
+// Insert 插入通知，同一事件已向该用户发过通知时视为成功
+func (r *NotificationRepo) Insert(ctx context.Context, n *model.Notification) error {
+	_, err := r.conn.InsertOneNoCache(ctx, n)
+	if n.EventKey != "" && mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// CountUnreadByUserID 统计用户的未读通知数
+func (r *NotificationRepo) CountUnreadByUserID(ctx context.Context, userId string) (int64, error) {
+	return r.conn.CountDocuments(ctx, bson.M{consts.UserID: userId, consts.Read: false})
+}
+
+// MarkReadByID 将用户的一条通知标记为已读，返回通知是否存在
+func (r *NotificationRepo) MarkReadByID(ctx context.Context, userId, id string) (bool, error) {
+	res, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id, consts.UserID: userId},
+		bson.M{"$set": bson.M{consts.Read: true, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// MarkAllReadByUserID 将用户所有未读通知标记为已读，返回被标记的数量
+func (r *NotificationRepo) MarkAllReadByUserID(ctx context.Context, userId string) (int64, error) {
+	res, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.Read: false},
+		bson.M{"$set": bson.M{consts.Read: true, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// FindManyByUserID 根据用户ID分页查询通知，unreadOnly为true时只返回未读通知
+func (r *NotificationRepo) FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string, unreadOnly bool) ([]*model.Notification, int64, error) {
+	notifications := []*model.Notification{}
+	filter := bson.M{consts.UserID: userId}
+	if unreadOnly {
+		filter[consts.Read] = false
+	}
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &notifications, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
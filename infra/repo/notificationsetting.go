@@ -0,0 +1,78 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ INotificationSettingRepo = (*NotificationSettingRepo)(nil)
+
+const (
+	NotificationSettingCollectionName = "notificationsetting"
+)
+
+type INotificationSettingRepo interface {
+	FindByUserID(ctx context.Context, userId string) (*model.NotificationSetting, error)
+	UpsertMutedTypes(ctx context.Context, userId string, mutedTypes []int32) error
+}
+
+type NotificationSettingRepo struct {
+	conn *monc.Model
+}
+
+func NewNotificationSettingRepo(cfg *config.Config) *NotificationSettingRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, NotificationSettingCollectionName, cfg.Cache)
+	return &NotificationSettingRepo{conn: conn}
+}
+
+// FindByUserID 查询用户的通知偏好，未设置过时返回nil
+func (r *NotificationSettingRepo) FindByUserID(ctx context.Context, userId string) (*model.NotificationSetting, error) {
+	setting := &model.NotificationSetting{}
+	if err := r.conn.FindOneNoCache(ctx, setting, bson.M{consts.UserID: userId}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return setting, nil
+}
+
+// UpsertMutedTypes 覆盖用户屏蔽的通知类型
+func (r *NotificationSettingRepo) UpsertMutedTypes(ctx context.Context, userId string, mutedTypes []int32) error {
+	now := time.Now()
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId},
+		bson.M{
+			"$set": bson.M{consts.MutedTypes: mutedTypes, consts.UpdatedAt: now},
+			"$setOnInsert": bson.M{
+				consts.ID:        primitive.NewObjectID().Hex(),
+				consts.CreatedAt: now,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ IPlanRepo = (*PlanRepo)(nil)
+
+const (
+	PlanCollectionName = "plan"
+)
+
+type IPlanRepo interface {
+	FindByUserID(ctx context.Context, userId, semester string) (*model.SemesterPlan, error)
+	AddOffering(ctx context.Context, userId, semester, offeringId string) error
+	RemoveOffering(ctx context.Context, userId, semester, offeringId string) error
+}
+
+type PlanRepo struct {
+	conn *monc.Model
+}
+
+func NewPlanRepo(cfg *config.Config) *PlanRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, PlanCollectionName, cfg.Cache)
+	ensureIndexes(conn, PlanCollectionName, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: consts.UserID, Value: 1}, {Key: consts.Semester, Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	return &PlanRepo{conn: conn}
+}
+
+// FindByUserID 查询用户某学期的选课计划，未创建过时返回nil
+func (r *PlanRepo) FindByUserID(ctx context.Context, userId, semester string) (*model.SemesterPlan, error) {
+	plan := &model.SemesterPlan{}
+	if err := r.conn.FindOneNoCache(ctx, plan, bson.M{consts.UserID: userId, consts.Semester: semester}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return plan, nil
+}
+
+// AddOffering 将开设加入用户的选课计划，计划不存在时自动创建，已加入的不重复添加
+func (r *PlanRepo) AddOffering(ctx context.Context, userId, semester, offeringId string) error {
+	now := time.Now()
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.Semester: semester},
+		bson.M{
+			"$addToSet": bson.M{consts.OfferingIDs: offeringId},
+			"$set":      bson.M{consts.UpdatedAt: now},
+			"$setOnInsert": bson.M{
+				consts.ID:        primitive.NewObjectID().Hex(),
+				consts.CreatedAt: now,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// RemoveOffering 从用户的选课计划中移除开设，计划不存在时不做任何操作
+func (r *PlanRepo) RemoveOffering(ctx context.Context, userId, semester, offeringId string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.Semester: semester},
+		bson.M{
+			"$pull": bson.M{consts.OfferingIDs: offeringId},
+			"$set":  bson.M{consts.UpdatedAt: time.Now()},
+		},
+	)
+	return err
+}
+
+// ReplaceOffering 将选课计划中的来源开设替换为目标开设，已包含目标开设的计划只移除来源开设，返回涉及的计划数量
+func (r *PlanRepo) ReplaceOffering(ctx context.Context, fromOfferingIds []string, toOfferingId string) (int64, error) {
+	filter := bson.M{consts.OfferingIDs: bson.M{"$in": fromOfferingIds}}
+	res, err := r.conn.UpdateManyNoCache(ctx, filter, bson.M{
+		"$addToSet": bson.M{consts.OfferingIDs: toOfferingId},
+		"$set":      bson.M{consts.UpdatedAt: time.Now()},
+	})
+	if err != nil {
+		return 0, err
+	}
+	// 第一步已加入目标开设，按目标开设定位再移除来源开设
+	if _, err = r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.OfferingIDs: toOfferingId},
+		bson.M{"$pull": bson.M{consts.OfferingIDs: bson.M{"$in": fromOfferingIds}}},
+	); err != nil {
+		return 0, err
+	}
+	return res.MatchedCount, nil
+}
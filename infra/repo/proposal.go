@@ -24,10 +24,12 @@ import (
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/zeromicro/go-zero/core/stores/monc"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var _ IProposalRepo = (*ProposalRepo)(nil)
@@ -39,6 +41,7 @@ const (
 type IProposalRepo interface {
 	Insert(ctx context.Context, proposal *model.Proposal) error
 	IsCourseInExistingProposals(ctx context.Context, course *model.ProposalCourse) (bool, error)
+	IsChangePending(ctx context.Context, proposalType, targetID string, openStatuses []int32, excludeID string) (bool, error)
 	FindMany(ctx context.Context, param *dto.PageParam) ([]*model.Proposal, int64, error)
 	FindManyByStatus(ctx context.Context, param *dto.PageParam, status int32) ([]*model.Proposal, int64, error)
 	FindManyByFilter(ctx context.Context, req *dto.FilterProposalReq, statuses []int32) ([]*model.Proposal, int64, error)
@@ -53,21 +56,28 @@ type IProposalRepo interface {
 	IncrementLikeCnt(ctx context.Context, proposalID string, delta int64) error
 	UpdateStatusAndReasonByID(ctx context.Context, proposalID string, statusID int32, rejectReason string) (bool, error)
 	UpdateContributionByID(ctx context.Context, proposalID string, contribution int64) error
+	ForEach(ctx context.Context, fn func(*model.Proposal) error) error
 }
 
 type ProposalRepo struct {
 	conn *monc.Model
+	changeHooks
 }
 
 func NewProposalRepo(cfg *config.Config) *ProposalRepo {
 	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, ProposalCollectionName, cfg.Cache)
+	ensureIndexes(conn, ProposalCollectionName, searchKeyIndexes)
 	return &ProposalRepo{conn: conn}
 }
 
 // Insert 插入一个新的提案
 func (r *ProposalRepo) Insert(ctx context.Context, proposal *model.Proposal) error {
-	_, err := r.conn.InsertOneNoCache(ctx, proposal)
-	return err
+	proposal.SearchKeys = searchkey.Build(proposal.Title)
+	if _, err := r.conn.InsertOneNoCache(ctx, proposal); err != nil {
+		return err
+	}
+	r.notify(ctx, proposal.ID)
+	return nil
 }
 
 // IsCourseInExistingProposals 检查课程是否已经存在于现有提案中
@@ -81,6 +91,7 @@ func (r *ProposalRepo) IsCourseInExistingProposals(ctx context.Context, course *
 		consts.PathCourseCampuses:   course.Campuses,
 		consts.PathCourseTeachers:   course.Teachers,
 		consts.Deleted:              false,
+		consts.Type:                 bson.M{"$ne": consts.ProposalTypeChange},
 	}
 
 	count, err := r.conn.CountDocuments(ctx, filter)
@@ -91,6 +102,29 @@ func (r *ProposalRepo) IsCourseInExistingProposals(ctx context.Context, course *
 	return count > 0, nil
 }
 
+// IsChangePending 检查课程或教师是否已有同类型的未结提案（待审核或待补充信息），excludeID 为更新提案时排除的自身ID
+func (r *ProposalRepo) IsChangePending(ctx context.Context, proposalType, targetID string, openStatuses []int32, excludeID string) (bool, error) {
+	targetField := consts.CourseID
+	if proposalType == consts.ProposalTypeTeacher {
+		targetField = consts.TeacherID
+	}
+	filter := bson.M{
+		consts.Type:    proposalType,
+		targetField:    targetID,
+		consts.Status:  bson.M{"$in": openStatuses},
+		consts.Deleted: bson.M{"$ne": true},
+	}
+	if excludeID != "" {
+		filter[consts.ID] = bson.M{"$ne": excludeID}
+	}
+
+	count, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // FindMany 分页查询所有未删除的提案
 func (r *ProposalRepo) FindMany(ctx context.Context, param *dto.PageParam) ([]*model.Proposal, int64, error) {
 	proposals := []*model.Proposal{}
@@ -227,6 +261,7 @@ func (r *ProposalRepo) DeleteProposal(ctx context.Context, proposalId string, op
 		return err
 	}
 
+	r.notify(ctx, proposalId)
 	return nil
 }
 
@@ -238,41 +273,39 @@ func (r *ProposalRepo) UpdateProposal(ctx context.Context, proposal *model.Propo
 		consts.Deleted: bson.M{"$ne": true},
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"title":          proposal.Title,
-			"content":        proposal.Content,
-			"course":         proposal.Course,
-			consts.UpdatedAt: proposal.UpdatedAt,
-		},
+	set := bson.M{
+		"title":           proposal.Title,
+		"content":         proposal.Content,
+		"course":          proposal.Course,
+		consts.SearchKeys: searchkey.Build(proposal.Title),
+		consts.UpdatedAt:  proposal.UpdatedAt,
 	}
-
-	_, err := r.conn.UpdateOneNoCache(ctx, filter, update)
-	return err
-}
-
-// GetSuggestionsByTitle 根据提案标题模糊分页查询提案
-func (r *ProposalRepo) GetSuggestionsByTitle(ctx context.Context, title string, param *dto.PageParam) ([]*model.Proposal, int64, error) {
-	proposals := []*model.Proposal{}
-	filter := bson.M{
-		"title":        bson.M{"$regex": primitive.Regex{Pattern: title, Options: "i"}},
-		consts.Deleted: bson.M{"$ne": true},
-	}
-	sort := bson.D{
-		{consts.Status, 1},
-		{consts.CreatedAt, -1},
+	// 修改提案与教师更正提案同时更新字段级修改与修改前后的快照
+	switch proposal.Type {
+	case consts.ProposalTypeChange:
+		set[consts.Change] = proposal.Change
+		set[consts.Before] = proposal.Before
+	case consts.ProposalTypeTeacher:
+		set[consts.Teacher] = proposal.Teacher
+		set[consts.TeacherChange] = proposal.TeacherChange
+		set[consts.TeacherBefore] = proposal.TeacherBefore
 	}
 
-	if err := r.conn.Find(ctx, &proposals, filter, page.FindPageOption(param).SetSort(sort)); err != nil {
-		return nil, 0, err
+	if _, err := r.conn.UpdateOneNoCache(ctx, filter, bson.M{"$set": set}); err != nil {
+		return err
 	}
+	r.notify(ctx, proposal.ID)
+	return nil
+}
 
-	total, err := r.conn.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+// GetSuggestionsByTitle 根据提案标题、拼音或首字母模糊分页查询提案，相关度高的排在前面
+func (r *ProposalRepo) GetSuggestionsByTitle(ctx context.Context, title string, param *dto.PageParam) ([]*model.Proposal, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{"$or": keywordConds(title), consts.Deleted: bson.M{"$ne": true}}}},
+		{{"$addFields", bson.M{"relevance": keywordRelevance("title", title)}}},
+		{{"$sort", bson.D{{"relevance", -1}, {consts.Status, 1}, {consts.CreatedAt, -1}, {consts.ID, 1}}}},
 	}
-
-	return proposals, total, nil
+	return aggregatePage[model.Proposal](ctx, r.conn, pipeline, param)
 }
 
 // FindByIDs 根据提案ID列表批量查询提案
@@ -304,6 +337,18 @@ func (r *ProposalRepo) UpdateStatusByID(ctx context.Context, proposalID string,
 	return updated, nil
 }
 
+// UpdateStatusByIDFrom 仅当提案仍处于 fromStatusID 时更新状态，返回是否更新，用于避免覆盖并发的审核结果
+func (r *ProposalRepo) UpdateStatusByIDFrom(ctx context.Context, proposalID string, fromStatusID, statusID int32) (bool, error) {
+	filter := bson.M{consts.ID: proposalID, consts.Status: fromStatusID, consts.Deleted: bson.M{"$ne": true}}
+	update := bson.M{"$set": bson.M{consts.Status: statusID, consts.UpdatedAt: time.Now()}}
+
+	result, err := r.conn.UpdateOneNoCache(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
 // UpdateStatusAndReasonByID 根据提案ID更新提案状态和拒绝理由
 func (r *ProposalRepo) UpdateStatusAndReasonByID(ctx context.Context, proposalID string, statusID int32, rejectReason string) (bool, error) {
 	filter := bson.M{consts.ID: proposalID, consts.Deleted: bson.M{"$ne": true}}
@@ -351,8 +396,11 @@ func (r *ProposalRepo) RestoreProposal(ctx context.Context, proposalId string) e
 	}
 
 	key := fmt.Sprintf("proposal:%s", proposalId)
-	_, err := r.conn.UpdateOne(ctx, key, filter, update)
-	return err
+	if _, err := r.conn.UpdateOne(ctx, key, filter, update); err != nil {
+		return err
+	}
+	r.notify(ctx, proposalId)
+	return nil
 }
 
 func (r *ProposalRepo) IncrementLikeCnt(ctx context.Context, proposalID string, delta int64) error {
@@ -369,3 +417,46 @@ func (r *ProposalRepo) UpdateContributionByID(ctx context.Context, proposalID st
 	_, err := r.conn.UpdateOneNoCache(ctx, filter, update)
 	return err
 }
+
+// ForEach 使用游标按ID顺序遍历未删除的提案，fn 返回错误时停止遍历
+func (r *ProposalRepo) ForEach(ctx context.Context, fn func(*model.Proposal) error) error {
+	cur, err := r.conn.Collection.Find(ctx, bson.M{consts.Deleted: bson.M{"$ne": true}},
+		options.Find().SetSort(bson.M{consts.ID: 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		proposal := &model.Proposal{}
+		if err = cur.Decode(proposal); err != nil {
+			return err
+		}
+		if err = fn(proposal); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// RebuildSearchKeys 重新生成所有提案（包括已删除的）标题的搜索键，返回更新的数量，用于存量数据回填
+func (r *ProposalRepo) RebuildSearchKeys(ctx context.Context) (int64, error) {
+	cur, err := r.conn.Collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"title": 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var updated int64
+	for cur.Next(ctx) {
+		proposal := &model.Proposal{}
+		if err = cur.Decode(proposal); err != nil {
+			return updated, err
+		}
+		if _, err = r.conn.UpdateOneNoCache(ctx, bson.M{consts.ID: proposal.ID},
+			bson.M{"$set": bson.M{consts.SearchKeys: searchkey.Build(proposal.Title)}}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, cur.Err()
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ IProposalCommentRepo = (*ProposalCommentRepo)(nil)
+
+const (
+	ProposalCommentCollectionName = "proposalcomment"
+)
+
+type IProposalCommentRepo interface {
+	Insert(ctx context.Context, comment *model.ProposalComment) error
+	FindByID(ctx context.Context, id string) (*model.ProposalComment, error)
+	FindManyByProposalID(ctx context.Context, proposalId string, includeInternal bool) ([]*model.ProposalComment, error)
+	SoftDeleteByID(ctx context.Context, id string) error
+}
+
+type ProposalCommentRepo struct {
+	conn *monc.Model
+}
+
+func NewProposalCommentRepo(cfg *config.Config) *ProposalCommentRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, ProposalCommentCollectionName, cfg.Cache)
+	ensureIndexes(conn, ProposalCommentCollectionName, []mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.ProposalID, Value: 1}, {Key: consts.CreatedAt, Value: 1}}},
+	})
+	return &ProposalCommentRepo{conn: conn}
+}
+
+// Insert 插入一条提案讨论
+func (r *ProposalCommentRepo) Insert(ctx context.Context, comment *model.ProposalComment) error {
+	_, err := r.conn.InsertOneNoCache(ctx, comment)
+	return err
+}
+
+// FindByID 根据ID查询未删除的提案讨论，不存在时返回nil
+func (r *ProposalCommentRepo) FindByID(ctx context.Context, id string) (*model.ProposalComment, error) {
+	comment := &model.ProposalComment{}
+	filter := bson.M{consts.ID: id, consts.Deleted: bson.M{"$ne": true}}
+	if err := r.conn.FindOneNoCache(ctx, comment, filter); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// FindManyByProposalID 查询提案下未删除的讨论，按创建时间升序，includeInternal 为false时排除内部备注
+func (r *ProposalCommentRepo) FindManyByProposalID(ctx context.Context, proposalId string, includeInternal bool) ([]*model.ProposalComment, error) {
+	comments := []*model.ProposalComment{}
+	filter := bson.M{consts.ProposalID: proposalId, consts.Deleted: bson.M{"$ne": true}}
+	if !includeInternal {
+		filter[consts.Internal] = bson.M{"$ne": true}
+	}
+	if err := r.conn.Find(ctx, &comments, filter, options.Find().SetSort(page.DSort(consts.CreatedAt, 1))); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// SoftDeleteByID 软删除提案讨论
+func (r *ProposalCommentRepo) SoftDeleteByID(ctx context.Context, id string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Deleted: true, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ IPushTaskRepo = (*PushTaskRepo)(nil)
+
+const (
+	PushTaskCollectionName = "pushtask"
+)
+
+type IPushTaskRepo interface {
+	Insert(ctx context.Context, task *model.PushTask) (bool, error)
+	Activate(ctx context.Context, id string) error
+	DeleteByID(ctx context.Context, id string) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.PushTask, error)
+	MarkSent(ctx context.Context, id string) error
+	MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, lastErr string) error
+	MarkFailed(ctx context.Context, id string, lastErr string) error
+}
+
+type PushTaskRepo struct {
+	conn *monc.Model
+}
+
+func NewPushTaskRepo(cfg *config.Config) *PushTaskRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, PushTaskCollectionName, cfg.Cache)
+	ensureIndexes(conn, PushTaskCollectionName, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: consts.EventKey, Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{consts.EventKey: bson.M{"$exists": true}}),
+		},
+	})
+	return &PushTaskRepo{conn: conn}
+}
+
+// Insert 插入推送任务，返回是否插入成功；同一事件已生成过推送任务时返回 false
+func (r *PushTaskRepo) Insert(ctx context.Context, task *model.PushTask) (bool, error) {
+	_, err := r.conn.InsertOneNoCache(ctx, task)
+	if task.EventKey != "" && mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Activate 让任务立即可被领取
+func (r *PushTaskRepo) Activate(ctx context.Context, id string) error {
+	now := time.Now()
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.NextRetryAt: now, consts.UpdatedAt: now}},
+	)
+	return err
+}
+
+// DeleteByID 删除推送任务
+func (r *PushTaskRepo) DeleteByID(ctx context.Context, id string) error {
+	_, err := r.conn.DeleteOneNoCache(ctx, bson.M{consts.ID: id})
+	return err
+}
+
+// ClaimDue 领取一条到期的待发送任务，领取时累加尝试次数并顺延下次可领取时间
+// 没有到期任务时返回nil
+func (r *PushTaskRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.PushTask, error) {
+	task := &model.PushTask{}
+	err := r.conn.FindOneAndUpdateNoCache(ctx, task,
+		bson.M{consts.Status: consts.PushTaskStatusPending, consts.NextRetryAt: bson.M{"$lte": now}},
+		bson.M{
+			"$inc": bson.M{consts.Attempts: 1},
+			"$set": bson.M{consts.NextRetryAt: now.Add(lease), consts.UpdatedAt: now},
+		},
+		options.FindOneAndUpdate().SetSort(bson.M{consts.NextRetryAt: 1}).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// MarkSent 标记任务发送成功
+func (r *PushTaskRepo) MarkSent(ctx context.Context, id string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Status: consts.PushTaskStatusSent, consts.LastError: "", consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MarkRetry 记录失败原因并设置下次重试时间
+func (r *PushTaskRepo) MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.NextRetryAt: nextRetryAt, consts.LastError: lastErr, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
+
+// MarkFailed 标记任务最终失败，不再重试
+func (r *PushTaskRepo) MarkFailed(ctx context.Context, id string, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{consts.Status: consts.PushTaskStatusFailed, consts.LastError: lastErr, consts.UpdatedAt: time.Now()}},
+	)
+	return err
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	searchKeysPinyin     = consts.SearchKeys + ".pinyin"
+	searchKeysInitials   = consts.SearchKeys + ".initials"
+	searchKeysNormalized = consts.SearchKeys + ".normalized"
+	searchKeysGrams      = consts.SearchKeys + ".grams"
+	searchKeysSuffixes   = consts.SearchKeys + ".suffixes"
+)
+
+// searchKeyIndexes 搜索键的多键索引，关键词查询的各个分支都从这两个索引定位候选文档
+var searchKeyIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: searchKeysGrams, Value: 1}}},
+	{Keys: bson.D{{Key: searchKeysSuffixes, Value: 1}}},
+}
+
+// keywordConds 返回按规范化名称和拼音（含首字母）匹配关键词的“或”条件
+// 用户输入只以转义后的字面量进入正则，不生成任何量词或分组，匹配耗时与输入长度线性相关：
+// 名称分支先用二元片段走索引，再在候选上校验连续子串；拼音分支对音节后缀做锚定前缀匹配，同样可走索引。
+// 关键词规范化后为空时返回不匹配任何文档的条件。
+func keywordConds(keyword string) bson.A {
+	var conds bson.A
+	if norm, grams := searchkey.Query(keyword); norm != "" {
+		conds = append(conds, bson.M{
+			searchKeysGrams:      bson.M{"$all": grams},
+			searchKeysNormalized: bson.M{"$regex": regexp.QuoteMeta(norm)},
+		})
+	}
+	if py := searchkey.PinyinQuery(keyword); py != "" {
+		conds = append(conds, bson.M{searchKeysSuffixes: bson.M{"$regex": "^" + regexp.QuoteMeta(py)}})
+	}
+	if len(conds) == 0 {
+		conds = append(conds, bson.M{consts.ID: bson.M{"$in": bson.A{}}})
+	}
+	return conds
+}
+
+// keywordRelevance 返回关键词相关度表达式：field 完全匹配 3，field 或拼音前缀匹配 2，其余子串匹配 1
+func keywordRelevance(field, keyword string) bson.M {
+	prefixes := bson.A{
+		bson.M{"$regexMatch": bson.M{"input": "$" + field, "regex": "^" + regexp.QuoteMeta(keyword), "options": "i"}},
+	}
+	if norm, _ := searchkey.Query(keyword); norm != "" {
+		prefixes = append(prefixes, bson.M{"$regexMatch": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$" + searchKeysNormalized, ""}}, "regex": "^" + regexp.QuoteMeta(norm)}})
+	}
+	if py := searchkey.PinyinQuery(keyword); py != "" {
+		// 从第一个音节开始的全拼或首字母前缀
+		pinyin := bson.M{"$replaceAll": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$" + searchKeysPinyin, ""}}, "find": " ", "replacement": ""}}
+		prefixes = append(prefixes,
+			bson.M{"$regexMatch": bson.M{"input": pinyin, "regex": "^" + regexp.QuoteMeta(py)}},
+			bson.M{"$regexMatch": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$" + searchKeysInitials, ""}}, "regex": "^" + regexp.QuoteMeta(py)}},
+		)
+	}
+	return bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$eq": bson.A{bson.M{"$toLower": "$" + field}, strings.ToLower(keyword)}}, "then": 3},
+			bson.M{"case": bson.M{"$or": prefixes}, "then": 2},
+		},
+		"default": 1,
+	}}
+}
+
+// keywordSearchPipeline 构造按关键词匹配并按相关度排序的管道，同分按 field 排序
+func keywordSearchPipeline(match bson.M, field, keyword string) mongo.Pipeline {
+	match["$or"] = keywordConds(keyword)
+	return mongo.Pipeline{
+		{{"$match", match}},
+		{{"$addFields", bson.M{"relevance": keywordRelevance(field, keyword)}}},
+		{{"$sort", bson.D{{"relevance", -1}, {field, 1}, {consts.ID, 1}}}},
+	}
+}
+
+// aggregatePage 在管道末尾追加分页，返回当前页结果与总数
+func aggregatePage[T any](ctx context.Context, conn *monc.Model, pipeline mongo.Pipeline, param *dto.PageParam) ([]*T, int64, error) {
+	pageNum, pageSize := param.UnWrap()
+	pipeline = append(pipeline, bson.D{{"$facet", bson.M{
+		"items": bson.A{bson.M{"$skip": (pageNum - 1) * pageSize}, bson.M{"$limit": pageSize}},
+		"total": bson.A{bson.M{"$count": consts.Count}},
+	}}})
+
+	var results []struct {
+		Items []*T `bson:"items"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := conn.Aggregate(ctx, &results, pipeline); err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return []*T{}, 0, nil
+	}
+	return results[0].Items, results[0].Total[0].Count, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ ISubscribeConsentRepo = (*SubscribeConsentRepo)(nil)
+
+const (
+	SubscribeConsentCollectionName = "subscribeconsent"
+)
+
+type ISubscribeConsentRepo interface {
+	IncreaseQuota(ctx context.Context, userId string, typeId int32, n int64) error
+	ConsumeQuota(ctx context.Context, userId string, typeId int32) (bool, error)
+	FindManyByUserID(ctx context.Context, userId string) ([]*model.SubscribeConsent, error)
+}
+
+type SubscribeConsentRepo struct {
+	conn *monc.Model
+}
+
+func NewSubscribeConsentRepo(cfg *config.Config) *SubscribeConsentRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, SubscribeConsentCollectionName, cfg.Cache)
+	return &SubscribeConsentRepo{conn: conn}
+}
+
+// IncreaseQuota 增加用户某类订阅消息的可发送次数
+func (r *SubscribeConsentRepo) IncreaseQuota(ctx context.Context, userId string, typeId int32, n int64) error {
+	now := time.Now()
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.Type: typeId},
+		bson.M{
+			"$inc": bson.M{consts.Quota: n},
+			"$set": bson.M{consts.UpdatedAt: now},
+			"$setOnInsert": bson.M{
+				consts.ID:        primitive.NewObjectID().Hex(),
+				consts.CreatedAt: now,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// ConsumeQuota 扣减一次可发送次数，返回是否扣减成功
+func (r *SubscribeConsentRepo) ConsumeQuota(ctx context.Context, userId string, typeId int32) (bool, error) {
+	consent := &model.SubscribeConsent{}
+	err := r.conn.FindOneAndUpdateNoCache(ctx, consent,
+		bson.M{consts.UserID: userId, consts.Type: typeId, consts.Quota: bson.M{"$gt": 0}},
+		bson.M{
+			"$inc": bson.M{consts.Quota: -1},
+			"$set": bson.M{consts.UpdatedAt: time.Now()},
+		},
+	)
+	if err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// FindManyByUserID 查询用户所有订阅消息授权
+func (r *SubscribeConsentRepo) FindManyByUserID(ctx context.Context, userId string) ([]*model.SubscribeConsent, error) {
+	consents := []*model.SubscribeConsent{}
+	if err := r.conn.Find(ctx, &consents, bson.M{consts.UserID: userId}); err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
@@ -17,16 +17,17 @@ package repo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
-	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
-	"github.com/Boyuan-IT-Club/go-kit/logs"
 	"github.com/zeromicro/go-zero/core/stores/monc"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var _ ITeacherRepo = (*TeacherRepo)(nil)
@@ -34,36 +35,41 @@ var _ ITeacherRepo = (*TeacherRepo)(nil)
 const (
 	TeacherCollectionName = "teacher"
 	TeacherID2DBKey       = consts.CacheTeacherKeyPrefix + "id2db:"
-	TeacherName2IDKey     = consts.CacheTeacherKeyPrefix + "name2id:"
 )
 
 type ITeacherRepo interface {
 	Insert(ctx context.Context, teacher *model.Teacher) error
 	IsExistByID(ctx context.Context, id string) (bool, error)
 	FindByID(ctx context.Context, id string) (*model.Teacher, error)
+	FindManyByName(ctx context.Context, name string) ([]*model.Teacher, error)
+	Update(ctx context.Context, teacher *model.Teacher) error
+	MergeInto(ctx context.Context, ids []string, targetId string) error
 
-	GetIDByName(ctx context.Context, name string) (string, error)
 	GetSuggestionsByName(ctx context.Context, name string, param *dto.PageParam) ([]*model.Teacher, int64, error)
+	ForEach(ctx context.Context, fn func(*model.Teacher) error) error
+	RebuildSearchKeys(ctx context.Context) (int64, error)
 }
 
 type TeacherRepo struct {
 	conn *monc.Model
+	changeHooks
 }
 
 func NewTeacherRepo(cfg *config.Config) *TeacherRepo {
 	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, TeacherCollectionName, cfg.Cache)
+	ensureIndexes(conn, TeacherCollectionName, append([]mongo.IndexModel{
+		{Keys: bson.D{{Key: consts.Name, Value: 1}}},
+	}, searchKeyIndexes...))
 	return &TeacherRepo{conn: conn}
 }
 
 // Insert 插入教师
 func (r *TeacherRepo) Insert(ctx context.Context, teacher *model.Teacher) error {
+	teacher.SearchKeys = searchkey.Build(teacher.Name)
 	if _, err := r.conn.InsertOne(ctx, TeacherID2DBKey+teacher.ID, teacher); err != nil {
 		return err
 	}
-	// 设置name->ID映射缓存
-	if err := r.conn.SetCache(TeacherName2IDKey+teacher.Name, teacher.ID); err != nil {
-		logs.CtxWarnf(ctx, "[monc] [SetCache] set name to id cache error: %v", err)
-	}
+	r.notify(ctx, teacher.ID)
 	return nil
 }
 
@@ -85,41 +91,116 @@ func (r *TeacherRepo) FindByID(ctx context.Context, id string) (*model.Teacher,
 	return teacher, nil
 }
 
-// GetIDByName 根据教师名称查询教师ID
-func (r *TeacherRepo) GetIDByName(ctx context.Context, name string) (string, error) {
-	var teacherId string
-	teacher := &model.Teacher{}
-	// 先查name-id缓存
-	if err := r.conn.GetCache(TeacherName2IDKey+name, &teacherId); err == nil && teacherId != "" {
-		return teacherId, nil
+// FindManyByName 查询同名的全部未合并教师，按ID排序，由调用方结合院系、职称消歧
+func (r *TeacherRepo) FindManyByName(ctx context.Context, name string) ([]*model.Teacher, error) {
+	teachers := []*model.Teacher{}
+	if err := r.conn.Find(ctx, &teachers,
+		bson.M{consts.Name: name, consts.MergedInto: bson.M{"$exists": false}},
+		options.Find().SetSort(bson.M{consts.ID: 1}),
+	); err != nil {
+		return nil, err
 	}
-	// 使用NoCache版本，避免使用错误的缓存键
-	if err := r.conn.FindOneNoCache(ctx, teacher, bson.M{consts.Name: name}); err != nil {
-		if errors.Is(err, monc.ErrNotFound) {
-			return "", nil
+	return teachers, nil
+}
+
+// Update 更新教师的名称、职称与院系，同步重建搜索键
+func (r *TeacherRepo) Update(ctx context.Context, teacher *model.Teacher) error {
+	teacher.SearchKeys = searchkey.Build(teacher.Name)
+	if _, err := r.conn.UpdateOne(ctx, TeacherID2DBKey+teacher.ID, bson.M{consts.ID: teacher.ID},
+		bson.M{"$set": bson.M{
+			consts.Name:       teacher.Name,
+			consts.Title:      teacher.Title,
+			consts.Department: teacher.Department,
+			consts.SearchKeys: teacher.SearchKeys,
+			consts.UpdatedAt:  teacher.UpdatedAt,
+		}}); err != nil {
+		return err
+	}
+	r.notify(ctx, teacher.ID)
+	return nil
+}
+
+// MergeInto 记录教师的合并目标，旧ID通过 mergedInto 重定向，不再参与同名匹配与联想
+// 此前已合并到这些教师的教师一并改为指向目标，保证重定向只有一跳
+func (r *TeacherRepo) MergeInto(ctx context.Context, ids []string, targetId string) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{consts.ID: bson.M{"$in": ids}},
+		bson.M{consts.MergedInto: bson.M{"$in": ids}},
+	}}
+	cur, err := r.conn.Collection.Find(ctx, filter, options.Find().SetProjection(bson.M{consts.ID: 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	var merged, keys []string
+	for cur.Next(ctx) {
+		teacher := &model.Teacher{}
+		if err = cur.Decode(teacher); err != nil {
+			return err
 		}
-		return "", err
+		merged = append(merged, teacher.ID)
+		keys = append(keys, TeacherID2DBKey+teacher.ID)
+	}
+	if err = cur.Err(); err != nil || len(merged) == 0 {
+		return err
+	}
+
+	if _, err = r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.ID: bson.M{"$in": merged}},
+		bson.M{"$set": bson.M{consts.MergedInto: targetId, consts.UpdatedAt: time.Now()}},
+	); err != nil {
+		return err
 	}
-	// 回写缓存
-	if err := r.conn.SetCache(TeacherName2IDKey+name, teacher.ID); err != nil {
-		logs.CtxWarnf(ctx, "[monc] [SetCache] set name to id cache error: %v", err)
+	if err = r.conn.DelCache(ctx, keys...); err != nil {
+		return err
 	}
-	return teacher.ID, nil
+	r.notify(ctx, merged...)
+	return nil
 }
 
-// GetSuggestionsByName 根据教师名称模糊分页查询教师
+// GetSuggestionsByName 根据教师名称、拼音或首字母模糊分页查询教师，前缀匹配排在子串匹配之前
 func (r *TeacherRepo) GetSuggestionsByName(ctx context.Context, name string, param *dto.PageParam) ([]*model.Teacher, int64, error) {
-	teachers := []*model.Teacher{}
-	filter := bson.M{consts.Name: bson.M{"$regex": primitive.Regex{Pattern: name, Options: "i"}}}
-	
-	if err := r.conn.Find(ctx, &teachers, filter, page.FindPageOption(param)); err != nil {
-		return nil, 0, err
+	return aggregatePage[model.Teacher](ctx, r.conn, keywordSearchPipeline(bson.M{consts.MergedInto: bson.M{"$exists": false}}, consts.Name, name), param)
+}
+
+// ForEach 使用游标按ID顺序遍历所有教师，fn 返回错误时停止遍历
+func (r *TeacherRepo) ForEach(ctx context.Context, fn func(*model.Teacher) error) error {
+	cur, err := r.conn.Collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{consts.ID: 1}))
+	if err != nil {
+		return err
 	}
-	
-	total, err := r.conn.CountDocuments(ctx, filter)
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		teacher := &model.Teacher{}
+		if err = cur.Decode(teacher); err != nil {
+			return err
+		}
+		if err = fn(teacher); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// RebuildSearchKeys 重新生成所有教师的搜索键并清除教师缓存，返回更新的数量，用于存量数据回填
+func (r *TeacherRepo) RebuildSearchKeys(ctx context.Context) (int64, error) {
+	cur, err := r.conn.Collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{consts.Name: 1}))
 	if err != nil {
-		return nil, 0, err
+		return 0, err
+	}
+	defer cur.Close(ctx)
+
+	var updated int64
+	for cur.Next(ctx) {
+		teacher := &model.Teacher{}
+		if err = cur.Decode(teacher); err != nil {
+			return updated, err
+		}
+		if _, err = r.conn.UpdateOne(ctx, TeacherID2DBKey+teacher.ID, bson.M{consts.ID: teacher.ID},
+			bson.M{"$set": bson.M{consts.SearchKeys: searchkey.Build(teacher.Name)}}); err != nil {
+			return updated, err
+		}
+		updated++
 	}
-	
-	return teachers, total, nil
+	return updated, cur.Err()
 }
@@ -0,0 +1,184 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ IWatchlistRepo = (*WatchlistRepo)(nil)
+
+const (
+	WatchlistCollectionName = "watchlist"
+)
+
+type IWatchlistRepo interface {
+	Upsert(ctx context.Context, userId, courseId string) error
+	Delete(ctx context.Context, userId, courseId string) error
+	IsWatched(ctx context.Context, userId, courseId string) (bool, error)
+	UpdateLastSeenAt(ctx context.Context, userId, courseId string, t time.Time) (bool, error)
+	MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error)
+
+	FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string) ([]*model.Watch, int64, error)
+}
+
+type WatchlistRepo struct {
+	conn *monc.Model
+}
+
+func NewWatchlistRepo(cfg *config.Config) *WatchlistRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, WatchlistCollectionName, cfg.Cache)
+	ensureIndexes(conn, WatchlistCollectionName, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: consts.UserID, Value: 1}, {Key: consts.CourseID, Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	return &WatchlistRepo{conn: conn}
+}
+
+// Upsert 关注课程，已关注时保持原有记录不变；并发关注时唯一索引冲突的一方视为成功
+func (r *WatchlistRepo) Upsert(ctx context.Context, userId, courseId string) error {
+	now := time.Now()
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.CourseID: courseId},
+		bson.M{"$setOnInsert": bson.M{
+			consts.ID:         primitive.NewObjectID().Hex(),
+			consts.LastSeenAt: now,
+			consts.CreatedAt:  now,
+			consts.UpdatedAt:  now,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// Delete 取消关注课程
+func (r *WatchlistRepo) Delete(ctx context.Context, userId, courseId string) error {
+	_, err := r.conn.DeleteOneNoCache(ctx, bson.M{consts.UserID: userId, consts.CourseID: courseId})
+	return err
+}
+
+// IsWatched 判断用户是否关注了某课程
+func (r *WatchlistRepo) IsWatched(ctx context.Context, userId, courseId string) (bool, error) {
+	cnt, err := r.conn.CountDocuments(ctx, bson.M{consts.UserID: userId, consts.CourseID: courseId})
+	return cnt > 0, err
+}
+
+// UpdateLastSeenAt 更新用户最后查看课程的时间，返回是否存在对应的关注记录
+func (r *WatchlistRepo) UpdateLastSeenAt(ctx context.Context, userId, courseId string, t time.Time) (bool, error) {
+	res, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.UserID: userId, consts.CourseID: courseId},
+		bson.M{"$set": bson.M{consts.LastSeenAt: t, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// MoveCourse 将多个课程的关注迁移到目标课程，返回迁移数量
+// 同一用户关注了其中多门课程时只保留一条：优先保留目标课程上的关注，否则保留最早的关注
+func (r *WatchlistRepo) MoveCourse(ctx context.Context, fromCourseIds []string, toCourseId string) (int64, error) {
+	watches := []*model.Watch{}
+	if err := r.conn.Find(ctx, &watches,
+		bson.M{consts.CourseID: bson.M{"$in": append(slices.Clone(fromCourseIds), toCourseId)}},
+		options.Find().SetSort(bson.D{{consts.CreatedAt, 1}, {consts.ID, 1}}),
+	); err != nil {
+		return 0, err
+	}
+
+	kept := make(map[string]*model.Watch, len(watches))
+	for _, w := range watches {
+		if k, ok := kept[w.UserID]; !ok || (w.CourseID == toCourseId && k.CourseID != toCourseId) {
+			kept[w.UserID] = w
+		}
+	}
+	var moveIds, dropIds []string
+	for _, w := range watches {
+		switch {
+		case kept[w.UserID] != w:
+			dropIds = append(dropIds, w.ID)
+		case w.CourseID != toCourseId:
+			moveIds = append(moveIds, w.ID)
+		}
+	}
+
+	if len(dropIds) > 0 {
+		if _, err := r.conn.DeleteMany(ctx, bson.M{consts.ID: bson.M{"$in": dropIds}}); err != nil {
+			return 0, err
+		}
+	}
+	if len(moveIds) == 0 {
+		return 0, nil
+	}
+	res, err := r.conn.UpdateManyNoCache(ctx,
+		bson.M{consts.ID: bson.M{"$in": moveIds}},
+		bson.M{"$set": bson.M{consts.CourseID: toCourseId, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// FindManyByUserID 根据用户ID分页查询关注的未删除课程，最近关注的在前；总数同样不计已删除的课程
+func (r *WatchlistRepo) FindManyByUserID(ctx context.Context, param *dto.PageParam, userId string) ([]*model.Watch, int64, error) {
+	pageNum, pageSize := param.UnWrap()
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{consts.UserID: userId}}},
+		{{"$lookup", bson.M{
+			"from":         CourseCollectionName,
+			"localField":   consts.CourseID,
+			"foreignField": consts.ID,
+			"as":           "course",
+		}}},
+		{{"$match", bson.M{"course": bson.M{"$elemMatch": bson.M{consts.Deleted: bson.M{"$ne": true}}}}}},
+		{{"$project", bson.M{"course": 0}}},
+		{{"$sort", bson.D{{consts.CreatedAt, -1}, {consts.ID, -1}}}},
+		{{"$facet", bson.M{
+			"items": bson.A{bson.M{"$skip": (pageNum - 1) * pageSize}, bson.M{"$limit": pageSize}},
+			"total": bson.A{bson.M{"$count": consts.Count}},
+		}}},
+	}
+	var results []struct {
+		Items []*model.Watch `bson:"items"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := r.conn.Aggregate(ctx, &results, pipeline); err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return []*model.Watch{}, 0, nil
+	}
+	return results[0].Items, results[0].Total[0].Count, nil
+}
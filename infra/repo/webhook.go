@@ -0,0 +1,128 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var _ IWebhookRepo = (*WebhookRepo)(nil)
+
+const (
+	WebhookCollectionName = "webhook"
+)
+
+type IWebhookRepo interface {
+	Insert(ctx context.Context, w *model.Webhook) error
+	FindByID(ctx context.Context, id string) (*model.Webhook, error)
+	Update(ctx context.Context, w *model.Webhook) error
+	SoftDeleteByID(ctx context.Context, id string) (bool, error)
+
+	FindMany(ctx context.Context, param *dto.PageParam) ([]*model.Webhook, int64, error)
+	FindActiveByEvent(ctx context.Context, event string) ([]*model.Webhook, error)
+}
+
+type WebhookRepo struct {
+	conn *monc.Model
+}
+
+func NewWebhookRepo(cfg *config.Config) *WebhookRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, WebhookCollectionName, cfg.Cache)
+	return &WebhookRepo{conn: conn}
+}
+
+// Insert 插入webhook
+func (r *WebhookRepo) Insert(ctx context.Context, w *model.Webhook) error {
+	_, err := r.conn.InsertOneNoCache(ctx, w)
+	return err
+}
+
+// FindByID 根据ID查询未删除的webhook
+func (r *WebhookRepo) FindByID(ctx context.Context, id string) (*model.Webhook, error) {
+	w := &model.Webhook{}
+	if err := r.conn.FindOneNoCache(ctx, w, bson.M{consts.ID: id, consts.Deleted: bson.M{"$ne": true}}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// Update 更新webhook的可编辑字段
+func (r *WebhookRepo) Update(ctx context.Context, w *model.Webhook) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: w.ID},
+		bson.M{"$set": bson.M{
+			consts.URL:         w.URL,
+			consts.Events:      w.Events,
+			consts.Description: w.Description,
+			consts.Active:      w.Active,
+			consts.UpdatedAt:   time.Now(),
+		}},
+	)
+	return err
+}
+
+// SoftDeleteByID 软删除webhook
+func (r *WebhookRepo) SoftDeleteByID(ctx context.Context, id string) (bool, error) {
+	res, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id, consts.Deleted: bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{consts.Deleted: true, consts.Active: false, consts.UpdatedAt: time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// FindMany 分页查询未删除的webhook
+func (r *WebhookRepo) FindMany(ctx context.Context, param *dto.PageParam) ([]*model.Webhook, int64, error) {
+	webhooks := []*model.Webhook{}
+	filter := bson.M{consts.Deleted: bson.M{"$ne": true}}
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &webhooks, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return webhooks, total, nil
+}
+
+// FindActiveByEvent 查询订阅了某事件的启用中的webhook
+func (r *WebhookRepo) FindActiveByEvent(ctx context.Context, event string) ([]*model.Webhook, error) {
+	webhooks := []*model.Webhook{}
+	if err := r.conn.Find(ctx, &webhooks, bson.M{
+		consts.Active:  true,
+		consts.Deleted: bson.M{"$ne": true},
+		consts.Events:  bson.M{"$in": []string{event, consts.WebhookEventAll}},
+	}); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
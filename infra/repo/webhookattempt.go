@@ -0,0 +1,75 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var _ IWebhookAttemptRepo = (*WebhookAttemptRepo)(nil)
+
+const (
+	WebhookAttemptCollectionName = "webhookattempt"
+)
+
+type IWebhookAttemptRepo interface {
+	Insert(ctx context.Context, a *model.WebhookAttempt) error
+	FindMany(ctx context.Context, param *dto.PageParam, webhookId, deliveryId string) ([]*model.WebhookAttempt, int64, error)
+}
+
+type WebhookAttemptRepo struct {
+	conn *monc.Model
+}
+
+func NewWebhookAttemptRepo(cfg *config.Config) *WebhookAttemptRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, WebhookAttemptCollectionName, cfg.Cache)
+	return &WebhookAttemptRepo{conn: conn}
+}
+
+// Insert 插入投递尝试记录
+func (r *WebhookAttemptRepo) Insert(ctx context.Context, a *model.WebhookAttempt) error {
+	_, err := r.conn.InsertOneNoCache(ctx, a)
+	return err
+}
+
+// FindMany 分页查询投递尝试记录，webhookId、deliveryId为空时不按该条件过滤
+func (r *WebhookAttemptRepo) FindMany(ctx context.Context, param *dto.PageParam, webhookId, deliveryId string) ([]*model.WebhookAttempt, int64, error) {
+	attempts := []*model.WebhookAttempt{}
+	filter := bson.M{}
+	if webhookId != "" {
+		filter[consts.WebhookID] = webhookId
+	}
+	if deliveryId != "" {
+		filter[consts.DeliveryID] = deliveryId
+	}
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &attempts, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
+}
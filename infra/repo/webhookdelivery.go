@@ -0,0 +1,196 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/application/dto"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/page"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+	"github.com/zeromicro/go-zero/core/stores/monc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ IWebhookDeliveryRepo = (*WebhookDeliveryRepo)(nil)
+
+const (
+	WebhookDeliveryCollectionName = "webhookdelivery"
+)
+
+type IWebhookDeliveryRepo interface {
+	InsertMany(ctx context.Context, deliveries []*model.WebhookDelivery) error
+	FindByID(ctx context.Context, id string) (*model.WebhookDelivery, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	MarkSucceeded(ctx context.Context, id string, statusCode int) error
+	MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, statusCode int, lastErr string) error
+	MarkDead(ctx context.Context, id string, statusCode int, lastErr string) error
+	Requeue(ctx context.Context, id string) (bool, error)
+
+	FindMany(ctx context.Context, param *dto.PageParam, webhookId string, status int32) ([]*model.WebhookDelivery, int64, error)
+}
+
+type WebhookDeliveryRepo struct {
+	conn *monc.Model
+}
+
+func NewWebhookDeliveryRepo(cfg *config.Config) *WebhookDeliveryRepo {
+	conn := monc.MustNewModel(cfg.Mongo.URL, cfg.Mongo.DB, WebhookDeliveryCollectionName, cfg.Cache)
+	ensureIndexes(conn, WebhookDeliveryCollectionName, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: consts.EventKey, Value: 1}, {Key: consts.WebhookID, Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{consts.EventKey: bson.M{"$exists": true}}),
+		},
+	})
+	return &WebhookDeliveryRepo{conn: conn}
+}
+
+// InsertMany 批量插入投递任务，同一事件已生成过的投递会被跳过，其余照常插入
+func (r *WebhookDeliveryRepo) InsertMany(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	docs := make([]any, len(deliveries))
+	for i, d := range deliveries {
+		docs[i] = d
+	}
+	_, err := r.conn.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// FindByID 根据ID查询投递任务
+func (r *WebhookDeliveryRepo) FindByID(ctx context.Context, id string) (*model.WebhookDelivery, error) {
+	d := &model.WebhookDelivery{}
+	if err := r.conn.FindOneNoCache(ctx, d, bson.M{consts.ID: id}); err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// ClaimDue 领取一条到期的待投递任务，领取时累加尝试次数并顺延下次可领取时间
+// 没有到期任务时返回nil
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	d := &model.WebhookDelivery{}
+	err := r.conn.FindOneAndUpdateNoCache(ctx, d,
+		bson.M{consts.Status: consts.WebhookDeliveryStatusPending, consts.NextRetryAt: bson.M{"$lte": now}},
+		bson.M{
+			"$inc": bson.M{consts.Attempts: 1},
+			"$set": bson.M{consts.NextRetryAt: now.Add(lease), consts.UpdatedAt: now},
+		},
+		options.FindOneAndUpdate().SetSort(bson.M{consts.NextRetryAt: 1}).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, monc.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// MarkSucceeded 标记投递成功
+func (r *WebhookDeliveryRepo) MarkSucceeded(ctx context.Context, id string, statusCode int) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{
+			consts.Status:         consts.WebhookDeliveryStatusSucceeded,
+			consts.LastStatusCode: statusCode,
+			consts.LastError:      "",
+			consts.UpdatedAt:      time.Now(),
+		}},
+	)
+	return err
+}
+
+// MarkRetry 记录失败原因并设置下次重试时间
+func (r *WebhookDeliveryRepo) MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, statusCode int, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{
+			consts.NextRetryAt:    nextRetryAt,
+			consts.LastStatusCode: statusCode,
+			consts.LastError:      lastErr,
+			consts.UpdatedAt:      time.Now(),
+		}},
+	)
+	return err
+}
+
+// MarkDead 重试耗尽，移入死信列表
+func (r *WebhookDeliveryRepo) MarkDead(ctx context.Context, id string, statusCode int, lastErr string) error {
+	_, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id},
+		bson.M{"$set": bson.M{
+			consts.Status:         consts.WebhookDeliveryStatusDead,
+			consts.LastStatusCode: statusCode,
+			consts.LastError:      lastErr,
+			consts.UpdatedAt:      time.Now(),
+		}},
+	)
+	return err
+}
+
+// Requeue 将死信任务重新放回投递队列并清零尝试次数，返回任务是否存在于死信列表
+func (r *WebhookDeliveryRepo) Requeue(ctx context.Context, id string) (bool, error) {
+	now := time.Now()
+	res, err := r.conn.UpdateOneNoCache(ctx,
+		bson.M{consts.ID: id, consts.Status: consts.WebhookDeliveryStatusDead},
+		bson.M{"$set": bson.M{
+			consts.Status:      consts.WebhookDeliveryStatusPending,
+			consts.Attempts:    0,
+			consts.NextRetryAt: now,
+			consts.UpdatedAt:   now,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// FindMany 分页查询投递任务，webhookId为空或status为0时不按该条件过滤
+func (r *WebhookDeliveryRepo) FindMany(ctx context.Context, param *dto.PageParam, webhookId string, status int32) ([]*model.WebhookDelivery, int64, error) {
+	deliveries := []*model.WebhookDelivery{}
+	filter := bson.M{}
+	if webhookId != "" {
+		filter[consts.WebhookID] = webhookId
+	}
+	if status > 0 {
+		filter[consts.Status] = status
+	}
+	total, err := r.conn.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = r.conn.Find(ctx, &deliveries, filter,
+		page.FindPageOption(param).SetSort(page.DSort(consts.CreatedAt, -1)),
+	); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
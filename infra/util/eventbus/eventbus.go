@@ -0,0 +1,346 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbus 进程内领域事件总线
+//
+// 订阅者可选择同步或异步分发：同步订阅者在 Publish 中依次执行，错误随 Publish 返回；
+// 异步订阅者由后台 worker 执行。配置 Outbox 后，异步分发前先按订阅者写入持久化记录，
+// 执行成功后标记完成，失败或进程崩溃遗留的记录由 StartRelay 按退避时间补发，
+// 因此异步订阅者需要保证幂等：同一条记录的每次分发通过 DispatchKey 得到相同的键，可用于去重。
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Boyuan-IT-Club/go-kit/logs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event 领域事件，EventName 需使用值接收者实现
+type Event interface {
+	EventName() string
+}
+
+// Mode 分发方式
+type Mode int
+
+const (
+	Sync  Mode = iota // 在 Publish 中同步执行
+	Async             // 由后台 worker 异步执行
+)
+
+// Record 持久化到 outbox 的一次异步分发
+type Record struct {
+	ID         string
+	Event      string
+	Subscriber string
+	Payload    []byte
+	Attempts   int32
+}
+
+// Outbox 异步分发的持久化存储
+type Outbox interface {
+	// Save 保存待分发记录，记录在 lease 时间内不会被 ClaimDue 领取
+	Save(ctx context.Context, rec *Record, lease time.Duration) error
+	// ClaimDue 领取一条到期记录并增加尝试次数，没有时返回 nil
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*Record, error)
+	MarkDone(ctx context.Context, id string) error
+	MarkRetry(ctx context.Context, id string, nextRetryAt time.Time, lastErr string) error
+	MarkFailed(ctx context.Context, id string, lastErr string) error
+}
+
+// Options 总线配置
+type Options struct {
+	Workers           int           // 异步 worker 数量
+	QueueSize         int           // 异步队列长度
+	Outbox            Outbox        // 为空时异步事件仅保存在内存中
+	Lease             time.Duration // outbox 记录被领取后的占用时长
+	MaxAttempts       int32         // outbox 记录最大尝试次数
+	RetryBaseInterval time.Duration // 第n次失败后等待 base*2^(n-1)
+	RelayInterval     time.Duration // 补发任务的轮询间隔
+}
+
+type subscriber struct {
+	name    string
+	mode    Mode
+	handler func(ctx context.Context, e Event) error
+}
+
+type job struct {
+	recordID string
+	sub      *subscriber
+	event    Event
+}
+
+type dispatchKeyCtx struct{}
+
+// DispatchKey 返回当前异步分发对应的 outbox 记录ID，同一记录补发时不变；
+// 同步分发或未配置 outbox 时不会重复分发，返回空字符串
+func DispatchKey(ctx context.Context) string {
+	key, _ := ctx.Value(dispatchKeyCtx{}).(string)
+	return key
+}
+
+func withDispatchKey(ctx context.Context, recordID string) context.Context {
+	if recordID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, dispatchKeyCtx{}, recordID)
+}
+
+// Bus 事件总线
+type Bus struct {
+	opts  Options
+	mu    sync.RWMutex
+	subs  map[string][]*subscriber
+	types map[string]reflect.Type
+	queue chan *job
+}
+
+// New 创建事件总线并启动异步 worker
+func New(opts Options) *Bus {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1024
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Minute
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.RetryBaseInterval <= 0 {
+		opts.RetryBaseInterval = 30 * time.Second
+	}
+	if opts.RelayInterval <= 0 {
+		opts.RelayInterval = 10 * time.Second
+	}
+	b := &Bus{
+		opts:  opts,
+		subs:  make(map[string][]*subscriber),
+		types: make(map[string]reflect.Type),
+		queue: make(chan *job, opts.QueueSize),
+	}
+	for i := 0; i < opts.Workers; i++ {
+		go b.work()
+	}
+	return b
+}
+
+// Subscribe 订阅事件类型 T，name 在同一事件的订阅者中唯一，用于 outbox 记录补发
+func Subscribe[T Event](b *Bus, name string, mode Mode, handler func(ctx context.Context, e T) error) {
+	var zero T
+	eventName := zero.EventName()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.types[eventName] = reflect.TypeOf(zero)
+	b.subs[eventName] = append(b.subs[eventName], &subscriber{
+		name: name,
+		mode: mode,
+		handler: func(ctx context.Context, e Event) error {
+			return handler(ctx, e.(T))
+		},
+	})
+}
+
+// Publish 发布事件：同步订阅者依次执行并汇总错误，异步订阅者进入队列
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	subs := b.subs[e.EventName()]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if sub.mode != Sync {
+			continue
+		}
+		if err := b.call(ctx, sub, e); err != nil {
+			errs = append(errs, fmt.Errorf("subscriber %s: %w", sub.name, err))
+		}
+	}
+
+	var payload []byte
+	var marshalErr error
+	for _, sub := range subs {
+		if sub.mode != Async {
+			continue
+		}
+		j := &job{sub: sub, event: e}
+		if b.opts.Outbox != nil && payload == nil && marshalErr == nil {
+			// 序列化失败时仍投递内存任务，只是无法持久化补发
+			if payload, marshalErr = json.Marshal(e); marshalErr != nil {
+				errs = append(errs, fmt.Errorf("marshal event %s: %w", e.EventName(), marshalErr))
+			}
+		}
+		if payload != nil {
+			rec := &Record{
+				ID:         primitive.NewObjectID().Hex(),
+				Event:      e.EventName(),
+				Subscriber: sub.name,
+				Payload:    payload,
+				Attempts:   1, // 入队即视为第一次尝试
+			}
+			if err := b.opts.Outbox.Save(ctx, rec, b.opts.Lease); err != nil {
+				errs = append(errs, fmt.Errorf("save outbox record of %s: %w", sub.name, err))
+			} else {
+				j.recordID = rec.ID
+			}
+		}
+		select {
+		case b.queue <- j:
+		default:
+			if j.recordID != "" {
+				// 已持久化，队列满时交给补发任务
+				continue
+			}
+			errs = append(errs, fmt.Errorf("queue full, event %s dropped for %s", e.EventName(), sub.name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StartRelay 定时补发 outbox 中到期的记录，直到ctx结束，未配置 outbox 时直接返回
+func (b *Bus) StartRelay(ctx context.Context) {
+	if b.opts.Outbox == nil {
+		return
+	}
+	ticker := time.NewTicker(b.opts.RelayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.Relay(ctx, 100)
+		}
+	}
+}
+
+// Relay 补发最多 limit 条到期记录，返回处理的记录数
+func (b *Bus) Relay(ctx context.Context, limit int) int {
+	if b.opts.Outbox == nil {
+		return 0
+	}
+	processed := 0
+	for processed < limit {
+		rec, err := b.opts.Outbox.ClaimDue(ctx, time.Now(), b.opts.Lease)
+		if err != nil {
+			logs.CtxErrorf(ctx, "[EventBus] [ClaimDue] error: %v", err)
+			break
+		}
+		if rec == nil {
+			break
+		}
+		processed++
+
+		sub, e, err := b.decode(rec)
+		if err != nil {
+			b.fail(ctx, rec.ID, err)
+			continue
+		}
+		err = b.call(withDispatchKey(ctx, rec.ID), sub, e)
+		b.settle(ctx, rec.ID, rec.Attempts, err)
+	}
+	return processed
+}
+
+// work 执行异步队列中的分发
+func (b *Bus) work() {
+	for j := range b.queue {
+		ctx := context.Background()
+		err := b.call(withDispatchKey(ctx, j.recordID), j.sub, j.event)
+		if j.recordID == "" {
+			if err != nil {
+				logs.CtxErrorf(ctx, "[EventBus] [%s] [%s] error: %v", j.event.EventName(), j.sub.name, err)
+			}
+			continue
+		}
+		b.settle(ctx, j.recordID, 1, err)
+	}
+}
+
+// call 执行订阅者，recover 订阅者中的 panic
+func (b *Bus) call(ctx context.Context, sub *subscriber, e Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return sub.handler(ctx, e)
+}
+
+// settle 根据执行结果更新 outbox 记录
+func (b *Bus) settle(ctx context.Context, id string, attempts int32, err error) {
+	if err == nil {
+		if markErr := b.opts.Outbox.MarkDone(ctx, id); markErr != nil {
+			logs.CtxErrorf(ctx, "[EventBus] [MarkDone] error: %v, recordId: %s", markErr, id)
+		}
+		return
+	}
+	if attempts >= b.opts.MaxAttempts {
+		b.fail(ctx, id, err)
+		return
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+	nextRetryAt := time.Now().Add(b.opts.RetryBaseInterval << (attempts - 1))
+	if markErr := b.opts.Outbox.MarkRetry(ctx, id, nextRetryAt, err.Error()); markErr != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [MarkRetry] error: %v, recordId: %s", markErr, id)
+	}
+}
+
+// fail 将记录标记为最终失败
+func (b *Bus) fail(ctx context.Context, id string, err error) {
+	logs.CtxErrorf(ctx, "[EventBus] dispatch failed: %v, recordId: %s", err, id)
+	if markErr := b.opts.Outbox.MarkFailed(ctx, id, err.Error()); markErr != nil {
+		logs.CtxErrorf(ctx, "[EventBus] [MarkFailed] error: %v, recordId: %s", markErr, id)
+	}
+}
+
+// decode 还原 outbox 记录对应的订阅者与事件
+func (b *Bus) decode(rec *Record) (*subscriber, Event, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	typ, ok := b.types[rec.Event]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown event %s", rec.Event)
+	}
+	var sub *subscriber
+	for _, s := range b.subs[rec.Event] {
+		if s.name == rec.Subscriber && s.mode == Async {
+			sub = s
+			break
+		}
+	}
+	if sub == nil {
+		return nil, nil, fmt.Errorf("unknown subscriber %s of event %s", rec.Subscriber, rec.Event)
+	}
+
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(rec.Payload, ptr.Interface()); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal event %s: %w", rec.Event, err)
+	}
+	return sub, ptr.Elem().Interface().(Event), nil
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	Value string `json:"value"`
+}
+
+func (testEvent) EventName() string { return "test.event" }
+
+// unmarshalableEvent 无法序列化的事件，用于测试 outbox 写入失败
+type unmarshalableEvent struct {
+	Ch chan int `json:"ch"`
+}
+
+func (unmarshalableEvent) EventName() string { return "test.unmarshalable" }
+
+// memOutbox 内存实现的 outbox，用于测试
+type memOutbox struct {
+	mu      sync.Mutex
+	records map[string]*memRecord
+}
+
+type memRecord struct {
+	rec         Record
+	nextRetryAt time.Time
+	done        bool
+	failed      bool
+}
+
+func newMemOutbox() *memOutbox {
+	return &memOutbox{records: make(map[string]*memRecord)}
+}
+
+func (o *memOutbox) Save(_ context.Context, rec *Record, lease time.Duration) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records[rec.ID] = &memRecord{rec: *rec, nextRetryAt: time.Now().Add(lease)}
+	return nil
+}
+
+func (o *memOutbox) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*Record, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, r := range o.records {
+		if !r.done && !r.failed && !r.nextRetryAt.After(now) {
+			r.rec.Attempts++
+			r.nextRetryAt = now.Add(lease)
+			rec := r.rec
+			return &rec, nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *memOutbox) MarkDone(_ context.Context, id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records[id].done = true
+	return nil
+}
+
+func (o *memOutbox) MarkRetry(_ context.Context, id string, nextRetryAt time.Time, _ string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records[id].nextRetryAt = nextRetryAt
+	return nil
+}
+
+func (o *memOutbox) MarkFailed(_ context.Context, id string, _ string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records[id].failed = true
+	return nil
+}
+
+// expire 让所有未完成的记录立即到期
+func (o *memOutbox) expire() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, r := range o.records {
+		r.nextRetryAt = time.Time{}
+	}
+}
+
+func (o *memOutbox) count(done, failed bool) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := 0
+	for _, r := range o.records {
+		if r.done == done && r.failed == failed {
+			n++
+		}
+	}
+	return n
+}
+
+// TestPublishSync 测试同步订阅者按顺序执行，错误汇总返回且不影响后续订阅者
+func TestPublishSync(t *testing.T) {
+	bus := New(Options{})
+	var got []string
+	Subscribe(bus, "first", Sync, func(_ context.Context, e testEvent) error {
+		got = append(got, "first:"+e.Value)
+		return errors.New("boom")
+	})
+	Subscribe(bus, "second", Sync, func(_ context.Context, e testEvent) error {
+		got = append(got, "second:"+e.Value)
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), testEvent{Value: "v"})
+	if err == nil {
+		t.Fatalf("Publish() error = nil, want error from first subscriber")
+	}
+	if len(got) != 2 || got[0] != "first:v" || got[1] != "second:v" {
+		t.Errorf("subscribers called = %v", got)
+	}
+}
+
+// TestPublishAsync 测试异步订阅者由 worker 执行，panic 不影响总线
+func TestPublishAsync(t *testing.T) {
+	bus := New(Options{Workers: 2})
+	done := make(chan string, 2)
+	Subscribe(bus, "panic", Async, func(_ context.Context, e testEvent) error {
+		panic("boom")
+	})
+	Subscribe(bus, "async", Async, func(_ context.Context, e testEvent) error {
+		done <- e.Value
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), testEvent{Value: "v"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case v := <-done:
+		if v != "v" {
+			t.Errorf("async subscriber got %q, want %q", v, "v")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("async subscriber not called")
+	}
+}
+
+// TestPublishMarshalError 测试事件无法序列化时返回错误，但所有异步订阅者仍以内存任务执行
+func TestPublishMarshalError(t *testing.T) {
+	outbox := newMemOutbox()
+	bus := New(Options{Outbox: outbox, Workers: 2})
+	done := make(chan string, 2)
+	for _, name := range []string{"first", "second"} {
+		name := name
+		Subscribe(bus, name, Async, func(_ context.Context, e unmarshalableEvent) error {
+			done <- name
+			return nil
+		})
+	}
+
+	if err := bus.Publish(context.Background(), unmarshalableEvent{Ch: make(chan int)}); err == nil {
+		t.Errorf("Publish() error = nil, want marshal error")
+	}
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case name := <-done:
+			got[name] = true
+		case <-time.After(time.Second):
+			t.Fatalf("async subscribers called = %v, want first and second", got)
+		}
+	}
+	outbox.mu.Lock()
+	defer outbox.mu.Unlock()
+	if len(outbox.records) != 0 {
+		t.Errorf("outbox records = %d, want 0", len(outbox.records))
+	}
+}
+
+// TestOutboxRelay 测试异步分发失败后由补发任务重试，重试耗尽标记失败
+func TestOutboxRelay(t *testing.T) {
+	outbox := newMemOutbox()
+	bus := New(Options{Outbox: outbox, MaxAttempts: 3})
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	called := make(chan struct{}, 10)
+	Subscribe(bus, "flaky", Async, func(_ context.Context, e testEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls["flaky"]++
+		called <- struct{}{}
+		if calls["flaky"] < 2 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	Subscribe(bus, "broken", Async, func(_ context.Context, e testEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls["broken"]++
+		called <- struct{}{}
+		return errors.New("permanent")
+	})
+
+	if err := bus.Publish(context.Background(), testEvent{Value: "v"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatalf("async subscribers not called")
+		}
+	}
+	// 等待 worker 更新记录状态
+	time.Sleep(50 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		outbox.expire()
+		bus.Relay(context.Background(), 10)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["flaky"] != 2 {
+		t.Errorf("flaky called %d times, want 2", calls["flaky"])
+	}
+	if calls["broken"] != 3 {
+		t.Errorf("broken called %d times, want 3", calls["broken"])
+	}
+	if outbox.count(true, false) != 1 || outbox.count(false, true) != 1 {
+		t.Errorf("done = %d, failed = %d, want 1, 1", outbox.count(true, false), outbox.count(false, true))
+	}
+}
+
+// TestDispatchKey 测试同一条 outbox 记录首次分发与补发时的分发键相同，同步分发没有分发键
+func TestDispatchKey(t *testing.T) {
+	outbox := newMemOutbox()
+	bus := New(Options{Outbox: outbox, MaxAttempts: 3})
+
+	var mu sync.Mutex
+	var keys []string
+	syncKey := "unset"
+	called := make(chan struct{}, 10)
+	Subscribe(bus, "async", Async, func(ctx context.Context, e testEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, DispatchKey(ctx))
+		called <- struct{}{}
+		return errors.New("retry")
+	})
+	Subscribe(bus, "sync", Sync, func(ctx context.Context, e testEvent) error {
+		syncKey = DispatchKey(ctx)
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), testEvent{Value: "v"})
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatalf("async subscriber not called")
+	}
+	time.Sleep(50 * time.Millisecond)
+	outbox.expire()
+	bus.Relay(context.Background(), 10)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if syncKey != "" {
+		t.Errorf("sync DispatchKey = %q, want empty", syncKey)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("async DispatchKeys = %q, want two equal non-empty keys", keys)
+	}
+}
@@ -0,0 +1,560 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fulltext 进程内全文检索索引
+//
+// 文本按 searchkey.Normalize 规范化后切分为一元和二元片段建立倒排索引，
+// 另外为每个音节开始的全拼和首字母建立前缀词项，支持拼音查询。
+// 中文查询要求命中全部二元片段，拼音查询按前缀匹配；开启模糊匹配后
+// 中文查询允许缺失少量片段，拼音查询允许少量拼写错误。结果按 BM25 排序并生成高亮。
+package fulltext
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
+)
+
+// 高亮标记
+const (
+	HighlightPre  = "<em>"
+	HighlightPost = "</em>"
+)
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// 不同匹配方式的得分权重
+const (
+	weightPinyin = 0.8 // 拼音前缀匹配
+	weightFuzzy  = 0.5 // 拼音模糊匹配
+	boostExact   = 2.0 // 规范化文本与查询完全相同
+	boostPrefix  = 1.5 // 规范化文本以查询开头
+)
+
+// MaxTextLen 参与索引的文本最大长度（规范化后的字符数），超出部分不参与检索和高亮
+const MaxTextLen = 256
+
+// 拼音词项前缀，与一元、二元片段区分
+const (
+	termPinyin   = "\x00p:"
+	termInitials = "\x00i:"
+)
+
+// Document 被索引的文档
+type Document struct {
+	Type string // 文档类型，如 course、teacher、proposal
+	ID   string
+	Text string // 参与检索和高亮的文本
+}
+
+// Query 检索条件
+type Query struct {
+	Text   string
+	Types  []string // 为空时检索全部类型
+	Offset int
+	Limit  int // 小于等于 0 时返回全部结果
+	Fuzzy  bool
+}
+
+// Hit 一条检索结果
+type Hit struct {
+	Type      string
+	ID        string
+	Text      string // 文档原文
+	Score     float64
+	Highlight string // 用 HighlightPre、HighlightPost 包裹命中部分的原文
+}
+
+// SearchIndex 全文检索索引
+type SearchIndex interface {
+	// Upsert 新增或替换文档
+	Upsert(docs ...Document)
+	// Delete 删除文档，不存在时忽略
+	Delete(typ string, ids ...string)
+	// Search 检索并返回当前页结果与命中总数
+	Search(q *Query) ([]*Hit, int)
+	// Len 返回文档数量
+	Len() int
+}
+
+var _ SearchIndex = (*Memory)(nil)
+
+type entry struct {
+	doc       Document
+	norm      []rune // 规范化文本
+	origin    []int  // norm[i] 在原文中的字符下标
+	syllables []searchkey.Syllable
+	length    int // 一元、二元片段数量，BM25 的文档长度
+}
+
+// Memory 基于倒排索引的内存实现，并发安全
+type Memory struct {
+	mu       sync.RWMutex
+	next     int
+	entries  map[int]*entry
+	keys     map[string]int         // Type + "\x00" + ID -> 文档编号
+	postings map[string]map[int]int // 词项 -> 文档编号 -> 词频
+	totalLen int                    // 所有文档长度之和
+	pinyin   []string               // 排序后的拼音词项，用于前缀与模糊查找
+	dirty    bool                   // pinyin 需要重建
+}
+
+// NewMemory 创建空的内存索引
+func NewMemory() *Memory {
+	return &Memory{
+		entries:  make(map[int]*entry),
+		keys:     make(map[string]int),
+		postings: make(map[string]map[int]int),
+	}
+}
+
+func docKey(typ, id string) string { return typ + "\x00" + id }
+
+// Len 返回文档数量
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
+
+// Upsert 新增或替换文档，规范化后为空的文档只做删除
+func (m *Memory) Upsert(docs ...Document) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, doc := range docs {
+		m.remove(docKey(doc.Type, doc.ID))
+		e, terms := analyze(doc)
+		if len(e.norm) == 0 {
+			continue
+		}
+		n := m.next
+		m.next++
+		m.entries[n] = e
+		m.keys[docKey(doc.Type, doc.ID)] = n
+		m.totalLen += e.length
+		for term, tf := range terms {
+			p, ok := m.postings[term]
+			if !ok {
+				p = make(map[int]int)
+				m.postings[term] = p
+				if isPinyinTerm(term) {
+					m.dirty = true
+				}
+			}
+			p[n] = tf
+		}
+	}
+}
+
+// Delete 删除文档，不存在时忽略
+func (m *Memory) Delete(typ string, ids ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.remove(docKey(typ, id))
+	}
+}
+
+func (m *Memory) remove(key string) {
+	n, ok := m.keys[key]
+	if !ok {
+		return
+	}
+	e := m.entries[n]
+	_, terms := analyze(e.doc)
+	for term := range terms {
+		if p := m.postings[term]; p != nil {
+			delete(p, n)
+			if len(p) == 0 {
+				delete(m.postings, term)
+				if isPinyinTerm(term) {
+					m.dirty = true
+				}
+			}
+		}
+	}
+	m.totalLen -= e.length
+	delete(m.entries, n)
+	delete(m.keys, key)
+}
+
+// analyze 规范化文档并统计词项频率
+func analyze(doc Document) (*entry, map[string]int) {
+	e := &entry{doc: doc}
+	i := 0
+	for _, r := range doc.Text {
+		if len(e.norm) >= MaxTextLen {
+			break
+		}
+		if nr, ok := searchkey.NormalizeRune(r); ok {
+			e.norm = append(e.norm, nr)
+			e.origin = append(e.origin, i)
+		}
+		i++
+	}
+	terms := make(map[string]int)
+	for j := range e.norm {
+		terms[string(e.norm[j])]++
+		e.length++
+		if j+1 < len(e.norm) {
+			terms[string(e.norm[j:j+2])]++
+			e.length++
+		}
+	}
+	e.syllables = searchkey.Syllables(string(e.norm))
+	syllables := e.pinyinSyllables()
+	for j := range syllables {
+		full, initials := joinSyllables(syllables[j:])
+		terms[termPinyin+full] = 1
+		terms[termInitials+initials] = 1
+	}
+	return e, terms
+}
+
+// pinyinSyllables 返回建立拼音词项的音节，词项随音节数平方增长，只取前 searchkey.MaxNameLen 个
+func (e *entry) pinyinSyllables() []searchkey.Syllable {
+	return e.syllables[:min(len(e.syllables), searchkey.MaxNameLen)]
+}
+
+// joinSyllables 返回音节连接后的全拼与首字母
+func joinSyllables(syllables []searchkey.Syllable) (string, string) {
+	var full, initials strings.Builder
+	for _, syl := range syllables {
+		full.WriteString(syl.Pinyin)
+		for _, r := range syl.Pinyin {
+			initials.WriteRune(r)
+			break
+		}
+	}
+	return full.String(), initials.String()
+}
+
+func isPinyinTerm(term string) bool {
+	return strings.HasPrefix(term, termPinyin) || strings.HasPrefix(term, termInitials)
+}
+
+// Search 检索并返回当前页结果与命中总数
+func (m *Memory) Search(q *Query) ([]*Hit, int) {
+	m.mu.RLock()
+	if m.dirty {
+		m.mu.RUnlock()
+		m.rebuildPinyin()
+		m.mu.RLock()
+	}
+	defer m.mu.RUnlock()
+
+	norm, grams := searchkey.Query(q.Text)
+	if norm == "" || len(m.entries) == 0 {
+		return []*Hit{}, 0
+	}
+	var types map[string]bool
+	if len(q.Types) > 0 {
+		types = make(map[string]bool, len(q.Types))
+		for _, t := range q.Types {
+			types[t] = true
+		}
+	}
+
+	scores := make(map[int]float64)
+	m.matchGrams(grams, q.Fuzzy, scores)
+	pinyinQuery := isPinyinQuery(norm)
+	if pinyinQuery {
+		m.matchPinyin(norm, q.Fuzzy, scores)
+	}
+
+	type candidate struct {
+		e     *entry
+		score float64
+	}
+	candidates := make([]candidate, 0, len(scores))
+	for n, score := range scores {
+		e := m.entries[n]
+		if types != nil && !types[e.doc.Type] {
+			continue
+		}
+		if text := string(e.norm); text == norm {
+			score *= boostExact
+		} else if strings.HasPrefix(text, norm) {
+			score *= boostPrefix
+		}
+		candidates = append(candidates, candidate{e: e, score: score})
+	}
+	// 同分按原文和文档键排序，保证分页稳定
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.score != cj.score {
+			return ci.score > cj.score
+		}
+		if ci.e.doc.Text != cj.e.doc.Text {
+			return ci.e.doc.Text < cj.e.doc.Text
+		}
+		return docKey(ci.e.doc.Type, ci.e.doc.ID) < docKey(cj.e.doc.Type, cj.e.doc.ID)
+	})
+
+	total := len(candidates)
+	if q.Offset >= total {
+		return []*Hit{}, total
+	}
+	candidates = candidates[max(q.Offset, 0):]
+	if q.Limit > 0 && len(candidates) > q.Limit {
+		candidates = candidates[:q.Limit]
+	}
+	hits := make([]*Hit, 0, len(candidates))
+	for _, c := range candidates {
+		hits = append(hits, &Hit{
+			Type:      c.e.doc.Type,
+			ID:        c.e.doc.ID,
+			Text:      c.e.doc.Text,
+			Score:     c.score,
+			Highlight: c.e.highlight(norm, grams, pinyinQuery),
+		})
+	}
+	return hits, total
+}
+
+// matchGrams 中文片段匹配：默认要求命中全部片段，模糊匹配时允许缺失四分之一（至少一个）片段
+func (m *Memory) matchGrams(grams []string, fuzzy bool, scores map[int]float64) {
+	minShould := len(grams)
+	if fuzzy && len(grams) > 1 {
+		minShould -= max(1, len(grams)/4)
+	}
+	matched := make(map[int]int)
+	partial := make(map[int]float64)
+	for _, g := range grams {
+		idf := m.idf(len(m.postings[g]))
+		for n, tf := range m.postings[g] {
+			matched[n]++
+			partial[n] += idf * m.tfNorm(n, tf)
+		}
+	}
+	for n, cnt := range matched {
+		if cnt < minShould {
+			continue
+		}
+		// 按命中比例折算，未命中全部片段的模糊结果排在后面
+		score := partial[n] * float64(cnt) / float64(len(grams))
+		if score > scores[n] {
+			scores[n] = score
+		}
+	}
+}
+
+// matchPinyin 拼音匹配：查询是某个音节开始的全拼或首字母的前缀，模糊匹配时允许少量拼写错误
+// 非模糊匹配时直接定位到以查询开头的词项，模糊匹配时扫描全部拼音词项
+func (m *Memory) matchPinyin(query string, fuzzy bool, scores map[int]float64) {
+	maxDist := 0
+	if fuzzy {
+		maxDist = fuzzyDistance(len(query))
+	}
+	for _, prefix := range []string{termPinyin, termInitials} {
+		seek := prefix
+		if maxDist == 0 {
+			seek += query
+		}
+		lo := sort.SearchStrings(m.pinyin, seek)
+		for i := lo; i < len(m.pinyin) && strings.HasPrefix(m.pinyin[i], seek); i++ {
+			term := m.pinyin[i]
+			weight := 0.0
+			if strings.HasPrefix(term[len(prefix):], query) {
+				weight = weightPinyin
+			} else if maxDist > 0 && prefixDistance(query, term[len(prefix):], maxDist) <= maxDist {
+				weight = weightFuzzy
+			} else {
+				continue
+			}
+			idf := m.idf(len(m.postings[term]))
+			for n, tf := range m.postings[term] {
+				if score := weight * idf * m.tfNorm(n, tf); score > scores[n] {
+					scores[n] = score
+				}
+			}
+		}
+	}
+}
+
+// rebuildPinyin 重建排序后的拼音词项
+func (m *Memory) rebuildPinyin() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.dirty {
+		return
+	}
+	m.pinyin = m.pinyin[:0]
+	for term := range m.postings {
+		if isPinyinTerm(term) {
+			m.pinyin = append(m.pinyin, term)
+		}
+	}
+	sort.Strings(m.pinyin)
+	m.dirty = false
+}
+
+func (m *Memory) idf(df int) float64 {
+	n := float64(len(m.entries))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func (m *Memory) tfNorm(n, tf int) float64 {
+	avg := float64(m.totalLen) / float64(len(m.entries))
+	dl := float64(m.entries[n].length)
+	return float64(tf) * (bm25K1 + 1) / (float64(tf) + bm25K1*(1-bm25B+bm25B*dl/avg))
+}
+
+// isPinyinQuery 查询只包含小写字母和数字时按拼音匹配
+func isPinyinQuery(norm string) bool {
+	if len(norm) > searchkey.MaxQueryLen {
+		return false
+	}
+	for _, r := range norm {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyDistance 按查询长度允许的编辑距离
+func fuzzyDistance(n int) int {
+	switch {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// prefixDistance 返回 query 与 term 任一前缀的最小编辑距离，超过 max 时提前返回 max+1
+func prefixDistance(query, term string, max int) int {
+	prev := make([]int, len(term)+1)
+	cur := make([]int, len(term)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(query); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(term); j++ {
+			cost := 1
+			if query[i-1] == term[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+	best := prev[0]
+	for _, d := range prev {
+		best = min(best, d)
+	}
+	return best
+}
+
+// highlight 标记原文中命中的部分：优先标记查询的连续出现，否则标记命中的片段或拼音音节
+func (e *entry) highlight(norm string, grams []string, pinyinQuery bool) string {
+	marked := make([]bool, len(e.norm))
+	query := []rune(norm)
+	found := markOccurrences(e.norm, query, marked)
+	if !found {
+		for _, g := range grams {
+			if markOccurrences(e.norm, []rune(g), marked) {
+				found = true
+			}
+		}
+	}
+	if !found && pinyinQuery {
+		e.markPinyin(norm, marked)
+	}
+
+	runes := []rune(e.doc.Text)
+	hit := make([]bool, len(runes))
+	for i, ok := range marked {
+		if ok {
+			hit[e.origin[i]] = true
+		}
+	}
+	var b strings.Builder
+	for i, r := range runes {
+		if hit[i] && (i == 0 || !hit[i-1]) {
+			b.WriteString(HighlightPre)
+		}
+		b.WriteRune(r)
+		if hit[i] && (i == len(runes)-1 || !hit[i+1]) {
+			b.WriteString(HighlightPost)
+		}
+	}
+	return b.String()
+}
+
+// markOccurrences 标记 text 中 sub 的所有出现，返回是否找到
+func markOccurrences(text, sub []rune, marked []bool) bool {
+	if len(sub) == 0 || len(sub) > len(text) {
+		return false
+	}
+	found := false
+	for i := 0; i+len(sub) <= len(text); i++ {
+		if string(text[i:i+len(sub)]) == string(sub) {
+			for j := i; j < i+len(sub); j++ {
+				marked[j] = true
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// markPinyin 标记与拼音查询最接近的起始音节之后被查询覆盖的音节
+func (e *entry) markPinyin(query string, marked []bool) {
+	best, bestDist := -1, len(query)+1
+	initials := false
+	syllables := e.pinyinSyllables()
+	for i := range syllables {
+		full, ini := joinSyllables(syllables[i:])
+		if d := prefixDistance(query, full, bestDist); d < bestDist {
+			best, bestDist, initials = i, d, false
+		}
+		if d := prefixDistance(query, ini, bestDist); d < bestDist {
+			best, bestDist, initials = i, d, true
+		}
+	}
+	if best < 0 {
+		return
+	}
+	remain := len(query)
+	for _, syl := range e.syllables[best:] {
+		if remain <= 0 {
+			break
+		}
+		for j := syl.Start; j < syl.End; j++ {
+			marked[j] = true
+		}
+		if initials {
+			remain--
+		} else {
+			remain -= len(syl.Pinyin)
+		}
+	}
+}
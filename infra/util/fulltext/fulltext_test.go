@@ -0,0 +1,269 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/searchkey"
+)
+
+func newTestIndex() *Memory {
+	idx := NewMemory()
+	idx.Upsert(
+		Document{Type: "course", ID: "c1", Text: "高等数学"},
+		Document{Type: "course", ID: "c2", Text: "高等数学（下）"},
+		Document{Type: "course", ID: "c3", Text: "线性代数"},
+		Document{Type: "course", ID: "c4", Text: "数学分析"},
+		Document{Type: "course", ID: "c5", Text: "C语言程序设计"},
+		Document{Type: "teacher", ID: "t1", Text: "高数"},
+		Document{Type: "proposal", ID: "p1", Text: "新增课程：概率论与数理统计"},
+	)
+	return idx
+}
+
+func ids(hits []*Hit) []string {
+	var out []string
+	for _, h := range hits {
+		out = append(out, h.ID)
+	}
+	return out
+}
+
+func TestSearchChinese(t *testing.T) {
+	idx := newTestIndex()
+
+	hits, total := idx.Search(&Query{Text: "数学"})
+	if total != 3 {
+		t.Fatalf("total = %d, want 3, hits = %v", total, ids(hits))
+	}
+	// 完全相同 > 前缀 > 子串
+	if got := ids(hits); got[0] != "c4" {
+		t.Errorf("prefix match should rank first, got %v", got)
+	}
+
+	hits, _ = idx.Search(&Query{Text: "高等数学"})
+	if got := ids(hits); len(got) != 2 || got[0] != "c1" {
+		t.Errorf("exact match should rank first, got %v", got)
+	}
+
+	// 必须命中全部片段
+	if hits, total = idx.Search(&Query{Text: "高等代数"}); total != 0 {
+		t.Errorf("高等代数 matched %v without fuzzy", ids(hits))
+	}
+}
+
+func TestSearchTypesAndPaging(t *testing.T) {
+	idx := newTestIndex()
+
+	hits, total := idx.Search(&Query{Text: "高", Types: []string{"teacher"}})
+	if total != 1 || hits[0].ID != "t1" {
+		t.Errorf("teacher search = %v, total %d", ids(hits), total)
+	}
+
+	all, total := idx.Search(&Query{Text: "高"})
+	page, pageTotal := idx.Search(&Query{Text: "高", Offset: 1, Limit: 1})
+	if pageTotal != total || len(page) != 1 || page[0].ID != all[1].ID {
+		t.Errorf("page = %v, all = %v", ids(page), ids(all))
+	}
+	if hits, _ = idx.Search(&Query{Text: "高", Offset: 100}); len(hits) != 0 {
+		t.Errorf("offset beyond total returned %v", ids(hits))
+	}
+}
+
+func TestSearchPinyin(t *testing.T) {
+	idx := newTestIndex()
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"gaodeng", "c1"},
+		{"gdsx", "c1"},
+		{"xianxing", "c3"},
+		{"xxds", "c3"},
+		{"shuxue", "c4"}, // 从中间音节开始
+		{"cyuyan", "c5"},
+	}
+	for _, tt := range tests {
+		hits, _ := idx.Search(&Query{Text: tt.query, Types: []string{"course"}})
+		found := false
+		for _, h := range hits {
+			if h.ID == tt.want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Search(%q) = %v, want contains %s", tt.query, ids(hits), tt.want)
+		}
+	}
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	idx := newTestIndex()
+
+	if _, total := idx.Search(&Query{Text: "xianxnig"}); total != 0 {
+		t.Errorf("typo matched without fuzzy")
+	}
+	hits, _ := idx.Search(&Query{Text: "xianxnig", Fuzzy: true})
+	if got := ids(hits); len(got) == 0 || got[0] != "c3" {
+		t.Errorf("fuzzy pinyin = %v, want c3 first", got)
+	}
+
+	hits, _ = idx.Search(&Query{Text: "高等数字", Fuzzy: true})
+	if got := ids(hits); len(got) == 0 || got[0] != "c1" {
+		t.Errorf("fuzzy chinese = %v, want c1 first", got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	idx := newTestIndex()
+	tests := []struct {
+		query, id, want string
+	}{
+		{"数学", "c2", "高等<em>数学</em>（下）"},
+		{"数理统计", "p1", "新增课程：概率论与<em>数理统计</em>"},
+		{"xxds", "c3", "<em>线性代数</em>"},
+		{"shuxue", "c1", "高等<em>数学</em>"},
+		{"C语言", "c5", "<em>C语言</em>程序设计"},
+	}
+	for _, tt := range tests {
+		hits, _ := idx.Search(&Query{Text: tt.query})
+		var got string
+		for _, h := range hits {
+			if h.ID == tt.id {
+				got = h.Highlight
+			}
+		}
+		if got != tt.want {
+			t.Errorf("Search(%q) highlight of %s = %q, want %q", tt.query, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestUpsertAndDelete(t *testing.T) {
+	idx := newTestIndex()
+	n := idx.Len()
+
+	idx.Upsert(Document{Type: "course", ID: "c3", Text: "抽象代数"})
+	if idx.Len() != n {
+		t.Errorf("Len after replace = %d, want %d", idx.Len(), n)
+	}
+	if _, total := idx.Search(&Query{Text: "线性"}); total != 0 {
+		t.Errorf("old text still searchable after replace")
+	}
+	if hits, _ := idx.Search(&Query{Text: "chouxiang"}); len(hits) != 1 || hits[0].ID != "c3" {
+		t.Errorf("new text not searchable: %v", ids(hits))
+	}
+
+	idx.Delete("course", "c3", "missing")
+	if idx.Len() != n-1 {
+		t.Errorf("Len after delete = %d, want %d", idx.Len(), n-1)
+	}
+	if _, total := idx.Search(&Query{Text: "代数"}); total != 0 {
+		t.Errorf("deleted document still searchable")
+	}
+
+	// 规范化后为空的文本不进入索引
+	idx.Upsert(Document{Type: "course", ID: "c6", Text: "（）"})
+	if idx.Len() != n-1 {
+		t.Errorf("empty document was indexed")
+	}
+}
+
+func TestSearchAdversarialQuery(t *testing.T) {
+	idx := newTestIndex()
+	for _, q := range []string{"", "   ", ".*", "(a+)+$", `\`, strings.Repeat("数", 10000), strings.Repeat("a", 10000)} {
+		hits, total := idx.Search(&Query{Text: q, Fuzzy: true})
+		if total != len(hits) {
+			t.Errorf("Search(%.20q) total = %d, len = %d", q, total, len(hits))
+		}
+	}
+}
+
+func TestLongTextBounded(t *testing.T) {
+	idx := NewMemory()
+	long := strings.Repeat("高等数学", 1000)
+	idx.Upsert(Document{Type: "course", ID: "long", Text: long})
+
+	e, terms := analyze(Document{Text: long})
+	if len(e.norm) != MaxTextLen {
+		t.Errorf("len(norm) = %d, want %d", len(e.norm), MaxTextLen)
+	}
+	pinyinLen := 0
+	for term := range terms {
+		if isPinyinTerm(term) {
+			pinyinLen += len(term)
+		}
+	}
+	// 每个拼音词项最多包含 MaxNameLen 个音节，单个音节不超过 6 个字母
+	if limit := 2 * searchkey.MaxNameLen * (searchkey.MaxNameLen*6 + len(termPinyin)); pinyinLen > limit {
+		t.Errorf("pinyin terms total length = %d, want <= %d", pinyinLen, limit)
+	}
+
+	for _, q := range []string{"gaodengshuxue", "gdsx", "高等数学"} {
+		if _, total := idx.Search(&Query{Text: q}); total != 1 {
+			t.Errorf("Search(%q) total = %d, want 1", q, total)
+		}
+	}
+}
+
+func TestSearchPinyinPrefixSeek(t *testing.T) {
+	idx := newTestIndex()
+	// 非模糊匹配只命中以查询开头的词项，前后相邻的词项不受影响
+	for _, tt := range []struct {
+		query string
+		want  int
+	}{
+		{"gaodengshuxuex", 1},
+		{"gaodengshuxue", 2},
+		{"gaodengshuxud", 0},
+		{"zzz", 0},
+	} {
+		if _, total := idx.Search(&Query{Text: tt.query, Types: []string{"course"}}); total != tt.want {
+			t.Errorf("Search(%q) total = %d, want %d", tt.query, total, tt.want)
+		}
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	idx := newTestIndex()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := fmt.Sprintf("x%d-%d", i, j)
+				idx.Upsert(Document{Type: "course", ID: id, Text: "离散数学"})
+				if j%2 == 0 {
+					idx.Delete("course", id)
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				idx.Search(&Query{Text: "lisan", Fuzzy: true})
+			}
+		}()
+	}
+	wg.Wait()
+	if _, total := idx.Search(&Query{Text: "离散数学"}); total != 8*50 {
+		t.Errorf("total = %d, want %d", total, 8*50)
+	}
+}
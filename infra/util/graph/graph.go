@@ -0,0 +1,76 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph 按层遍历课程关系图
+// 相邻节点由调用方按层批量查询，遍历本身不关心存储
+package graph
+
+// Neighbors 批量返回一层节点的相邻节点，可以包含重复或已访问的节点
+type Neighbors func(ids []string) ([]string, error)
+
+// Walk 从 start 出发按层遍历，返回除起点外可达的节点（按发现顺序）
+// 最多返回 limit 个，limit <= 0 时不限；truncated 表示是否因达到上限提前结束
+func Walk(start []string, next Neighbors, limit int) (reached []string, truncated bool, err error) {
+	visited := make(map[string]bool, len(start))
+	frontier := make([]string, 0, len(start))
+	for _, id := range start {
+		if !visited[id] {
+			visited[id] = true
+			frontier = append(frontier, id)
+		}
+	}
+	reached = []string{}
+	for len(frontier) > 0 {
+		ids, err := next(frontier)
+		if err != nil {
+			return nil, false, err
+		}
+		frontier = frontier[:0]
+		for _, id := range ids {
+			if visited[id] {
+				continue
+			}
+			if limit > 0 && len(reached) >= limit {
+				return reached, true, nil
+			}
+			visited[id] = true
+			reached = append(reached, id)
+			frontier = append(frontier, id)
+		}
+	}
+	return reached, false, nil
+}
+
+// Reachable 判断从 from 出发能否到达 to，from == to 时为 true
+// 访问节点数达到 limit 仍未找到时无法确定，保守地返回 true
+func Reachable(from, to string, next Neighbors, limit int) (bool, error) {
+	if from == to {
+		return true, nil
+	}
+	found := false
+	_, truncated, err := Walk([]string{from}, func(ids []string) ([]string, error) {
+		nexts, err := next(ids)
+		for _, id := range nexts {
+			if id == to {
+				found = true
+				return nil, err // 找到后停止遍历
+			}
+		}
+		return nexts, err
+	}, limit)
+	if err != nil {
+		return false, err
+	}
+	return found || truncated, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func adjacency(edges map[string][]string) (Neighbors, *int) {
+	calls := 0
+	return func(ids []string) ([]string, error) {
+		calls++
+		var out []string
+		for _, id := range ids {
+			out = append(out, edges[id]...)
+		}
+		return out, nil
+	}, &calls
+}
+
+func TestWalk(t *testing.T) {
+	next, calls := adjacency(map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d", "a"},
+		"c": {"d"},
+		"d": {"e"},
+		"x": {"y"},
+	})
+	got, truncated, err := Walk([]string{"a", "a"}, next, 0)
+	if err != nil || truncated {
+		t.Fatalf("Walk error = %v, truncated = %v", err, truncated)
+	}
+	if want := []string{"b", "c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk = %v, want %v", got, want)
+	}
+	if *calls != 4 {
+		t.Errorf("neighbors called %d times, want one per level (4)", *calls)
+	}
+
+	got, truncated, _ = Walk([]string{"a"}, next, 2)
+	if !truncated || !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("Walk limit 2 = %v, truncated = %v", got, truncated)
+	}
+
+	got, truncated, _ = Walk([]string{"e"}, next, 0)
+	if truncated || len(got) != 0 || got == nil {
+		t.Errorf("Walk from sink = %#v, truncated = %v", got, truncated)
+	}
+}
+
+func TestWalkError(t *testing.T) {
+	boom := errors.New("boom")
+	_, _, err := Walk([]string{"a"}, func([]string) ([]string, error) { return nil, boom }, 0)
+	if !errors.Is(err, boom) {
+		t.Errorf("Walk error = %v, want %v", err, boom)
+	}
+	if _, err = Reachable("a", "b", func([]string) ([]string, error) { return nil, boom }, 0); !errors.Is(err, boom) {
+		t.Errorf("Reachable error = %v, want %v", err, boom)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	next, _ := adjacency(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"d"},
+		"d": {"e"},
+	})
+	tests := []struct {
+		from, to string
+		limit    int
+		want     bool
+	}{
+		{"a", "e", 0, true},
+		{"a", "a", 0, true},
+		{"e", "a", 0, false},
+		{"c", "b", 0, false},
+		{"a", "e", 2, true}, // 达到上限时保守地认为可达
+		{"a", "b", 1, true},
+	}
+	for _, tt := range tests {
+		got, err := Reachable(tt.from, tt.to, next, tt.limit)
+		if err != nil {
+			t.Fatalf("Reachable(%s, %s) error: %v", tt.from, tt.to, err)
+		}
+		if got != tt.want {
+			t.Errorf("Reachable(%s, %s, limit %d) = %v, want %v", tt.from, tt.to, tt.limit, got, tt.want)
+		}
+	}
+}
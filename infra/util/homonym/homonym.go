@@ -0,0 +1,129 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package homonym 在同名教师中识别同一位教师
+//
+// 教师的身份由姓名加院系确定，院系未知时退而比较职称：
+// 院系或职称任一方为空时视为兼容，只有两方都已知且不同时才视为不同的教师。
+package homonym
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+// ErrAmbiguous 多位同名教师都与给定的院系和职称兼容，无法确定是哪一位
+var ErrAmbiguous = errors.New("homonym: ambiguous teacher")
+
+// AmbiguousError 携带无法区分的教师姓名，errors.Is(err, ErrAmbiguous) 成立
+type AmbiguousError struct {
+	Name string
+}
+
+func (e *AmbiguousError) Error() string {
+	return ErrAmbiguous.Error() + " " + e.Name
+}
+
+func (e *AmbiguousError) Is(target error) bool {
+	return target == ErrAmbiguous
+}
+
+// Match 在同名的候选教师中查找与院系和职称对应的教师，没有兼容的教师时返回 nil
+// 多位兼容时依次优先院系一致、职称一致的教师，仍不唯一时返回 *AmbiguousError
+func Match(candidates []*model.Teacher, department int32, title string) (*model.Teacher, error) {
+	title = strings.TrimSpace(title)
+	compatible := make([]*model.Teacher, 0, len(candidates))
+	for _, c := range candidates {
+		if c == nil {
+			continue
+		}
+		if department != 0 && c.Department != 0 && c.Department != department {
+			continue
+		}
+		if title != "" && c.Title != "" && c.Title != title {
+			continue
+		}
+		compatible = append(compatible, c)
+	}
+
+	if department != 0 && len(compatible) > 1 {
+		compatible = narrow(compatible, func(c *model.Teacher) bool { return c.Department == department })
+	}
+	if title != "" && len(compatible) > 1 {
+		compatible = narrow(compatible, func(c *model.Teacher) bool { return c.Title == title })
+	}
+
+	switch len(compatible) {
+	case 0:
+		return nil, nil
+	case 1:
+		return compatible[0], nil
+	default:
+		return nil, &AmbiguousError{Name: compatible[0].Name}
+	}
+}
+
+// MatchByName 在同名的候选教师中按姓名匹配，院系只用于区分多位同名教师，适用于院系只是推测的场景（如导入时取开课院系）
+// 没有候选时返回 nil；只有一位时直接返回；多位时取院系一致的唯一教师，仍不唯一时返回 *AmbiguousError
+func MatchByName(candidates []*model.Teacher, department int32) (*model.Teacher, error) {
+	teachers := make([]*model.Teacher, 0, len(candidates))
+	for _, c := range candidates {
+		if c != nil {
+			teachers = append(teachers, c)
+		}
+	}
+	if len(teachers) > 1 && department != 0 {
+		teachers = narrow(teachers, func(c *model.Teacher) bool { return c.Department == department })
+	}
+
+	switch len(teachers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return teachers[0], nil
+	default:
+		return nil, &AmbiguousError{Name: teachers[0].Name}
+	}
+}
+
+// Identical 返回候选中与院系和职称确定为同一身份的教师，用于创建和修改教师时防重
+// 院系已知时院系相同即为同一身份；院系未知时只与同样未知院系且职称相同的教师视为同一身份
+func Identical(candidates []*model.Teacher, department int32, title string) *model.Teacher {
+	title = strings.TrimSpace(title)
+	for _, c := range candidates {
+		if c == nil || c.Department != department {
+			continue
+		}
+		if department != 0 || c.Title == title {
+			return c
+		}
+	}
+	return nil
+}
+
+// narrow 保留满足条件的教师，没有满足条件的教师时保持原样
+func narrow(teachers []*model.Teacher, keep func(*model.Teacher) bool) []*model.Teacher {
+	kept := make([]*model.Teacher, 0, len(teachers))
+	for _, t := range teachers {
+		if keep(t) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == 0 {
+		return teachers
+	}
+	return kept
+}
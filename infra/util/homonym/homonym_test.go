@@ -0,0 +1,138 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package homonym
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+func TestMatch(t *testing.T) {
+	csProf := &model.Teacher{ID: "cs-prof", Name: "张伟", Department: 1, Title: "教授"}
+	csLect := &model.Teacher{ID: "cs-lect", Name: "张伟", Department: 1, Title: "讲师"}
+	math := &model.Teacher{ID: "math", Name: "张伟", Department: 2, Title: "教授"}
+	unknown := &model.Teacher{ID: "unknown", Name: "张伟"}
+
+	tests := []struct {
+		name       string
+		candidates []*model.Teacher
+		department int32
+		title      string
+		want       string
+		ambiguous  bool
+	}{
+		{"无候选", nil, 1, "教授", "", false},
+		{"唯一候选且信息未知", []*model.Teacher{math}, 0, "", "math", false},
+		{"院系不同视为新教师", []*model.Teacher{math}, 1, "教授", "", false},
+		{"按院系区分", []*model.Teacher{csProf, math}, 2, "", "math", false},
+		{"同院系按职称区分", []*model.Teacher{csProf, csLect, math}, 1, "讲师", "cs-lect", false},
+		{"院系未知时按职称区分", []*model.Teacher{csLect, math}, 0, "教授", "math", false},
+		{"优先院系一致的教师", []*model.Teacher{unknown, math}, 2, "", "math", false},
+		{"院系未知的候选也兼容", []*model.Teacher{unknown, csProf}, 3, "", "unknown", false},
+		{"无法区分", []*model.Teacher{csProf, math}, 0, "教授", "", true},
+		{"同院系同职称", []*model.Teacher{csProf, {ID: "dup", Name: "张伟", Department: 1, Title: "教授"}}, 1, "教授", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Match(tt.candidates, tt.department, tt.title)
+			if tt.ambiguous {
+				var ae *AmbiguousError
+				if !errors.Is(err, ErrAmbiguous) || !errors.As(err, &ae) || ae.Name != tt.candidates[0].Name {
+					t.Fatalf("Match() error = %v, want ErrAmbiguous", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			gotId := ""
+			if got != nil {
+				gotId = got.ID
+			}
+			if gotId != tt.want {
+				t.Errorf("Match() = %q, want %q", gotId, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchByName(t *testing.T) {
+	csProf := &model.Teacher{ID: "cs-prof", Name: "张伟", Department: 1, Title: "教授"}
+	csLect := &model.Teacher{ID: "cs-lect", Name: "张伟", Department: 1, Title: "讲师"}
+	math := &model.Teacher{ID: "math", Name: "张伟", Department: 2, Title: "教授"}
+
+	tests := []struct {
+		name       string
+		candidates []*model.Teacher
+		department int32
+		want       string
+		ambiguous  bool
+	}{
+		{"无候选", nil, 1, "", false},
+		{"唯一候选院系不同也匹配", []*model.Teacher{math}, 1, "math", false},
+		{"按院系区分", []*model.Teacher{csProf, math}, 2, "math", false},
+		{"院系都不一致", []*model.Teacher{csProf, math}, 3, "", true},
+		{"同院系多位", []*model.Teacher{csProf, csLect, math}, 1, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchByName(tt.candidates, tt.department)
+			if tt.ambiguous {
+				if !errors.Is(err, ErrAmbiguous) {
+					t.Fatalf("MatchByName() error = %v, want ErrAmbiguous", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MatchByName() error = %v", err)
+			}
+			gotId := ""
+			if got != nil {
+				gotId = got.ID
+			}
+			if gotId != tt.want {
+				t.Errorf("MatchByName() = %q, want %q", gotId, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentical(t *testing.T) {
+	csProf := &model.Teacher{ID: "cs-prof", Name: "张伟", Department: 1, Title: "教授"}
+	unknown := &model.Teacher{ID: "unknown", Name: "张伟", Title: "讲师"}
+	candidates := []*model.Teacher{csProf, unknown}
+
+	tests := []struct {
+		name       string
+		department int32
+		title      string
+		want       string
+	}{
+		{"院系相同即为同一身份", 1, "讲师", "cs-prof"},
+		{"院系不同", 2, "教授", ""},
+		{"院系未知时比较职称", 0, "讲师", "unknown"},
+		{"院系未知且职称不同", 0, "教授", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Identical(candidates, tt.department, tt.title)
+			if (got == nil && tt.want != "") || (got != nil && got.ID != tt.want) {
+				t.Fatalf("Identical() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,12 +36,14 @@ type StaticData struct {
 	ProposalStatusNameByID      map[int32]string
 	LikeTargetTypeNameByID      map[int32]string
 	ChangeLogTargetTypeNameByID map[int32]string
+	NotificationTypeNameByID    map[int32]string
 	CampusIDByName              map[string]int32
 	DepartmentIDByName          map[string]int32
 	CategoryIDByName            map[string]int32
 	ProposalStatusIDByName      map[string]int32
 	LikeTargetTypeIDByName      map[string]int32
 	ChangeLogTargetTypeIDByName map[string]int32
+	NotificationTypeIDByName    map[string]int32
 	// 数据库和缓存依赖
 	mappingRepo  *repo.MappingRepo
 	mappingCache *cache.MappingCache
@@ -55,12 +57,14 @@ var Data = &StaticData{
 	ProposalStatusNameByID:      make(map[int32]string),
 	LikeTargetTypeNameByID:      make(map[int32]string),
 	ChangeLogTargetTypeNameByID: make(map[int32]string),
+	NotificationTypeNameByID:    make(map[int32]string),
 	CampusIDByName:              make(map[string]int32),
 	DepartmentIDByName:          make(map[string]int32),
 	CategoryIDByName:            make(map[string]int32),
 	ProposalStatusIDByName:      make(map[string]int32),
 	LikeTargetTypeIDByName:      make(map[string]int32),
 	ChangeLogTargetTypeIDByName: make(map[string]int32),
+	NotificationTypeIDByName:    make(map[string]int32),
 }
 
 func init() {
@@ -82,6 +86,9 @@ func init() {
 	for k, v := range mapping.ChangeLogTargetTypeMap {
 		Data.ChangeLogTargetTypeNameByID[k] = v
 	}
+	for k, v := range mapping.NotificationTypeMap {
+		Data.NotificationTypeNameByID[k] = v
+	}
 
 	for id, name := range Data.CampusNameByID {
 		Data.CampusIDByName[name] = id
@@ -101,6 +108,9 @@ func init() {
 	for id, name := range Data.ChangeLogTargetTypeNameByID {
 		Data.ChangeLogTargetTypeIDByName[name] = id
 	}
+	for id, name := range Data.NotificationTypeNameByID {
+		Data.NotificationTypeIDByName[name] = id
+	}
 }
 
 // InitWithDependencies 初始化映射工具类的数据库和缓存依赖
@@ -223,6 +233,16 @@ func (d *StaticData) GetChangeLogTargetTypeNameByID(id int32) string {
 	return "未知变更记录类型"
 }
 
+func (d *StaticData) GetNotificationTypeNameByID(id int32) string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if name, ok := d.NotificationTypeNameByID[id]; ok {
+		return name
+	}
+	return "未知通知类型"
+}
+
 func (d *StaticData) GetCampusIDByName(name string) int32 {
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
@@ -272,6 +292,16 @@ func (d *StaticData) GetLikeTargetTypeIDByName(name string) int32 {
 	return 0
 }
 
+func (d *StaticData) GetNotificationTypeIDByName(name string) int32 {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if id, ok := d.NotificationTypeIDByName[name]; ok {
+		return id
+	}
+	return 0
+}
+
 // AutoRegisterDepartment 自动注册不存在的院系，返回其ID
 func (d *StaticData) AutoRegisterDepartment(name string) int32 {
 	d.mutex.Lock()
@@ -77,6 +77,7 @@ func TestData_GetProposalStatusNameByID(t *testing.T) {
 		{"待审核状态", 1, "pending"},
 		{"已通过状态", 2, "approved"},
 		{"已拒绝状态", 3, "rejected"},
+		{"待补充信息状态", 4, "needsInfo"},
 		{"不存在的状态", 999, "未知提案状态"},
 	}
 
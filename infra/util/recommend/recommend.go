@@ -0,0 +1,175 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommend 基于本地信号的可解释推荐打分
+// 各类信号按权重累加到候选项上，同时记录每条信号的来源，用于向用户解释推荐理由
+package recommend
+
+import (
+	"math"
+	"sort"
+)
+
+// Reason 一条推荐理由，Kind 为信号类型，Subject 为具体对象（如搜索词、院系名）
+type Reason struct {
+	Kind    string
+	Subject string
+	Score   float64
+}
+
+// Item 候选项的累计得分和理由
+type Item struct {
+	ID      string
+	Score   float64
+	Reasons []*Reason
+}
+
+// Ranker 累加候选项得分，非并发安全
+type Ranker struct {
+	items    map[string]*Item
+	excluded map[string]bool
+}
+
+func NewRanker() *Ranker {
+	return &Ranker{
+		items:    make(map[string]*Item),
+		excluded: make(map[string]bool),
+	}
+}
+
+// Exclude 排除候选项，已有得分被丢弃，之后的加分也会被忽略
+func (r *Ranker) Exclude(ids ...string) {
+	for _, id := range ids {
+		r.excluded[id] = true
+		delete(r.items, id)
+	}
+}
+
+// Add 为候选项加分，同类型同对象的理由合并为一条
+func (r *Ranker) Add(id string, score float64, kind, subject string) {
+	if id == "" || score <= 0 || math.IsInf(score, 0) || r.excluded[id] {
+		return
+	}
+	item, ok := r.items[id]
+	if !ok {
+		item = &Item{ID: id}
+		r.items[id] = item
+	}
+	item.Score += score
+	for _, reason := range item.Reasons {
+		if reason.Kind == kind && reason.Subject == subject {
+			reason.Score += score
+			return
+		}
+	}
+	item.Reasons = append(item.Reasons, &Reason{Kind: kind, Subject: subject, Score: score})
+}
+
+// Fill 仅为尚未出现的候选项加分，用于以热门内容补足个性化结果
+func (r *Ranker) Fill(id string, score float64, kind, subject string) {
+	if _, ok := r.items[id]; ok {
+		return
+	}
+	r.Add(id, score, kind, subject)
+}
+
+// Len 返回候选项数量
+func (r *Ranker) Len() int {
+	return len(r.items)
+}
+
+// Top 返回得分最高的 n 个候选项，n <= 0 时返回全部
+// 同分按ID排序保证结果稳定，每个候选项的理由按贡献从大到小排列
+func (r *Ranker) Top(n int) []*Item {
+	items := make([]*Item, 0, len(r.items))
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].ID < items[j].ID
+	})
+	if n > 0 && len(items) > n {
+		items = items[:n]
+	}
+	for _, item := range items {
+		sort.SliceStable(item.Reasons, func(i, j int) bool {
+			return item.Reasons[i].Score > item.Reasons[j].Score
+		})
+	}
+	return items
+}
+
+// Decay 第 rank 个（从 0 开始）信号的位置衰减权重，1/log2(rank+2)
+func Decay(rank int) float64 {
+	if rank < 0 {
+		rank = 0
+	}
+	return 1 / math.Log2(float64(rank)+2)
+}
+
+// Jaccard 两个集合的 Jaccard 相似度，重复元素按一个计，任一集合为空时为 0
+func Jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, x := range a {
+		set[x] = true
+	}
+	var inter int
+	union := len(set)
+	seen := make(map[string]bool, len(b))
+	for _, x := range b {
+		if seen[x] {
+			continue
+		}
+		seen[x] = true
+		if set[x] {
+			inter++
+		} else {
+			union++
+		}
+	}
+	return float64(inter) / float64(union)
+}
+
+// Neighbor 相似用户及其相似度
+type Neighbor struct {
+	ID         string
+	Similarity float64
+}
+
+// Neighbors 按 Jaccard 相似度从 others 中选出与 self 最相似的 k 个，k <= 0 时不限数量
+// 相似度低于 minSim 的忽略，同分按ID排序
+func Neighbors(self []string, others map[string][]string, k int, minSim float64) []Neighbor {
+	neighbors := make([]Neighbor, 0, len(others))
+	for id, set := range others {
+		if sim := Jaccard(self, set); sim > 0 && sim >= minSim {
+			neighbors = append(neighbors, Neighbor{ID: id, Similarity: sim})
+		}
+	}
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].Similarity != neighbors[j].Similarity {
+			return neighbors[i].Similarity > neighbors[j].Similarity
+		}
+		return neighbors[i].ID < neighbors[j].ID
+	})
+	if k > 0 && len(neighbors) > k {
+		neighbors = neighbors[:k]
+	}
+	return neighbors
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommend
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRanker(t *testing.T) {
+	r := NewRanker()
+	r.Add("c1", 1, "search", "Python")
+	r.Add("c1", 0.5, "search", "Python")
+	r.Add("c1", 2, "department", "计算机学院")
+	r.Add("c2", 3, "similar", "")
+	r.Add("c3", 3, "similar", "")
+	r.Add("c4", 10, "search", "Java")
+	r.Add("c5", 0, "search", "Go") // 非正分不计入
+	r.Exclude("c4")
+	r.Add("c4", 10, "search", "Java")
+
+	if r.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", r.Len())
+	}
+	top := r.Top(0)
+	want := []string{"c1", "c2", "c3"}
+	for i, item := range top {
+		if item.ID != want[i] {
+			t.Fatalf("Top order = %v, want %v", top, want)
+		}
+	}
+	c1 := top[0]
+	if c1.Score != 3.5 || len(c1.Reasons) != 2 {
+		t.Fatalf("c1 = %+v", c1)
+	}
+	if c1.Reasons[0].Kind != "department" || c1.Reasons[1].Score != 1.5 {
+		t.Errorf("c1 reasons not merged or sorted: %+v %+v", c1.Reasons[0], c1.Reasons[1])
+	}
+
+	if got := r.Top(1); len(got) != 1 || got[0].ID != "c1" {
+		t.Errorf("Top(1) = %v", got)
+	}
+}
+
+func TestRankerFill(t *testing.T) {
+	r := NewRanker()
+	r.Add("c1", 1, "search", "Python")
+	r.Exclude("c2")
+	for _, id := range []string{"c1", "c2", "c3"} {
+		r.Fill(id, 0.1, "popular", "")
+	}
+	top := r.Top(0)
+	if len(top) != 2 || top[0].ID != "c1" || top[1].ID != "c3" {
+		t.Fatalf("Top = %v", top)
+	}
+	if len(top[0].Reasons) != 1 || top[0].Reasons[0].Kind != "search" {
+		t.Errorf("Fill changed existing item: %+v", top[0].Reasons)
+	}
+}
+
+func TestDecay(t *testing.T) {
+	if Decay(0) != 1 || Decay(-1) != 1 {
+		t.Errorf("Decay(0) = %v", Decay(0))
+	}
+	if math.Abs(Decay(2)-0.5) > 1e-9 {
+		t.Errorf("Decay(2) = %v, want 0.5", Decay(2))
+	}
+	for i := 0; i < 10; i++ {
+		if Decay(i+1) >= Decay(i) {
+			t.Fatalf("Decay not decreasing at %d", i)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{nil, []string{"x"}, 0},
+		{[]string{"x"}, nil, 0},
+		{[]string{"x", "y"}, []string{"x", "y"}, 1},
+		{[]string{"x", "y"}, []string{"y", "z"}, 1.0 / 3},
+		{[]string{"x", "x", "y"}, []string{"y", "y"}, 0.5},
+		{[]string{"x"}, []string{"z"}, 0},
+	}
+	for _, tt := range tests {
+		if got := Jaccard(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Jaccard(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	self := []string{"a", "b", "c"}
+	others := map[string][]string{
+		"u1": {"a", "b", "c"},
+		"u2": {"a", "d"},
+		"u3": {"a", "b"},
+		"u4": {"x", "y"},
+		"u5": {"b", "d"},
+	}
+	got := Neighbors(self, others, 0, 0)
+	want := []string{"u1", "u3", "u2", "u5"}
+	if len(got) != len(want) {
+		t.Fatalf("Neighbors = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].ID != want[i] {
+			t.Fatalf("Neighbors = %v, want %v", got, want)
+		}
+	}
+
+	if got = Neighbors(self, others, 2, 0); len(got) != 2 || got[1].ID != "u3" {
+		t.Errorf("Neighbors k=2 = %v", got)
+	}
+	if got = Neighbors(self, others, 0, 0.5); len(got) != 2 {
+		t.Errorf("Neighbors minSim=0.5 = %v", got)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package searchkey 为中文名称生成拼音、首字母与规范化名称，以及用于前缀匹配的拼音后缀
+package searchkey
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+	"github.com/mozillazg/go-pinyin"
+)
+
+// MaxQueryLen 参与匹配的查询最大长度，超出部分被截断
+const MaxQueryLen = 32
+
+// MaxNameLen 生成搜索键的名称最大长度（规范化后的字符数），超出部分被截断，
+// 音节数随之不超过该值，避免音节后缀随名称长度平方增长撑大文档和多键索引
+const MaxNameLen = 32
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Normalize 转为小写、全角转半角，并去掉空白和标点
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r, ok := NormalizeRune(r); ok {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NormalizeRune 对单个字符做 Normalize 的转换，第二个返回值为 false 表示该字符应被去掉
+func NormalizeRune(r rune) (rune, bool) {
+	if r >= 0xFF01 && r <= 0xFF5E {
+		r -= 0xFEE0
+	}
+	r = unicode.ToLower(r)
+	return r, unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// Syllable 规范化名称中的一个音节，[Start, End) 为它在规范化名称中的字符区间
+type Syllable struct {
+	Pinyin     string
+	Start, End int
+}
+
+// Syllables 将规范化名称切分为音节：每个汉字是一个音节，连续的字母数字作为一个音节，
+// 没有拼音的汉字被跳过
+func Syllables(normalized string) []Syllable {
+	var syllables []Syllable
+	var word []rune
+	start := 0
+	flush := func(end int) {
+		if len(word) > 0 {
+			syllables = append(syllables, Syllable{Pinyin: string(word), Start: start, End: end})
+			word = word[:0]
+		}
+	}
+	i := 0
+	for _, r := range normalized {
+		if !unicode.Is(unicode.Han, r) {
+			if len(word) == 0 {
+				start = i
+			}
+			word = append(word, r)
+			i++
+			continue
+		}
+		flush(i)
+		if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 {
+			syllables = append(syllables, Syllable{Pinyin: py[0], Start: i, End: i + 1})
+		}
+		i++
+	}
+	flush(i)
+	return syllables
+}
+
+// Build 生成名称的搜索键，规范化后超过 MaxNameLen 个字符的名称先被截断
+// 每个汉字是一个音节，连续的字母数字作为一个音节，如 "C语言程序设计" -> "c yu yan cheng xu she ji"
+func Build(name string) *model.SearchKeys {
+	normalized := Normalize(name)
+	if runes := []rune(normalized); len(runes) > MaxNameLen {
+		normalized = string(runes[:MaxNameLen])
+	}
+	var syllables []string
+	for _, syl := range Syllables(normalized) {
+		syllables = append(syllables, syl.Pinyin)
+	}
+
+	var initials strings.Builder
+	for _, s := range syllables {
+		for _, r := range s {
+			initials.WriteRune(r)
+			break
+		}
+	}
+	return &model.SearchKeys{
+		Pinyin:     strings.Join(syllables, " "),
+		Initials:   initials.String(),
+		Normalized: normalized,
+		Grams:      Grams(normalized),
+		Suffixes:   Suffixes(syllables),
+	}
+}
+
+// Suffixes 生成从每个音节开始的全拼后缀和首字母后缀（均不含空格），去重后作为前缀匹配的索引键
+// 如 "gao deng shu xue" -> "gaodengshuxue","dengshuxue","shuxue","xue","gdsx","dsx","sx","x"
+func Suffixes(syllables []string) []string {
+	seen := make(map[string]struct{}, 2*len(syllables))
+	suffixes := make([]string, 0, 2*len(syllables))
+	add := func(s string) {
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			suffixes = append(suffixes, s)
+		}
+	}
+	initials := make([]rune, 0, len(syllables))
+	for _, s := range syllables {
+		for _, r := range s {
+			initials = append(initials, r)
+			break
+		}
+	}
+	for i := range syllables {
+		add(strings.Join(syllables[i:], ""))
+	}
+	for i := range initials {
+		add(string(initials[i:]))
+	}
+	return suffixes
+}
+
+// Grams 将规范化后的名称切分为去重的一元和二元片段，作为索引键
+func Grams(normalized string) []string {
+	runes := []rune(normalized)
+	seen := make(map[string]struct{}, 2*len(runes))
+	grams := make([]string, 0, 2*len(runes))
+	add := func(g string) {
+		if _, ok := seen[g]; !ok {
+			seen[g] = struct{}{}
+			grams = append(grams, g)
+		}
+	}
+	for i := range runes {
+		add(string(runes[i]))
+		if i+1 < len(runes) {
+			add(string(runes[i : i+2]))
+		}
+	}
+	return grams
+}
+
+// Query 规范化查询并截断到 MaxQueryLen 个字符，返回规范化查询及其索引片段
+// 单个字符查询一元片段，否则查询全部二元片段；规范化后为空时返回空
+func Query(query string) (string, []string) {
+	runes := []rune(Normalize(query))
+	if len(runes) > MaxQueryLen {
+		runes = runes[:MaxQueryLen]
+	}
+	switch len(runes) {
+	case 0:
+		return "", nil
+	case 1:
+		return string(runes), []string{string(runes)}
+	}
+	var grams []string
+	seen := make(map[string]struct{}, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		g := string(runes[i : i+2])
+		if _, ok := seen[g]; !ok {
+			seen[g] = struct{}{}
+			grams = append(grams, g)
+		}
+	}
+	return string(runes), grams
+}
+
+// PinyinQuery 规范化拼音或首字母查询并截断到 MaxQueryLen 个字符，用于对 SearchKeys.Suffixes 做前缀匹配
+// 全拼（如 "shuxue"）或首字母（如 "sx"）都能匹配名称中从某个音节开始的部分，但不支持两者混写。
+// 查询规范化后为空或包含小写字母和数字以外的字符时返回空字符串。
+func PinyinQuery(query string) string {
+	runes := []rune(Normalize(query))
+	if len(runes) > MaxQueryLen {
+		runes = runes[:MaxQueryLen]
+	}
+	for _, r := range runes {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,369 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchkey
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name                         string
+		pinyin, initials, normalized string
+	}{
+		{"高等数学", "gao deng shu xue", "gdsx", "高等数学"},
+		{"C语言程序设计", "c yu yan cheng xu she ji", "cyycxsj", "c语言程序设计"},
+		{"大学英语（Ⅱ）", "da xue ying yu ⅱ", "dxyyⅱ", "大学英语ⅱ"},
+		{"Python 数据分析", "python shu ju fen xi", "psjfx", "python数据分析"},
+	}
+	for _, tt := range tests {
+		keys := Build(tt.name)
+		if keys.Pinyin != tt.pinyin || keys.Initials != tt.initials || keys.Normalized != tt.normalized {
+			t.Errorf("Build(%q) = %+v, want {%q %q %q}", tt.name, keys, tt.pinyin, tt.initials, tt.normalized)
+		}
+	}
+}
+
+func TestPinyinQuery(t *testing.T) {
+	suffixes := Build("高等数学").Suffixes
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"gs", false},
+		{"gdsx", true},
+		{"gd", true},
+		{"sx", true},
+		{"gaodeng", true},
+		{"GaoDengShuXue", true},
+		{"shuxue", true},
+		{"shux", true},
+		{"xs", false},
+		{"gaoz", false},
+		{"engs", false},
+		{"gaos", false},
+	}
+	for _, tt := range tests {
+		py := PinyinQuery(tt.query)
+		if py == "" {
+			t.Fatalf("PinyinQuery(%q) is empty", tt.query)
+		}
+		re := regexp.MustCompile("^" + regexp.QuoteMeta(py))
+		got := false
+		for _, s := range suffixes {
+			got = got || re.MatchString(s)
+		}
+		if got != tt.want {
+			t.Errorf("PinyinQuery(%q) = %q, match = %v, want %v", tt.query, py, got, tt.want)
+		}
+	}
+}
+
+func TestPinyinQueryRejectsNonPinyin(t *testing.T) {
+	for _, q := range []string{"", "高数", "gao 数", "数学"} {
+		if py := PinyinQuery(q); py != "" {
+			t.Errorf("PinyinQuery(%q) = %q, want empty", q, py)
+		}
+	}
+}
+
+func TestBuildIndexKeys(t *testing.T) {
+	keys := Build("高等数学")
+	wantGrams := []string{"高", "高等", "等", "等数", "数", "数学", "学"}
+	if !reflect.DeepEqual(keys.Grams, wantGrams) {
+		t.Errorf("Grams = %q, want %q", keys.Grams, wantGrams)
+	}
+	wantSuffixes := []string{"gaodengshuxue", "dengshuxue", "shuxue", "xue", "gdsx", "dsx", "sx", "x"}
+	if !reflect.DeepEqual(keys.Suffixes, wantSuffixes) {
+		t.Errorf("Suffixes = %q, want %q", keys.Suffixes, wantSuffixes)
+	}
+}
+
+func TestBuildTruncatesLongName(t *testing.T) {
+	keys := Build(strings.Repeat("高等数学", 1000))
+	if n := len([]rune(keys.Normalized)); n != MaxNameLen {
+		t.Errorf("Normalized length = %d, want %d", n, MaxNameLen)
+	}
+	if len(keys.Suffixes) > 2*MaxNameLen {
+		t.Errorf("Suffixes count = %d, want <= %d", len(keys.Suffixes), 2*MaxNameLen)
+	}
+	if len(keys.Grams) > 2*MaxNameLen {
+		t.Errorf("Grams count = %d, want <= %d", len(keys.Grams), 2*MaxNameLen)
+	}
+
+	// 一个字母数字音节也不会超过截断后的名称
+	keys = Build(strings.Repeat("a", 10000))
+	if len(keys.Pinyin) > MaxNameLen {
+		t.Errorf("Pinyin length = %d, want <= %d", len(keys.Pinyin), MaxNameLen)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		norm  string
+		grams []string
+	}{
+		{"", "", nil},
+		{"  ", "", nil},
+		{"数", "数", []string{"数"}},
+		{"高等数学", "高等数学", []string{"高等", "等数", "数学"}},
+		{"哈哈哈", "哈哈哈", []string{"哈哈"}},
+		{"Ｃ 语言", "c语言", []string{"c语", "语言"}},
+	}
+	for _, tt := range tests {
+		norm, grams := Query(tt.query)
+		if norm != tt.norm || !reflect.DeepEqual(grams, tt.grams) {
+			t.Errorf("Query(%q) = %q, %q, want %q, %q", tt.query, norm, grams, tt.norm, tt.grams)
+		}
+	}
+}
+
+// 索引片段必须是 Build 生成片段的子集，否则名称中的子串会查不到
+func TestQueryGramsSubsetOfBuild(t *testing.T) {
+	keys := Build("C语言程序设计（实验）")
+	index := make(map[string]bool, len(keys.Grams))
+	for _, g := range keys.Grams {
+		index[g] = true
+	}
+	for _, q := range []string{"c", "语言", "程序设计", "c语言程序", "设计实验", "（实验）"} {
+		norm, grams := Query(q)
+		if !strings.Contains(keys.Normalized, norm) {
+			t.Fatalf("%q is not a substring of %q", norm, keys.Normalized)
+		}
+		for _, g := range grams {
+			if !index[g] {
+				t.Errorf("Query(%q) gram %q not in index", q, g)
+			}
+		}
+	}
+}
+
+func TestAdversarialInput(t *testing.T) {
+	inputs := []string{
+		".*",
+		"(a+)+$",
+		"^$",
+		`\`,
+		"[",
+		"a|b",
+		"{\"$where\": \"sleep(1000)\"}",
+		"$ne",
+		"\x00",
+		"\u200b",
+		strings.Repeat("a", 10000),
+		strings.Repeat("(.*)", 1000),
+		strings.Repeat("数", 10000),
+	}
+	for _, in := range inputs {
+		norm, grams := Query(in)
+		if n := len([]rune(norm)); n > MaxQueryLen {
+			t.Errorf("Query(%.20q) normalized length = %d, want <= %d", in, n, MaxQueryLen)
+		}
+		if len(grams) > MaxQueryLen {
+			t.Errorf("Query(%.20q) returned %d grams, want <= %d", in, len(grams), MaxQueryLen)
+		}
+		if regexp.QuoteMeta(norm) != norm {
+			t.Errorf("Query(%.20q) normalized = %q, contains regex metacharacters", in, norm)
+		}
+
+		py := PinyinQuery(in)
+		if n := len([]rune(py)); n > MaxQueryLen {
+			t.Errorf("PinyinQuery(%.20q) length = %d, want <= %d", in, n, MaxQueryLen)
+		}
+		if regexp.QuoteMeta(py) != py {
+			t.Errorf("PinyinQuery(%.20q) = %q, contains regex metacharacters", in, py)
+		}
+	}
+}
+
+// Go 的 regexp 不回溯，无法暴露 Mongo（PCRE）上的灾难性回溯，这里用回溯匹配器统计步数
+func TestPinyinPrefixWithBacktracking(t *testing.T) {
+	const budget = 1000000
+
+	// 旧实现在查询字符之间插入嵌套可选分组，确认匹配器能暴露这类正则的回溯
+	const jump = `(?:[a-z0-9]* (?:[a-z0-9]+ )*)?`
+	old := "^" + strings.Join(strings.Split(strings.Repeat("a", 12)+"z", ""), jump)
+	if _, exceeded := backtrackMatch(old, strings.Repeat("a ", 40), budget); !exceeded {
+		t.Fatalf("old pattern did not exceed %d steps", budget)
+	}
+
+	names := []string{
+		strings.Repeat("啊", 10000),
+		strings.Repeat("a", 10000),
+		strings.Repeat("a 啊 ", 5000),
+		strings.Repeat("高等数学", 1000),
+	}
+	queries := []string{
+		strings.Repeat("a", 100) + "z",
+		strings.Repeat("aa", 100),
+		"gdsx",
+		strings.Repeat("(a+)+", 100),
+	}
+	for _, name := range names {
+		for _, suffix := range Build(name).Suffixes {
+			for _, q := range queries {
+				py := PinyinQuery(q)
+				if py == "" {
+					continue
+				}
+				pattern := "^" + regexp.QuoteMeta(py)
+				steps, exceeded := backtrackMatch(pattern, suffix, budget)
+				if limit := 4 * (len(pattern) + len(suffix) + 1); exceeded || steps > limit {
+					t.Fatalf("pattern %.20q on %.20q took %d steps, want <= %d", pattern, suffix, steps, limit)
+				}
+			}
+		}
+	}
+}
+
+// btNode 回溯匹配器的语法节点，支持字面量、.、字符集、^、非捕获分组、| 以及 * + ? 量词
+type btNode struct {
+	kind     byte // 'c' 字面量, '.' 任意字符, '[' 字符集, '^' 开头, '(' 分组
+	r        rune
+	ranges   [][2]rune
+	alts     [][]*btNode
+	min, max int // max 为 -1 表示不限
+}
+
+func btParse(p []rune, i int) ([][]*btNode, int) {
+	alts := [][]*btNode{{}}
+	for i < len(p) {
+		cur := &alts[len(alts)-1]
+		switch c := p[i]; c {
+		case '|':
+			alts = append(alts, []*btNode{})
+			i++
+		case ')':
+			return alts, i
+		case '(':
+			sub, j := btParse(p, i+3) // 只支持 (?:
+			*cur = append(*cur, &btNode{kind: '(', alts: sub, min: 1, max: 1})
+			i = j + 1
+		case '[':
+			n := &btNode{kind: '[', min: 1, max: 1}
+			for i++; p[i] != ']'; i++ {
+				if i+2 < len(p) && p[i+1] == '-' && p[i+2] != ']' {
+					n.ranges = append(n.ranges, [2]rune{p[i], p[i+2]})
+					i += 2
+				} else {
+					n.ranges = append(n.ranges, [2]rune{p[i], p[i]})
+				}
+			}
+			*cur = append(*cur, n)
+			i++
+		case '*', '+', '?':
+			last := (*cur)[len(*cur)-1]
+			switch c {
+			case '*':
+				last.min, last.max = 0, -1
+			case '+':
+				last.min, last.max = 1, -1
+			case '?':
+				last.min, last.max = 0, 1
+			}
+			i++
+		case '\\':
+			*cur = append(*cur, &btNode{kind: 'c', r: p[i+1], min: 1, max: 1})
+			i += 2
+		case '.', '^':
+			*cur = append(*cur, &btNode{kind: byte(c), min: 1, max: 1})
+			i++
+		default:
+			*cur = append(*cur, &btNode{kind: 'c', r: c, min: 1, max: 1})
+			i++
+		}
+	}
+	return alts, i
+}
+
+type btMatcher struct {
+	input        []rune
+	steps, limit int
+}
+
+// backtrackMatch 以回溯方式在 input 的每个起点尝试匹配，返回消耗的步数以及是否超出预算
+func backtrackMatch(pattern, input string, limit int) (int, bool) {
+	alts, _ := btParse([]rune(pattern), 0)
+	root := &btNode{kind: '(', alts: alts, min: 1, max: 1}
+	m := &btMatcher{input: []rune(input), limit: limit}
+	for start := 0; start <= len(m.input) && m.steps <= m.limit; start++ {
+		if m.seq([]*btNode{root}, 0, start, func(int) bool { return true }) {
+			break
+		}
+	}
+	return m.steps, m.steps > m.limit
+}
+
+func (m *btMatcher) seq(nodes []*btNode, i, pos int, k func(int) bool) bool {
+	if i == len(nodes) {
+		return k(pos)
+	}
+	return m.repeat(nodes[i], 0, pos, func(p int) bool { return m.seq(nodes, i+1, p, k) })
+}
+
+func (m *btMatcher) repeat(n *btNode, count, pos int, k func(int) bool) bool {
+	if n.max == -1 || count < n.max {
+		if m.one(n, pos, func(p int) bool {
+			if p == pos && count >= n.min {
+				return false
+			}
+			return m.repeat(n, count+1, p, k)
+		}) {
+			return true
+		}
+	}
+	return count >= n.min && k(pos)
+}
+
+func (m *btMatcher) one(n *btNode, pos int, k func(int) bool) bool {
+	m.steps++
+	if m.steps > m.limit {
+		return false
+	}
+	switch n.kind {
+	case '^':
+		return pos == 0 && k(pos)
+	case '(':
+		for _, alt := range n.alts {
+			if m.seq(alt, 0, pos, k) {
+				return true
+			}
+		}
+		return false
+	}
+	if pos >= len(m.input) {
+		return false
+	}
+	r := m.input[pos]
+	switch n.kind {
+	case 'c':
+		if r != n.r {
+			return false
+		}
+	case '[':
+		ok := false
+		for _, rg := range n.ranges {
+			ok = ok || (r >= rg[0] && r <= rg[1])
+		}
+		if !ok {
+			return false
+		}
+	}
+	return k(pos + 1)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semester 学年学期的计算
+// 学期记为 "2024-2025-1" 形式：8 月至次年 1 月为第一学期（秋季），2 月至 7 月为第二学期（春季）
+package semester
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// MonthLayout 月份的格式
+const MonthLayout = "2006-01"
+
+var pattern = regexp.MustCompile(`^(\d{4})-(\d{4})-[12]$`)
+
+// Of 返回时间所属的学期
+func Of(t time.Time) string {
+	year, month := t.Year(), t.Month()
+	switch {
+	case month >= time.August:
+		return fmt.Sprintf("%d-%d-1", year, year+1)
+	case month == time.January:
+		return fmt.Sprintf("%d-%d-1", year-1, year)
+	default:
+		return fmt.Sprintf("%d-%d-2", year-1, year)
+	}
+}
+
+// OfMonth 返回 "2025-03" 形式月份所属的学期
+func OfMonth(month string) (string, error) {
+	t, err := time.Parse(MonthLayout, month)
+	if err != nil {
+		return "", err
+	}
+	return Of(t), nil
+}
+
+// Valid 判断是否为合法的学期，要求后一年份恰好比前一年份大 1
+// 合法学期按字符串比较即为时间先后
+func Valid(s string) bool {
+	m := pattern.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	start, _ := strconv.Atoi(m[1])
+	end, _ := strconv.Atoi(m[2])
+	return end == start+1
+}
+
+// WeekOne 返回学期第一周的周一零点，未单独配置校历时使用
+// 第一学期为 9 月 1 日及之后的第一个周一，第二学期为 2 月 20 日及之后的第一个周一
+func WeekOne(s string, loc *time.Location) (time.Time, error) {
+	m := pattern.FindStringSubmatch(s)
+	if m == nil || !Valid(s) {
+		return time.Time{}, fmt.Errorf("invalid semester %q", s)
+	}
+	start, _ := strconv.Atoi(m[1])
+	day := time.Date(start, time.September, 1, 0, 0, 0, 0, loc)
+	if s[len(s)-1] == '2' {
+		day = time.Date(start+1, time.February, 20, 0, 0, 0, 0, loc)
+	}
+	return day.AddDate(0, 0, (int(time.Monday)-int(day.Weekday())+7)%7), nil
+}
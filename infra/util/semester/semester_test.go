@@ -0,0 +1,96 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semester
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		month string
+		want  string
+	}{
+		{"2024-08", "2024-2025-1"},
+		{"2024-12", "2024-2025-1"},
+		{"2025-01", "2024-2025-1"},
+		{"2025-02", "2024-2025-2"},
+		{"2025-07", "2024-2025-2"},
+		{"2025-09", "2025-2026-1"},
+	}
+	for _, tt := range tests {
+		got, err := OfMonth(tt.month)
+		if err != nil {
+			t.Fatalf("OfMonth(%q) error: %v", tt.month, err)
+		}
+		if got != tt.want {
+			t.Errorf("OfMonth(%q) = %q, want %q", tt.month, got, tt.want)
+		}
+	}
+
+	if got := Of(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)); got != "2024-2025-2" {
+		t.Errorf("Of(2025-03-01) = %q", got)
+	}
+}
+
+func TestOfMonthInvalid(t *testing.T) {
+	for _, m := range []string{"", "2025-13", "2025/03", "abc"} {
+		if _, err := OfMonth(m); err == nil {
+			t.Errorf("OfMonth(%q) expected error", m)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, s := range []string{"2024-2025-1", "2024-2025-2", "1999-2000-1"} {
+		if !Valid(s) {
+			t.Errorf("Valid(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"", "2024-2025-3", "2024-2026-1", "2025-2024-1", "2024-2025", "24-25-1", " 2024-2025-1", "2024-2025-1\n"} {
+		if Valid(s) {
+			t.Errorf("Valid(%q) = true", s)
+		}
+	}
+	if !("2024-2025-2" < "2025-2026-1" && "2024-2025-1" < "2024-2025-2") {
+		t.Error("semesters should sort as strings")
+	}
+}
+
+func TestWeekOne(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		semester string
+		want     time.Time
+	}{
+		{"2024-2025-1", time.Date(2024, time.September, 2, 0, 0, 0, 0, loc)},
+		{"2024-2025-2", time.Date(2025, time.February, 24, 0, 0, 0, 0, loc)},
+		{"2025-2026-1", time.Date(2025, time.September, 1, 0, 0, 0, 0, loc)},
+		{"2025-2026-2", time.Date(2026, time.February, 23, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		got, err := WeekOne(tt.semester, loc)
+		if err != nil {
+			t.Fatalf("WeekOne(%q) error: %v", tt.semester, err)
+		}
+		if !got.Equal(tt.want) || got.Weekday() != time.Monday {
+			t.Errorf("WeekOne(%q) = %v, want %v", tt.semester, got, tt.want)
+		}
+	}
+	if _, err := WeekOne("2024-2026-1", loc); err == nil {
+		t.Error("WeekOne expected error for invalid semester")
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sheet 解析 CSV 与 XLSX 表格文件
+//
+// XLSX 只读取第一个工作表的单元格文本，不处理公式、样式和日期格式，
+// 足以满足教务处课程表等纯文本表格的导入。
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedFormat 文件扩展名既不是 .csv 也不是 .xlsx
+var ErrUnsupportedFormat = errors.New("sheet: unsupported file format")
+
+// Read 根据文件扩展名解析表格，返回所有行
+func Read(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ReadCSV(bytes.NewReader(data))
+	case ".xlsx":
+		return ReadXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// ReadCSV 解析 CSV，去掉 Excel 导出时附带的 UTF-8 BOM，允许各行列数不同
+func ReadCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+// ReadXLSX 解析 XLSX 的第一个工作表
+func ReadXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	shared, err := readSharedStrings(files)
+	if err != nil {
+		return nil, err
+	}
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("sheet: worksheet %s not found", sheetPath)
+	}
+
+	var ws struct {
+		Rows []struct {
+			Index int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string     `xml:"r,attr"`
+				Type   string     `xml:"t,attr"`
+				Value  string     `xml:"v"`
+				Inline inlineText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err = decodeXML(f, &ws); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		// 空行不会出现在 sheetData 中，按行号补齐
+		for row.Index > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var cells []string
+		for i, c := range row.Cells {
+			col := i
+			if c.Ref != "" {
+				if col, err = columnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(cells) < col {
+				cells = append(cells, "")
+			}
+			var text string
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, fmt.Errorf("sheet: invalid shared string index %q in %s", c.Value, c.Ref)
+				}
+				text = shared[idx]
+			case "inlineStr":
+				text = c.Inline.String()
+			default:
+				text = c.Value
+			}
+			if col < len(cells) {
+				cells[col] = text
+			} else {
+				cells = append(cells, text)
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// inlineText 对应 <si> 与 <is> 元素，富文本会被拆成多个 <r><t>
+type inlineText struct {
+	Text string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (t inlineText) String() string {
+	return t.Text + strings.Join(t.Runs, "")
+}
+
+func readSharedStrings(files map[string]*zip.File) ([]string, error) {
+	f, ok := files["xl/sharedStrings.xml"]
+	if !ok {
+		return nil, nil
+	}
+	var sst struct {
+		Items []inlineText `xml:"si"`
+	}
+	if err := decodeXML(f, &sst); err != nil {
+		return nil, err
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.String()
+	}
+	return shared, nil
+}
+
+// firstSheetPath 通过 workbook.xml 与其关系文件找到第一个工作表的路径
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	wbFile, ok := files["xl/workbook.xml"]
+	relFile, relOk := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relOk {
+		return fallback, nil
+	}
+
+	var wb struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXML(wbFile, &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", errors.New("sheet: workbook has no sheets")
+	}
+
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXML(relFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// columnIndex 将 "C12" 这样的单元格引用转换为从 0 开始的列号
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("sheet: invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+func decodeXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	data := []byte("\ufeffname,code,teachers\n高等数学, MATH101,张三、李四\n大学英语\n")
+	rows, err := Read("catalog.CSV", data)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := [][]string{
+		{"name", "code", "teachers"},
+		{"高等数学", "MATH101", "张三、李四"},
+		{"大学英语"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read() = %v, want %v", rows, want)
+	}
+}
+
+func TestReadUnsupported(t *testing.T) {
+	if _, err := Read("catalog.xls", nil); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Read() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	files := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="课程" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="styles" Target="styles.xml"/>
+<Relationship Id="rId3" Type="worksheet" Target="worksheets/courses.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>name</t></si><si><t>code</t></si><si><r><t>高等</t></r><r><t>数学</t></r></si>
+</sst>`,
+		"xl/worksheets/courses.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>101</v></c><c r="D3" t="inlineStr"><is><t>张三</t></is></c></row>
+</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := Read("catalog.xlsx", buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := [][]string{
+		{"name", "code"},
+		nil,
+		{"高等数学", "", "101", "张三"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read() = %q, want %q", rows, want)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "AB3": 27}
+	for ref, want := range tests {
+		if got, err := columnIndex(ref); err != nil || got != want {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", ref, got, err, want)
+		}
+	}
+	if _, err := columnIndex("12"); err == nil {
+		t.Error("columnIndex(\"12\") want error")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timetable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+// DefaultPeriods 默认作息，每节课的起止时间
+var DefaultPeriods = []string{
+	"08:00-08:45", "08:55-09:40", "10:00-10:45", "10:55-11:40", "11:50-12:35",
+	"13:30-14:15", "14:25-15:10", "15:30-16:15", "16:25-17:10", "17:20-18:05",
+	"18:30-19:15", "19:25-20:10", "20:20-21:05", "21:15-22:00",
+}
+
+// Period 一节课的起止时间，为距当天零点的时长
+type Period struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParsePeriods 解析 "08:00-08:45" 形式的作息，每节必须在当天内且开始早于结束
+func ParsePeriods(specs []string) ([]Period, error) {
+	periods := make([]Period, 0, len(specs))
+	for i, spec := range specs {
+		start, end, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, fmt.Errorf("period %d: invalid %q", i+1, spec)
+		}
+		s, err := parseClock(start)
+		if err != nil {
+			return nil, fmt.Errorf("period %d: %w", i+1, err)
+		}
+		e, err := parseClock(end)
+		if err != nil {
+			return nil, fmt.Errorf("period %d: %w", i+1, err)
+		}
+		if s >= e {
+			return nil, fmt.Errorf("period %d: %q ends before it starts", i+1, spec)
+		}
+		periods = append(periods, Period{Start: s, End: e})
+	}
+	return periods, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Occurrence 时间段在某一周的一次上课
+type Occurrence struct {
+	Week  int32
+	Start time.Time
+	End   time.Time
+}
+
+// Occurrences 按第一周周一的零点展开时间段在学期内的每次上课，按时间升序
+func Occurrences(slot *model.TimeSlot, weekOne time.Time, periods []Period) ([]Occurrence, error) {
+	if err := Validate(slot); err != nil {
+		return nil, err
+	}
+	if int(slot.EndPeriod) > len(periods) {
+		return nil, fmt.Errorf("%w: period %d has no time", ErrInvalidSlot, slot.EndPeriod)
+	}
+	start, end := periods[slot.StartPeriod-1].Start, periods[slot.EndPeriod-1].End
+	weeks := Weeks(slot.Weeks)
+	occurrences := make([]Occurrence, 0, len(weeks))
+	for _, w := range weeks {
+		day := weekOne.AddDate(0, 0, int(w-1)*7+int(slot.Day-1))
+		occurrences = append(occurrences, Occurrence{Week: w, Start: day.Add(start), End: day.Add(end)})
+	}
+	return occurrences, nil
+}
+
+// Event 一个日程
+type Event struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+const icsTimeLayout = "20060102T150405Z"
+
+// WriteICS 以 iCalendar（RFC 5545）格式写出日历，时间统一转换为 UTC，stamp 为生成时间
+func WriteICS(w io.Writer, name string, events []*Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		writeFolded(bw, s)
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Meowpick//Semester Plan//CN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if name != "" {
+		line("X-WR-CALNAME:" + escapeText(name))
+	}
+	dtstamp := stamp.UTC().Format(icsTimeLayout)
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(e.UID))
+		line("DTSTAMP:" + dtstamp)
+		line("DTSTART:" + e.Start.UTC().Format(icsTimeLayout))
+		line("DTEND:" + e.End.UTC().Format(icsTimeLayout))
+		line("SUMMARY:" + escapeText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION:" + escapeText(e.Location))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText 转义 TEXT 类型属性值中的反斜杠、分号、逗号和换行
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// writeFolded 写出一行内容，超过 75 字节时在 UTF-8 字符边界折行，续行以空格开头
+func writeFolded(w *bufio.Writer, s string) {
+	const limit = 75
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // 续行开头的空格占一个字节
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timetable 课表时间段的校验、冲突检测、周课表网格以及日程展开与 iCalendar 导出
+// 星期从 1（周一）到 7（周日），节次和周次均从 1 开始，范围为闭区间
+package timetable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+const (
+	Days      = 7  // 一周的天数
+	MaxPeriod = 14 // 每天的最大节次
+	MaxWeek   = 30 // 每学期的最大周次
+)
+
+// 周次单双
+const (
+	ParityAll  int32 = 0
+	ParityOdd  int32 = 1
+	ParityEven int32 = 2
+)
+
+// ErrInvalidSlot 时间段不合法
+var ErrInvalidSlot = errors.New("invalid time slot")
+
+// Validate 校验时间段的星期、节次和周次范围，且至少覆盖一周
+func Validate(slot *model.TimeSlot) error {
+	if slot == nil {
+		return fmt.Errorf("%w: empty slot", ErrInvalidSlot)
+	}
+	if slot.Day < 1 || slot.Day > Days {
+		return fmt.Errorf("%w: day %d", ErrInvalidSlot, slot.Day)
+	}
+	if slot.StartPeriod < 1 || slot.StartPeriod > slot.EndPeriod || slot.EndPeriod > MaxPeriod {
+		return fmt.Errorf("%w: periods %d-%d", ErrInvalidSlot, slot.StartPeriod, slot.EndPeriod)
+	}
+	if len(slot.Weeks) == 0 {
+		return fmt.Errorf("%w: no weeks", ErrInvalidSlot)
+	}
+	for _, w := range slot.Weeks {
+		if w == nil || w.From < 1 || w.From > w.To || w.To > MaxWeek {
+			return fmt.Errorf("%w: weeks %s", ErrInvalidSlot, formatRange(w))
+		}
+		if w.Parity != ParityAll && w.Parity != ParityOdd && w.Parity != ParityEven {
+			return fmt.Errorf("%w: parity %d", ErrInvalidSlot, w.Parity)
+		}
+	}
+	if weekMask(slot.Weeks) == 0 {
+		return fmt.Errorf("%w: weeks %s cover no week", ErrInvalidSlot, FormatWeeks(slot.Weeks))
+	}
+	return nil
+}
+
+// Weeks 返回周次范围覆盖的全部周次，升序且不重复
+func Weeks(ranges []*model.WeekRange) []int32 {
+	return maskWeeks(weekMask(ranges))
+}
+
+// FormatWeeks 将周次范围格式化为 "1-8,10-16周"、"1-15(单)周" 的形式
+func FormatWeeks(ranges []*model.WeekRange) string {
+	parts := make([]string, 0, len(ranges))
+	for _, w := range ranges {
+		part := formatRange(w)
+		switch w.Parity {
+		case ParityOdd:
+			part += "(单)"
+		case ParityEven:
+			part += "(双)"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",") + "周"
+}
+
+// Entry 参与冲突检测和网格排布的条目，如一个课程开设
+type Entry struct {
+	ID    string
+	Slots []*model.TimeSlot
+}
+
+// Conflict 两个条目在同一天重叠节次、重叠周次上的冲突
+type Conflict struct {
+	A, B        string
+	Day         int32
+	StartPeriod int32
+	EndPeriod   int32
+	Weeks       []int32
+}
+
+// Conflicts 检测条目之间两两的时间冲突，同一条目内部的时间段不互相比较
+// 结果按 A、B 在输入中的先后排列，A 总在 B 之前
+func Conflicts(entries []*Entry) []*Conflict {
+	var conflicts []*Conflict
+	for i, a := range entries {
+		for _, b := range entries[i+1:] {
+			for _, sa := range a.Slots {
+				for _, sb := range b.Slots {
+					if c := overlap(sa, sb); c != nil {
+						c.A, c.B = a.ID, b.ID
+						conflicts = append(conflicts, c)
+					}
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// Grid 排布周课表网格，grid[day-1][period-1] 为该节次有课的条目ID，按输入顺序
+// 只要有一周在该节次上课即计入，单元格有多个ID时表示存在冲突
+func Grid(entries []*Entry) [][][]string {
+	grid := make([][][]string, Days)
+	for d := range grid {
+		grid[d] = make([][]string, MaxPeriod)
+		for p := range grid[d] {
+			grid[d][p] = []string{}
+		}
+	}
+	for _, e := range entries {
+		for _, slot := range e.Slots {
+			if Validate(slot) != nil {
+				continue
+			}
+			for p := slot.StartPeriod; p <= slot.EndPeriod; p++ {
+				cell := grid[slot.Day-1][p-1]
+				if len(cell) == 0 || cell[len(cell)-1] != e.ID {
+					grid[slot.Day-1][p-1] = append(cell, e.ID)
+				}
+			}
+		}
+	}
+	return grid
+}
+
+// overlap 计算两个时间段的冲突，无冲突或时间段不合法时返回 nil
+func overlap(a, b *model.TimeSlot) *Conflict {
+	if a.Day != b.Day || Validate(a) != nil || Validate(b) != nil {
+		return nil
+	}
+	start, end := max(a.StartPeriod, b.StartPeriod), min(a.EndPeriod, b.EndPeriod)
+	if start > end {
+		return nil
+	}
+	mask := weekMask(a.Weeks) & weekMask(b.Weeks)
+	if mask == 0 {
+		return nil
+	}
+	return &Conflict{Day: a.Day, StartPeriod: start, EndPeriod: end, Weeks: maskWeeks(mask)}
+}
+
+// weekMask 将周次范围转换为位图，第 w 位表示第 w 周，超出范围的周次被忽略
+func weekMask(ranges []*model.WeekRange) uint64 {
+	var mask uint64
+	for _, r := range ranges {
+		if r == nil {
+			continue
+		}
+		for w := max(r.From, 1); w <= min(r.To, MaxWeek); w++ {
+			if (r.Parity == ParityOdd && w%2 == 0) || (r.Parity == ParityEven && w%2 == 1) {
+				continue
+			}
+			mask |= 1 << uint(w)
+		}
+	}
+	return mask
+}
+
+func maskWeeks(mask uint64) []int32 {
+	weeks := []int32{}
+	for w := int32(1); w <= MaxWeek; w++ {
+		if mask&(1<<uint(w)) != 0 {
+			weeks = append(weeks, w)
+		}
+	}
+	return weeks
+}
+
+func formatRange(w *model.WeekRange) string {
+	if w == nil {
+		return "<nil>"
+	}
+	if w.From == w.To {
+		return strconv.Itoa(int(w.From))
+	}
+	return strconv.Itoa(int(w.From)) + "-" + strconv.Itoa(int(w.To))
+}
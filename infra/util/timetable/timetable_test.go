@@ -0,0 +1,220 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timetable
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/model"
+)
+
+func slot(day, start, end int32, weeks ...*model.WeekRange) *model.TimeSlot {
+	return &model.TimeSlot{Day: day, StartPeriod: start, EndPeriod: end, Weeks: weeks}
+}
+
+func weeks(from, to, parity int32) *model.WeekRange {
+	return &model.WeekRange{From: from, To: to, Parity: parity}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []*model.TimeSlot{
+		slot(1, 1, 2, weeks(1, 16, ParityAll)),
+		slot(7, 14, 14, weeks(1, 1, ParityOdd)),
+		slot(3, 3, 5, weeks(1, 8, ParityAll), weeks(10, 16, ParityEven)),
+	}
+	for _, s := range valid {
+		if err := Validate(s); err != nil {
+			t.Errorf("Validate(%+v) = %v", s, err)
+		}
+	}
+
+	invalid := []*model.TimeSlot{
+		slot(0, 1, 2, weeks(1, 16, ParityAll)),
+		slot(8, 1, 2, weeks(1, 16, ParityAll)),
+		slot(1, 0, 2, weeks(1, 16, ParityAll)),
+		slot(1, 3, 2, weeks(1, 16, ParityAll)),
+		slot(1, 1, MaxPeriod+1, weeks(1, 16, ParityAll)),
+		slot(1, 1, 2),
+		slot(1, 1, 2, weeks(0, 16, ParityAll)),
+		slot(1, 1, 2, weeks(5, 4, ParityAll)),
+		slot(1, 1, 2, weeks(1, MaxWeek+1, ParityAll)),
+		slot(1, 1, 2, weeks(1, 16, 3)),
+		slot(1, 1, 2, weeks(2, 2, ParityOdd)), // 不覆盖任何一周
+		slot(1, 1, 2, nil),
+		nil,
+	}
+	for _, s := range invalid {
+		if err := Validate(s); !errors.Is(err, ErrInvalidSlot) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidSlot", s, err)
+		}
+	}
+}
+
+func TestWeeksAndFormat(t *testing.T) {
+	ranges := []*model.WeekRange{weeks(1, 5, ParityOdd), weeks(4, 6, ParityAll), weeks(10, 12, ParityEven)}
+	if got, want := Weeks(ranges), []int32{1, 3, 4, 5, 6, 10, 12}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Weeks = %v, want %v", got, want)
+	}
+	if got, want := FormatWeeks(ranges), "1-5(单),4-6,10-12(双)周"; got != want {
+		t.Errorf("FormatWeeks = %q, want %q", got, want)
+	}
+	if got := FormatWeeks([]*model.WeekRange{weeks(3, 3, ParityAll)}); got != "3周" {
+		t.Errorf("FormatWeeks single = %q", got)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	entries := []*Entry{
+		{ID: "a", Slots: []*model.TimeSlot{slot(1, 1, 2, weeks(1, 16, ParityAll)), slot(3, 3, 4, weeks(1, 16, ParityOdd))}},
+		{ID: "b", Slots: []*model.TimeSlot{slot(1, 2, 3, weeks(9, 18, ParityAll))}},  // 与 a 周一第 2 节、9-16 周冲突
+		{ID: "c", Slots: []*model.TimeSlot{slot(3, 3, 4, weeks(1, 16, ParityEven))}}, // 与 a 单双周错开
+		{ID: "d", Slots: []*model.TimeSlot{slot(2, 1, 2, weeks(1, 16, ParityAll))}},  // 不同天
+		{ID: "e", Slots: []*model.TimeSlot{slot(3, 4, 6, weeks(3, 3, ParityAll))}},   // 与 a 第 3 周第 4 节冲突
+	}
+	got := Conflicts(entries)
+	if len(got) != 2 {
+		t.Fatalf("Conflicts = %d, want 2: %+v", len(got), got)
+	}
+	ab := got[0]
+	if ab.A != "a" || ab.B != "b" || ab.Day != 1 || ab.StartPeriod != 2 || ab.EndPeriod != 2 {
+		t.Errorf("a-b conflict = %+v", ab)
+	}
+	if want := []int32{9, 10, 11, 12, 13, 14, 15, 16}; !reflect.DeepEqual(ab.Weeks, want) {
+		t.Errorf("a-b weeks = %v, want %v", ab.Weeks, want)
+	}
+	ae := got[1]
+	if ae.A != "a" || ae.B != "e" || ae.StartPeriod != 4 || ae.EndPeriod != 4 || !reflect.DeepEqual(ae.Weeks, []int32{3}) {
+		t.Errorf("a-e conflict = %+v", ae)
+	}
+
+	if got := Conflicts(nil); len(got) != 0 {
+		t.Errorf("Conflicts(nil) = %v", got)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	grid := Grid([]*Entry{
+		{ID: "a", Slots: []*model.TimeSlot{slot(1, 1, 2, weeks(1, 16, ParityAll)), slot(1, 2, 3, weeks(1, 16, ParityAll))}},
+		{ID: "b", Slots: []*model.TimeSlot{slot(1, 2, 2, weeks(1, 8, ParityAll))}},
+		{ID: "bad", Slots: []*model.TimeSlot{slot(9, 1, 1, weeks(1, 1, ParityAll))}},
+	})
+	if len(grid) != Days || len(grid[0]) != MaxPeriod {
+		t.Fatalf("grid size = %dx%d", len(grid), len(grid[0]))
+	}
+	if got := grid[0][0]; !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Mon 1 = %v", got)
+	}
+	if got := grid[0][1]; !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Mon 2 = %v", got)
+	}
+	if got := grid[0][2]; !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Mon 3 = %v", got)
+	}
+	if got := grid[6][13]; got == nil || len(got) != 0 {
+		t.Errorf("empty cell = %#v, want non-nil empty", got)
+	}
+}
+
+func TestParsePeriods(t *testing.T) {
+	periods, err := ParsePeriods(DefaultPeriods)
+	if err != nil {
+		t.Fatalf("ParsePeriods(DefaultPeriods) error: %v", err)
+	}
+	if len(periods) != MaxPeriod {
+		t.Errorf("default periods = %d, want %d", len(periods), MaxPeriod)
+	}
+	if periods[0].Start != 8*time.Hour || periods[0].End != 8*time.Hour+45*time.Minute {
+		t.Errorf("period 1 = %+v", periods[0])
+	}
+	for _, specs := range [][]string{{"08:00"}, {"8:00-x"}, {"09:00-08:00"}, {"25:00-26:00"}} {
+		if _, err := ParsePeriods(specs); err == nil {
+			t.Errorf("ParsePeriods(%q) expected error", specs)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	weekOne := time.Date(2024, time.September, 2, 0, 0, 0, 0, loc) // 周一
+	periods, _ := ParsePeriods(DefaultPeriods)
+
+	got, err := Occurrences(slot(3, 1, 2, weeks(1, 3, ParityOdd)), weekOne, periods)
+	if err != nil {
+		t.Fatalf("Occurrences error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Occurrences = %d, want 2", len(got))
+	}
+	if want := time.Date(2024, time.September, 4, 8, 0, 0, 0, loc); !got[0].Start.Equal(want) {
+		t.Errorf("week 1 start = %v, want %v", got[0].Start, want)
+	}
+	if want := time.Date(2024, time.September, 18, 9, 40, 0, 0, loc); got[1].Week != 3 || !got[1].End.Equal(want) {
+		t.Errorf("week 3 = %+v, want end %v", got[1], want)
+	}
+
+	if _, err = Occurrences(slot(1, 1, 3, weeks(1, 1, ParityAll)), weekOne, periods[:2]); err == nil {
+		t.Error("expected error for period without time")
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	var sb strings.Builder
+	err := WriteICS(&sb, "2024-2025-1 课表", []*Event{{
+		UID:         "o1-1@meowpick",
+		Summary:     "高等数学; 习题课, 第1周",
+		Location:    "A101",
+		Description: "教师：张三\n" + strings.Repeat("很长的描述", 20),
+		Start:       time.Date(2024, time.September, 2, 8, 0, 0, 0, loc),
+		End:         time.Date(2024, time.September, 2, 9, 40, 0, 0, loc),
+	}}, time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("WriteICS error: %v", err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:2024-2025-1 课表\r\n",
+		"DTSTAMP:20240801T000000Z\r\n",
+		"DTSTART:20240902T000000Z\r\n",
+		"DTEND:20240902T014000Z\r\n",
+		`SUMMARY:高等数学\; 习题课\, 第1周` + "\r\n",
+		"LOCATION:A101\r\n",
+		`DESCRIPTION:教师：张三\n`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+		if !strings.HasPrefix(line, " ") && !strings.Contains(line, ":") {
+			t.Errorf("malformed line %q", line)
+		}
+	}
+	// 折行后拼接应还原为完整的 UTF-8 文本
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, strings.Repeat("很长的描述", 20)) {
+		t.Error("folded description does not unfold to the original text")
+	}
+}
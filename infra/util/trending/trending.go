@@ -0,0 +1,74 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trending 按时间窗口分桶累计热度，查询时按桶的年龄指数衰减后合并
+//
+// 每个窗口的热度单独累计在一个桶中，桶在保留窗口数之后过期；
+// 第 i 个（0 为当前窗口）桶的权重为 0.5^(i*Window/HalfLife)。
+package trending
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxKeywordLen 参与热搜统计的关键词最大长度，超出部分截断
+const MaxKeywordLen = 32
+
+// Decay 分桶与衰减参数
+type Decay struct {
+	Window   time.Duration // 每个桶覆盖的时长
+	Windows  int           // 参与合并的桶数
+	HalfLife time.Duration // 热度减半所需时长
+}
+
+// Bucket 返回时间所在的桶编号
+func (d Decay) Bucket(t time.Time) int64 {
+	return t.Unix() / int64(d.Window/time.Second)
+}
+
+// Buckets 返回参与合并的桶编号，从当前窗口开始向前
+func (d Decay) Buckets(now time.Time) []int64 {
+	cur := d.Bucket(now)
+	buckets := make([]int64, d.Windows)
+	for i := range buckets {
+		buckets[i] = cur - int64(i)
+	}
+	return buckets
+}
+
+// Weights 返回与 Buckets 一一对应的衰减权重
+func (d Decay) Weights() []float64 {
+	weights := make([]float64, d.Windows)
+	for i := range weights {
+		weights[i] = math.Pow(0.5, float64(time.Duration(i)*d.Window)/float64(d.HalfLife))
+	}
+	return weights
+}
+
+// TTL 返回桶的过期时间，多保留一个窗口避免边界上的桶提前过期
+func (d Decay) TTL() time.Duration {
+	return time.Duration(d.Windows+1) * d.Window
+}
+
+// Keyword 规范化搜索关键词：去掉首尾空白、合并连续空白并转为小写，超长时按字符截断
+func Keyword(q string) string {
+	q = strings.ToLower(strings.Join(strings.Fields(q), " "))
+	if utf8.RuneCountInString(q) > MaxKeywordLen {
+		q = strings.TrimSpace(string([]rune(q)[:MaxKeywordLen]))
+	}
+	return q
+}
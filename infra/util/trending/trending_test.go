@@ -0,0 +1,80 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trending
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuckets(t *testing.T) {
+	d := Decay{Window: time.Hour, Windows: 3, HalfLife: 2 * time.Hour}
+	now := time.Date(2025, time.March, 1, 10, 30, 0, 0, time.UTC)
+
+	cur := d.Bucket(now)
+	if got := d.Bucket(now.Add(29 * time.Minute)); got != cur {
+		t.Errorf("Bucket(10:59) = %d, want %d", got, cur)
+	}
+	if got := d.Bucket(now.Add(30 * time.Minute)); got != cur+1 {
+		t.Errorf("Bucket(11:00) = %d, want %d", got, cur+1)
+	}
+
+	buckets := d.Buckets(now)
+	want := []int64{cur, cur - 1, cur - 2}
+	if len(buckets) != len(want) {
+		t.Fatalf("Buckets() = %v, want %v", buckets, want)
+	}
+	for i := range want {
+		if buckets[i] != want[i] {
+			t.Errorf("Buckets()[%d] = %d, want %d", i, buckets[i], want[i])
+		}
+	}
+
+	if got := d.TTL(); got != 4*time.Hour {
+		t.Errorf("TTL() = %v, want 4h", got)
+	}
+}
+
+func TestWeights(t *testing.T) {
+	d := Decay{Window: time.Hour, Windows: 5, HalfLife: 2 * time.Hour}
+	want := []float64{1, math.Sqrt(0.5), 0.5, 0.5 * math.Sqrt(0.5), 0.25}
+	got := d.Weights()
+	if len(got) != len(want) {
+		t.Fatalf("Weights() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("Weights()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestKeyword(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"  Python  ", "python"},
+		{"高等\t数学 \n A", "高等 数学 a"},
+		{"   ", ""},
+		{strings.Repeat("数", MaxKeywordLen+5), strings.Repeat("数", MaxKeywordLen)},
+	}
+	for _, tt := range tests {
+		if got := Keyword(tt.in); got != tt.want {
+			t.Errorf("Keyword(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
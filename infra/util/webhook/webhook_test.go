@@ -0,0 +1,84 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// TestSign 测试签名结果稳定且与密钥、时间戳、请求体相关
+func TestSign(t *testing.T) {
+	base := Sign("secret", 1700000000, []byte(`{"event":"course.created"}`))
+	if base != Sign("secret", 1700000000, []byte(`{"event":"course.created"}`)) {
+		t.Fatalf("Sign() is not deterministic")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+	}{
+		{"密钥不同", "other", 1700000000, `{"event":"course.created"}`},
+		{"时间戳不同", "secret", 1700000001, `{"event":"course.created"}`},
+		{"请求体不同", "secret", 1700000000, `{"event":"proposal.created"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Sign(tt.secret, tt.timestamp, []byte(tt.body)) == base {
+				t.Errorf("Sign() should differ when %s", tt.name)
+			}
+		})
+	}
+}
+
+// TestPost 测试投递请求头签名可被订阅方校验，非2xx状态码返回错误
+func TestPost(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{"订阅方返回200", http.StatusOK, false},
+		{"订阅方返回204", http.StatusNoContent, false},
+		{"订阅方返回500", http.StatusInternalServerError, true},
+	}
+
+	body := []byte(`{"event":"course.created"}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ts, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+				if r.Header.Get(HeaderSignature) != Sign("secret", ts, body) {
+					t.Errorf("signature mismatch")
+				}
+				if r.Header.Get(HeaderEvent) != "course.created" || r.Header.Get(HeaderDelivery) != "d1" {
+					t.Errorf("unexpected headers: %v", r.Header)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			code, err := Post(context.Background(), srv.URL, "secret", "course.created", "d1", body)
+			if (err != nil) != tt.wantErr || code != tt.statusCode {
+				t.Errorf("Post() = %d, %v, want %d, wantErr %v", code, err, tt.statusCode, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wechat 封装小程序服务端接口：获取接口调用凭证、发送订阅消息
+package wechat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	accessTokenPath      = "/cgi-bin/token"
+	subscribeMessagePath = "/cgi-bin/message/subscribe/send"
+)
+
+// 微信接口错误码
+const (
+	ErrCodeInvalidCredential = 40001 // access_token 无效
+	ErrCodeInvalidToken      = 40014 // 不合法的 access_token
+	ErrCodeTokenExpired      = 42001 // access_token 过期
+	ErrCodeInvalidOpenID     = 40003 // 不合法的 openid
+	ErrCodeInvalidTemplate   = 40037 // 不合法的模板ID
+	ErrCodeInvalidData       = 47003 // 模板参数不准确
+	ErrCodeUserRefused       = 43101 // 用户拒绝接受消息
+)
+
+var client = &http.Client{Timeout: 5 * time.Second}
+
+// APIError 微信接口返回的业务错误
+type APIError struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("wechat api error: %d %s", e.ErrCode, e.ErrMsg)
+}
+
+// IsTokenInvalid access_token 失效，需要刷新后重试
+func (e *APIError) IsTokenInvalid() bool {
+	return e.ErrCode == ErrCodeInvalidCredential || e.ErrCode == ErrCodeInvalidToken || e.ErrCode == ErrCodeTokenExpired
+}
+
+// IsPermanent 重试也不会成功的错误
+func (e *APIError) IsPermanent() bool {
+	switch e.ErrCode {
+	case ErrCodeInvalidOpenID, ErrCodeInvalidTemplate, ErrCodeInvalidData, ErrCodeUserRefused:
+		return true
+	}
+	return false
+}
+
+type accessTokenResp struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	APIError
+}
+
+// SubscribeMessage 订阅消息请求体
+type SubscribeMessage struct {
+	ToUser           string                    `json:"touser"`
+	TemplateID       string                    `json:"template_id"`
+	Page             string                    `json:"page,omitempty"`
+	MiniProgramState string                    `json:"miniprogram_state,omitempty"`
+	Lang             string                    `json:"lang,omitempty"`
+	Data             map[string]SubscribeValue `json:"data"`
+}
+
+type SubscribeValue struct {
+	Value string `json:"value"`
+}
+
+// GetAccessToken 获取接口调用凭证，返回 access_token 及其有效秒数
+func GetAccessToken(ctx context.Context, baseURL, appID, appSecret string) (string, int64, error) {
+	params := url.Values{}
+	params.Add("grant_type", "client_credential")
+	params.Add("appid", appID)
+	params.Add("secret", appSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+accessTokenPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return "", 0, err
+	}
+	var resp accessTokenResp
+	if err = do(req, &resp); err != nil {
+		return "", 0, err
+	}
+	if resp.ErrCode != 0 {
+		return "", 0, &resp.APIError
+	}
+	return resp.AccessToken, resp.ExpiresIn, nil
+}
+
+// SendSubscribeMessage 发送订阅消息
+func SendSubscribeMessage(ctx context.Context, baseURL, accessToken string, msg *SubscribeMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		baseURL+subscribeMessagePath+"?access_token="+url.QueryEscape(accessToken), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp APIError
+	if err = do(req, &resp); err != nil {
+		return err
+	}
+	if resp.ErrCode != 0 {
+		return &resp
+	}
+	return nil
+}
+
+// TruncateThing 截断 thing 类型字段，微信限制其不超过20个字符
+func TruncateThing(s string) string {
+	r := []rune(s)
+	if len(r) <= 20 {
+		return s
+	}
+	return string(r[:19]) + "…"
+}
+
+func do(req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("wechat api http status: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
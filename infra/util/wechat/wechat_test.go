@@ -0,0 +1,95 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSendSubscribeMessage 使用本地桩服务测试订阅消息发送及错误码处理
+func TestSendSubscribeMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		errCode        int
+		wantErr        bool
+		wantTokenError bool
+		wantPermanent  bool
+	}{
+		{"发送成功", 0, false, false, false},
+		{"access_token过期", ErrCodeTokenExpired, true, true, false},
+		{"用户拒绝接收", ErrCodeUserRefused, true, false, true},
+		{"系统繁忙", -1, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != subscribeMessagePath || r.URL.Query().Get("access_token") != "token" {
+					t.Errorf("unexpected request: %s", r.URL.String())
+				}
+				var msg SubscribeMessage
+				if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || msg.ToUser != "openid" {
+					t.Errorf("unexpected body: %+v, err: %v", msg, err)
+				}
+				_ = json.NewEncoder(w).Encode(APIError{ErrCode: tt.errCode})
+			}))
+			defer srv.Close()
+
+			err := SendSubscribeMessage(context.Background(), srv.URL, "token", &SubscribeMessage{
+				ToUser:     "openid",
+				TemplateID: "tpl",
+				Data:       map[string]SubscribeValue{"thing1": {Value: "标题"}},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendSubscribeMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				if apiErr.IsTokenInvalid() != tt.wantTokenError {
+					t.Errorf("IsTokenInvalid() = %v, want %v", apiErr.IsTokenInvalid(), tt.wantTokenError)
+				}
+				if apiErr.IsPermanent() != tt.wantPermanent {
+					t.Errorf("IsPermanent() = %v, want %v", apiErr.IsPermanent(), tt.wantPermanent)
+				}
+			}
+		})
+	}
+}
+
+// TestTruncateThing 测试 thing 字段截断
+func TestTruncateThing(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"短文本不截断", "高等数学", 4},
+		{"正好20个字符", "一二三四五六七八九十一二三四五六七八九十", 20},
+		{"超长文本截断到20个字符", "一二三四五六七八九十一二三四五六七八九十一", 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len([]rune(TruncateThing(tt.input))); got != tt.want {
+				t.Errorf("TruncateThing(%q) length = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/api/router"
@@ -34,6 +35,9 @@ import (
 
 func main() {
 	provider.Init()
+	go provider.Get().PushService.StartPushWorker(context.Background())
+	go provider.Get().WebhookService.StartWebhookWorker(context.Background())
+	go provider.Get().EventBus.StartRelay(context.Background())
 	r := router.SetupRoutes()
 	setLogLevel()
 	r.GET("/openapi.json", func(c *gin.Context) {
@@ -22,7 +22,9 @@ import (
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/cache"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/config"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/repo"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/eventbus"
 	"github.com/Boyuan-IT-Club/Meowpick-Backend/infra/util/mapping"
+	"github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
 	"github.com/google/wire"
 )
 
@@ -40,6 +42,12 @@ func Init() {
 		MappingRepo:  provider.MappingRepo,
 		MappingCache: provider.MappingCache,
 	})
+
+	// 注册领域事件订阅者
+	provider.EventSubscriber.Register()
+
+	// 构建内存全文索引
+	provider.SearchIndexer.Start()
 }
 
 func Get() *Provider {
@@ -58,6 +66,15 @@ type Provider struct {
 	SearchService        service.SearchService
 	ProposalService      service.ProposalService
 	ChangeLogService     service.ChangeLogService
+	WatchlistService     service.WatchlistService
+	NotificationService  service.NotificationService
+	PushService          service.PushService
+	WebhookService       service.WebhookService
+	PlanService          service.PlanService
+	TrendingService      service.TrendingService
+	EventSubscriber      service.EventSubscriber
+	EventBus             *eventbus.Bus
+	SearchIndexer        *service.SearchIndexer
 
 	// 新增的映射相关依赖
 	MappingRepo  *repo.MappingRepo
@@ -74,12 +91,22 @@ var ApplicationSet = wire.NewSet(
 	service.SearchServiceSet,
 	service.ProposalServiceSet,
 	service.ChangeLogServiceSet,
+	service.WatchlistServiceSet,
+	service.NotificationServiceSet,
+	service.PushServiceSet,
+	service.WebhookServiceSet,
+	service.PlanServiceSet,
+	service.TrendingServiceSet,
+	service.EventSubscriberSet,
+	service.SearchIndexerSet,
 	// Assembler 相关
 	assembler.CommentAssemblerSet,
 	assembler.CourseAssemblerSet,
 	assembler.TeacherAssemblerSet,
 	assembler.ProposalAssemblerSet,
 	assembler.ChangeLogAssemblerSet,
+	assembler.CourseOfferingAssemblerSet,
+	assembler.CourseRelationAssemblerSet,
 )
 
 var InfraSet = wire.NewSet(
@@ -93,10 +120,29 @@ var InfraSet = wire.NewSet(
 	repo.NewProposalRepo,
 	repo.NewMappingRepo, // 添加映射仓储
 	repo.NewChangeLogRepo,
+	repo.NewWatchlistRepo,
+	repo.NewCourseOfferingRepo,
+	repo.NewCourseRelationRepo,
+	repo.NewProposalCommentRepo,
+	repo.NewNotificationRepo,
+	repo.NewNotificationSettingRepo,
+	repo.NewSubscribeConsentRepo,
+	repo.NewPushTaskRepo,
+	repo.NewWebhookRepo,
+	repo.NewWebhookDeliveryRepo,
+	repo.NewWebhookAttemptRepo,
+	repo.NewEventOutboxRepo,
+	repo.NewPlanRepo,
 	// 缓存相关
 	cache.NewLikeCache,
 	cache.NewCommentCache,
 	cache.NewMappingCache, // 添加映射缓存
+	cache.NewWeChatCache,
+	cache.NewCourseCache,
+	cache.NewTrendingCache,
+	cache.NewProposalCache,
+	// 事件总线
+	NewEventBus,
 )
 
 var AllProvider = wire.NewSet(
@@ -112,3 +158,19 @@ func InitMapping(mappingRepo *repo.MappingRepo, mappingCache *cache.MappingCache
 	}
 	mapping.Data.InitWithDependencies(deps)
 }
+
+// NewEventBus 创建领域事件总线，开启 outbox 时异步事件持久化到 Mongo
+func NewEventBus(cfg *config.Config, outboxRepo *repo.EventOutboxRepo) *eventbus.Bus {
+	opts := eventbus.Options{
+		Workers:           cfg.EventBus.Workers,
+		QueueSize:         cfg.EventBus.QueueSize,
+		Lease:             consts.EventOutboxLease,
+		MaxAttempts:       consts.EventOutboxMaxAttempts,
+		RetryBaseInterval: consts.EventOutboxRetryBaseInterval,
+		RelayInterval:     consts.EventOutboxRelayInterval,
+	}
+	if cfg.EventBus.Outbox {
+		opts.Outbox = outboxRepo
+	}
+	return eventbus.New(opts)
+}
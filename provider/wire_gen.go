@@ -26,28 +26,40 @@ func NewProvider() (*Provider, error) {
 	likeRepo := repo.NewLikeRepo(configConfig)
 	courseRepo := repo.NewCourseRepo(configConfig)
 	teacherRepo := repo.NewTeacherRepo(configConfig)
+	courseOfferingRepo := repo.NewCourseOfferingRepo(configConfig)
 	commentAssembler := &assembler.CommentAssembler{
 		LikeRepo:    likeRepo,
 		CourseRepo:  courseRepo,
 		TeacherRepo: teacherRepo,
 	}
+	eventOutboxRepo := repo.NewEventOutboxRepo(configConfig)
+	bus := NewEventBus(configConfig, eventOutboxRepo)
+	proposalRepo := repo.NewProposalRepo(configConfig)
+	searchIndexer := service.NewSearchIndexer(configConfig, courseRepo, teacherRepo, proposalRepo)
 	commentService := service.CommentService{
-		CommentRepo:      commentRepo,
-		CommentCache:     commentCache,
-		CommentAssembler: commentAssembler,
+		CommentRepo:        commentRepo,
+		CourseOfferingRepo: courseOfferingRepo,
+		CommentCache:       commentCache,
+		CommentAssembler:   commentAssembler,
+		EventBus:           bus,
 	}
 	searchHistoryRepo := repo.NewSearchHistoryRepo(configConfig)
 	searchHistoryService := service.SearchHistoryService{
 		SearchHistoryRepo: searchHistoryRepo,
+		EventBus:          bus,
 	}
 	userRepo := repo.NewUserRepo(configConfig)
 	changeLogRepo := repo.NewChangeLogRepo(configConfig)
 	changeLogAssembler := &assembler.ChangeLogAssembler{}
-	proposalRepo := repo.NewProposalRepo(configConfig)
-	courseAssembler := &assembler.CourseAssembler{
-		CommentRepo: commentRepo,
+	courseOfferingAssembler := &assembler.CourseOfferingAssembler{
 		TeacherRepo: teacherRepo,
-		CourseRepo:  courseRepo,
+	}
+	courseAssembler := &assembler.CourseAssembler{
+		CommentRepo:             commentRepo,
+		TeacherRepo:             teacherRepo,
+		CourseRepo:              courseRepo,
+		CourseOfferingRepo:      courseOfferingRepo,
+		CourseOfferingAssembler: courseOfferingAssembler,
 	}
 	changeLogService := &service.ChangeLogService{
 		ChangeLogRepo:      changeLogRepo,
@@ -61,40 +73,101 @@ func NewProvider() (*Provider, error) {
 		ChangeLogService: changeLogService,
 	}
 	likeCache := cache.NewLikeCache(configConfig)
+	notificationRepo := repo.NewNotificationRepo(configConfig)
+	notificationSettingRepo := repo.NewNotificationSettingRepo(configConfig)
+	notificationService := &service.NotificationService{
+		NotificationRepo:        notificationRepo,
+		NotificationSettingRepo: notificationSettingRepo,
+	}
 	likeService := service.LikeService{
-		LikeRepo:     likeRepo,
-		LikeCache:    likeCache,
-		ProposalRepo: proposalRepo,
+		LikeRepo:  likeRepo,
+		LikeCache: likeCache,
+		EventBus:  bus,
 	}
-	courseService := service.CourseService{
-		CourseRepo:      courseRepo,
-		TeacherRepo:     teacherRepo,
-		CourseAssembler: courseAssembler,
+	courseCache := cache.NewCourseCache(configConfig)
+	courseRelationRepo := repo.NewCourseRelationRepo(configConfig)
+	watchlistRepo := repo.NewWatchlistRepo(configConfig)
+	planRepo := repo.NewPlanRepo(configConfig)
+	courseRelationAssembler := &assembler.CourseRelationAssembler{
+		CourseRepo: courseRepo,
 	}
 	teacherAssembler := &assembler.TeacherAssembler{}
+	courseService := service.CourseService{
+		CourseRepo:              courseRepo,
+		TeacherRepo:             teacherRepo,
+		UserRepo:                userRepo,
+		CommentRepo:             commentRepo,
+		LikeRepo:                likeRepo,
+		SearchHistoryRepo:       searchHistoryRepo,
+		CourseOfferingRepo:      courseOfferingRepo,
+		CourseRelationRepo:      courseRelationRepo,
+		WatchlistRepo:           watchlistRepo,
+		PlanRepo:                planRepo,
+		CourseAssembler:         courseAssembler,
+		CommentAssembler:        commentAssembler,
+		TeacherAssembler:        teacherAssembler,
+		CourseOfferingAssembler: courseOfferingAssembler,
+		CourseRelationAssembler: courseRelationAssembler,
+		CourseCache:             courseCache,
+		ChangeLogService:        changeLogService,
+		EventBus:                bus,
+		SearchIndexer:           searchIndexer,
+	}
 	teacherService := service.TeacherService{
-		UserRepo:         userRepo,
-		TeacherRepo:      teacherRepo,
-		TeacherAssembler: teacherAssembler,
+		UserRepo:           userRepo,
+		TeacherRepo:        teacherRepo,
+		CourseRepo:         courseRepo,
+		CourseOfferingRepo: courseOfferingRepo,
+		CommentRepo:        commentRepo,
+		TeacherAssembler:   teacherAssembler,
+		CourseAssembler:    courseAssembler,
+		CommentAssembler:   commentAssembler,
+		ChangeLogService:   changeLogService,
 	}
 	searchService := service.SearchService{
-		CourseRepo:  courseRepo,
-		TeacherRepo: teacherRepo,
+		CourseRepo:    courseRepo,
+		TeacherRepo:   teacherRepo,
+		SearchIndexer: searchIndexer,
 	}
 	proposalAssembler := &assembler.ProposalAssembler{
 		CourseAssembler: courseAssembler,
 		LikeRepo:        likeRepo,
 	}
+	pushTaskRepo := repo.NewPushTaskRepo(configConfig)
+	subscribeConsentRepo := repo.NewSubscribeConsentRepo(configConfig)
+	weChatCache := cache.NewWeChatCache(configConfig)
+	pushService := &service.PushService{
+		PushTaskRepo:         pushTaskRepo,
+		SubscribeConsentRepo: subscribeConsentRepo,
+		UserRepo:             userRepo,
+		WeChatCache:          weChatCache,
+	}
+	webhookRepo := repo.NewWebhookRepo(configConfig)
+	webhookDeliveryRepo := repo.NewWebhookDeliveryRepo(configConfig)
+	webhookAttemptRepo := repo.NewWebhookAttemptRepo(configConfig)
+	webhookService := &service.WebhookService{
+		WebhookRepo:         webhookRepo,
+		WebhookDeliveryRepo: webhookDeliveryRepo,
+		WebhookAttemptRepo:  webhookAttemptRepo,
+		UserRepo:            userRepo,
+	}
+	proposalCommentRepo := repo.NewProposalCommentRepo(configConfig)
+	proposalCache := cache.NewProposalCache(configConfig)
 	proposalService := service.ProposalService{
-		CourseRepo:        courseRepo,
-		CourseAssembler:   courseAssembler,
-		ProposalRepo:      proposalRepo,
-		ProposalAssembler: proposalAssembler,
-		LikeRepo:          likeRepo,
-		LikeCache:         likeCache,
-		UserRepo:          userRepo,
-		TeacherRepo:       teacherRepo,
-		ChangeLogService:  changeLogService,
+		CourseRepo:          courseRepo,
+		CourseAssembler:     courseAssembler,
+		ProposalRepo:        proposalRepo,
+		ProposalAssembler:   proposalAssembler,
+		LikeRepo:            likeRepo,
+		LikeCache:           likeCache,
+		ProposalCache:       proposalCache,
+		UserRepo:            userRepo,
+		TeacherRepo:         teacherRepo,
+		ChangeLogService:    changeLogService,
+		NotificationService: notificationService,
+		EventBus:            bus,
+		SearchIndexer:       searchIndexer,
+		ProposalCommentRepo: proposalCommentRepo,
 	}
 	serviceChangeLogService := service.ChangeLogService{
 		ChangeLogRepo:      changeLogRepo,
@@ -103,6 +176,57 @@ func NewProvider() (*Provider, error) {
 		ProposalRepo:       proposalRepo,
 		CourseAssembler:    courseAssembler,
 	}
+	watchlistService := service.WatchlistService{
+		WatchlistRepo:   watchlistRepo,
+		CourseRepo:      courseRepo,
+		CommentRepo:     commentRepo,
+		CourseAssembler: courseAssembler,
+	}
+	serviceNotificationService := service.NotificationService{
+		NotificationRepo:        notificationRepo,
+		NotificationSettingRepo: notificationSettingRepo,
+	}
+	servicePushService := service.PushService{
+		PushTaskRepo:         pushTaskRepo,
+		SubscribeConsentRepo: subscribeConsentRepo,
+		UserRepo:             userRepo,
+		WeChatCache:          weChatCache,
+	}
+	serviceWebhookService := service.WebhookService{
+		WebhookRepo:         webhookRepo,
+		WebhookDeliveryRepo: webhookDeliveryRepo,
+		WebhookAttemptRepo:  webhookAttemptRepo,
+		UserRepo:            userRepo,
+	}
+	planService := service.PlanService{
+		PlanRepo:                planRepo,
+		CourseRepo:              courseRepo,
+		CourseOfferingRepo:      courseOfferingRepo,
+		CourseOfferingAssembler: courseOfferingAssembler,
+	}
+	trendingCache := cache.NewTrendingCache(configConfig)
+	trendingService := service.TrendingService{
+		CourseRepo:      courseRepo,
+		CourseAssembler: courseAssembler,
+		TrendingCache:   trendingCache,
+	}
+	eventSubscriber := service.EventSubscriber{
+		EventBus:            bus,
+		UserRepo:            userRepo,
+		ProposalRepo:        proposalRepo,
+		CourseRepo:          courseRepo,
+		CommentRepo:         commentRepo,
+		CommentCache:        commentCache,
+		CourseCache:         courseCache,
+		ProposalCache:       proposalCache,
+		TrendingCache:       trendingCache,
+		CourseAssembler:     courseAssembler,
+		ProposalAssembler:   proposalAssembler,
+		ChangeLogService:    changeLogService,
+		NotificationService: notificationService,
+		PushService:         pushService,
+		WebhookService:      webhookService,
+	}
 	mappingRepo := repo.NewMappingRepo(configConfig)
 	mappingCache := cache.NewMappingCache(configConfig)
 	providerProvider := &Provider{
@@ -116,6 +240,15 @@ func NewProvider() (*Provider, error) {
 		SearchService:        searchService,
 		ProposalService:      proposalService,
 		ChangeLogService:     serviceChangeLogService,
+		WatchlistService:     watchlistService,
+		NotificationService:  serviceNotificationService,
+		PushService:          servicePushService,
+		WebhookService:       serviceWebhookService,
+		PlanService:          planService,
+		TrendingService:      trendingService,
+		EventSubscriber:      eventSubscriber,
+		EventBus:             bus,
+		SearchIndexer:        searchIndexer,
 		MappingRepo:          mappingRepo,
 		MappingCache:         mappingCache,
 	}
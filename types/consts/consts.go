@@ -41,8 +41,46 @@ const (
 	TargetType       = "targetType"
 	Content          = "content"
 	ProposalID       = "proposalId"
+	MergedInto       = "mergedInto"
+	MergedProposalIDs = "mergedProposalIds"
+	SearchKeys       = "searchKeys"
 	Contribution     = "contribution"
 	UserContribution = "contributionPoints"
+	LastSeenAt       = "lastSeenAt"
+	Read             = "read"
+	MutedTypes       = "mutedTypes"
+	Type             = "type"
+	Quota            = "quota"
+	Attempts         = "attempts"
+	NextRetryAt      = "nextRetryAt"
+	LastError        = "lastError"
+	URL              = "url"
+	Events           = "events"
+	Description      = "description"
+	WebhookID        = "webhookId"
+	DeliveryID       = "deliveryId"
+	LastStatusCode   = "lastStatusCode"
+	Semester         = "semester"
+	Campus           = "campus"
+	Capacity         = "capacity"
+	OfferingID       = "offeringId"
+	OfferingIDs      = "offeringIds"
+	Slots            = "slots"
+	FromID           = "fromId"
+	ToID             = "toId"
+	ReviewerID       = "reviewerId"
+	RejectReason     = "rejectReason"
+	RelationID       = "relationId"
+	Title            = "title"
+	Change           = "change"
+	Before           = "before"
+	TeacherID        = "teacherId"
+	Teacher          = "teacher"
+	TeacherChange    = "teacherChange"
+	TeacherBefore    = "teacherBefore"
+	ParentID         = "parentId"
+	Internal         = "internal"
+	EventKey         = "eventKey"
 )
 
 const (
@@ -70,6 +108,22 @@ const (
 	ActionTypeRevokeApproveProposal  int32 = 7
 	ActionTypeRevokeRejectProposal   int32 = 8
 	ActionTypeRejectProposal         int32 = 10
+	ActionTypeCreateCourse           int32 = 11
+	ActionTypeUpdateCourse           int32 = 12
+	ActionTypeDeleteCourse           int32 = 13
+	ActionTypeRestoreCourse          int32 = 14
+	ActionTypeMergeCourse            int32 = 15
+	ActionTypeImportCourse           int32 = 16
+	ActionTypeCreateOffering         int32 = 17
+	ActionTypeUpdateOffering         int32 = 18
+	ActionTypeDeleteOffering         int32 = 19
+	ActionTypeCreateRelation         int32 = 20
+	ActionTypeReviewRelation         int32 = 21
+	ActionTypeDeleteRelation         int32 = 22
+	ActionTypeCreateTeacher          int32 = 23
+	ActionTypeUpdateTeacher          int32 = 24
+	ActionTypeMergeTeacher           int32 = 25
+	ActionTypeRequestProposalInfo    int32 = 26
 )
 
 const (
@@ -86,19 +140,32 @@ const (
 	CacheTeacherKeyPrefix       = "meowpick:teacher:"
 	CacheCourseKeyPrefix        = "meowpick:course:"
 	CacheProposalKeyPrefix      = "meowpick:proposal:"
+	CacheWeChatKeyPrefix        = "meowpick:wechat:"
+	CacheTrendingKeyPrefix      = "meowpick:trending:"
 
-	CacheCommentCountTTL   = 12 * time.Hour
-	CacheLikeStatusTTL     = 10 * time.Minute
-	CacheProposalStatusTTL = 10 * time.Minute
+	CacheCommentCountTTL    = 12 * time.Hour
+	CacheLikeStatusTTL      = 10 * time.Minute
+	CacheProposalStatusTTL  = 10 * time.Minute
+	CacheCourseStatsTTL     = 10 * time.Minute // 新评论会主动失效，点赞变化依赖过期刷新
+	CacheWeChatTokenMargin  = 5 * time.Minute  // access_token 提前过期的时间，避免临界失效
+	CacheTrendingTopTTL     = time.Minute      // 合并后的热门榜单缓存时长
+	CacheProposalPendingTTL = time.Minute      // 提案状态变化会主动失效，其余变化依赖过期刷新
 )
 
 // 上下文相关
 const (
-	CtxUserID     = "userId"
-	CtxToken      = "token"
-	CtxLikeID     = "likeId"
-	CtxCourseID   = "courseId"
-	CtxProposalID = "proposalId"
+	CtxUserID         = "userId"
+	CtxToken          = "token"
+	CtxLikeID         = "likeId"
+	CtxCourseID       = "courseId"
+	CtxProposalID     = "proposalId"
+	CtxNotificationID = "notificationId"
+	CtxWebhookID      = "webhookId"
+	CtxDeliveryID     = "deliveryId"
+	CtxOfferingID     = "offeringId"
+	CtxRelationID     = "relationId"
+	CtxTeacherID      = "teacherId"
+	CtxCommentID      = "commentId"
 )
 
 // Request 相关
@@ -115,16 +182,129 @@ const (
 	ReqProposalID = "proposalId"
 )
 
+// 课程搜索排序方式
+const (
+	CourseSortRelevance = "relevance" // 相关度，默认
+	CourseSortComments  = "comments"  // 评论数
+	CourseSortRating    = "rating"    // 口碑，按评论获得的点赞总数
+	CourseSortNewest    = "newest"    // 最新创建
+)
+
 // 限制相关
 const (
 	SearchHistoryLimit = 15
 )
 
+// 课程推荐相关
+const (
+	RecommendDefaultLimit   = 10  // 默认推荐数量
+	RecommendMaxLimit       = 50  // 推荐数量上限
+	RecommendCandidateLimit = 20  // 每个信号召回的候选课程数
+	RecommendSearchTop      = 5   // 参与召回的最近搜索词数
+	RecommendAffinityTop    = 3   // 参与召回的院系、类别数
+	RecommendLikeLimit      = 200 // 参与协同过滤的最近点赞数
+	RecommendNeighborLimit  = 50  // 参与计算相似度的用户数
+	RecommendNeighborTop    = 20  // 取最相似的用户数
+
+	RecommendWeightSearch     = 1.0 // 搜索过的关键词
+	RecommendWeightDepartment = 0.6 // 评价过课程的院系
+	RecommendWeightCategory   = 0.4 // 评价过课程的类别
+	RecommendWeightSimilar    = 2.0 // 相似用户点赞过评论的课程，乘以相似度
+	RecommendWeightPopular    = 0.1 // 热门课程补足
+
+	RecommendReasonSearch     = "search"
+	RecommendReasonDepartment = "department"
+	RecommendReasonCategory   = "category"
+	RecommendReasonSimilar    = "similar"
+	RecommendReasonPopular    = "popular"
+)
+
+// 课程导入相关
+const (
+	CourseImportFormFile = "file"  // 上传文件的表单字段
+	CourseImportMaxSize  = 5 << 20 // 文件大小上限
+	CourseImportMaxRows  = 5000    // 数据行数上限
+)
+
+// 课程导出相关
+const (
+	CourseExportFormatCSV    = "csv"
+	CourseExportFormatNDJSON = "ndjson"
+	CourseExportBatchSize    = 100 // 每批统计评论数与标签的课程数
+)
+
+// 订阅消息推送相关
+const (
+	PushTaskStatusPending int32 = 1 // 待发送
+	PushTaskStatusSent    int32 = 2 // 已发送
+	PushTaskStatusFailed  int32 = 3 // 重试耗尽或不可重试
+
+	PushMaxAttempts       = 5
+	PushRetryBaseInterval = 30 * time.Second // 第n次失败后等待 base*2^(n-1)
+	PushWorkerInterval    = 10 * time.Second
+	PushWorkerBatchSize   = 20
+	PushTaskLease         = time.Minute // 任务被领取后的占用时长，防止多实例重复发送
+)
+
+// Webhook 事件类型
+const (
+	WebhookEventAll               = "*" // 订阅全部事件
+	WebhookEventProposalCreated   = "proposal.created"
+	WebhookEventProposalApproved  = "proposal.approved"
+	WebhookEventProposalRejected  = "proposal.rejected"
+	WebhookEventProposalRevoked   = "proposal.revoked"
+	WebhookEventProposalNeedsInfo = "proposal.needs_info"
+	WebhookEventCourseCreated     = "course.created"
+)
+
+// Webhook 投递相关
+const (
+	WebhookDeliveryStatusPending   int32 = 1 // 待投递或等待重试
+	WebhookDeliveryStatusSucceeded int32 = 2 // 投递成功
+	WebhookDeliveryStatusDead      int32 = 3 // 重试耗尽，进入死信列表
+
+	WebhookMaxAttempts       = 6
+	WebhookRetryBaseInterval = 30 * time.Second // 第n次失败后等待 base*2^(n-1)
+	WebhookWorkerInterval    = 5 * time.Second
+	WebhookWorkerBatchSize   = 20
+	WebhookDeliveryLease     = time.Minute // 投递被领取后的占用时长，防止多实例重复投递
+)
+
+// 领域事件 outbox 相关
+const (
+	EventOutboxStatusPending int32 = 1 // 待分发或等待重试
+	EventOutboxStatusDone    int32 = 2 // 分发成功
+	EventOutboxStatusFailed  int32 = 3 // 重试耗尽
+
+	EventOutboxMaxAttempts       = 5
+	EventOutboxRetryBaseInterval = 30 * time.Second // 第n次失败后等待 base*2^(n-1)
+	EventOutboxRelayInterval     = 10 * time.Second
+	EventOutboxLease             = time.Minute // 记录被领取后的占用时长，防止多实例重复分发
+)
+
 // 提案状态相关
 const (
 	ProposalStatusPending  = "pending"  // 待审核
 	ProposalStatusApproved = "approved" // 已通过
 	ProposalStatusRejected = "rejected" // 已拒绝
+
+	ProposalStatusNeedsInfo = "needsInfo" // 待补充信息
+)
+
+// 提案类型相关
+const (
+	ProposalTypeAdd     = "add"     // 新增课程
+	ProposalTypeChange  = "change"  // 修改现有课程
+	ProposalTypeTeacher = "teacher" // 更正教师信息
+
+	ProposalChangeFieldName       = "name"
+	ProposalChangeFieldCode       = "code"
+	ProposalChangeFieldDepartment = "department"
+	ProposalChangeFieldCategory   = "category"
+	ProposalChangeFieldCampuses   = "campuses"
+	ProposalChangeFieldTeachers   = "teachers"
+	ProposalChangeFieldRetired    = "retired" // 课程不再开设
+	ProposalChangeFieldTitle      = "title"   // 教师职称
 )
 
 // 点赞目标类型相关
@@ -141,6 +321,49 @@ const (
 	SuggestionTargetTypeCategory   = "category"
 )
 
+// 课程统计相关
+const (
+	StatsTimezone                  = "Asia/Shanghai" // 按月统计评论量时使用的时区
+	CourseStatsGranularityMonth    = "month"
+	CourseStatsGranularitySemester = "semester"
+)
+
+// 课程对比相关
+const (
+	CourseCompareMinCount = 2 // 最少对比课程数
+	CourseCompareMaxCount = 4 // 最多对比课程数
+)
+
+// 教师主页相关
+const (
+	TeacherProfileCourseLimit = 50 // 教师主页最多展示的授课课程数
+)
+
+// 热门榜单相关
+const (
+	TrendingKindCourse = "course"
+	TrendingKindSearch = "search"
+	TrendingScopeAll   = "all" // 不区分院系的榜单
+
+	TrendingWindow       = time.Hour      // 每个桶覆盖的时长
+	TrendingWindows      = 72             // 参与合并的桶数，即只统计最近三天
+	TrendingHalfLife     = 24 * time.Hour // 热度减半所需时长
+	TrendingDefaultLimit = 10
+	TrendingMaxLimit     = 50
+
+	TrendingWeightComment int64 = 5 // 发布吐槽
+	TrendingWeightLike    int64 = 2 // 点赞课程下的吐槽，取消点赞时扣回
+	TrendingWeightView    int64 = 1 // 查看课程详情，同一用户每个窗口只计一次
+	TrendingWeightSearch  int64 = 1 // 搜索关键词，同一用户每个窗口只计一次
+)
+
+// 全文索引文档类型相关
+const (
+	SearchDocTypeCourse   = "course"
+	SearchDocTypeTeacher  = "teacher"
+	SearchDocTypeProposal = "proposal"
+)
+
 // 提案字段类型相关
 const (
 	FieldDepartment  = "department"
@@ -157,6 +380,18 @@ const (
 	RevokeActionReject  = "reject"  // 撤回拒绝
 )
 
+// 通知类型相关
+const (
+	NotificationTypeProposalApproved = "proposalApproved" // 提案被通过
+	NotificationTypeProposalRejected = "proposalRejected" // 提案被拒绝
+	NotificationTypeProposalRevoked  = "proposalRevoked"  // 提案审批被撤回
+	NotificationTypeProposalLiked    = "proposalLiked"    // 提案被点赞
+	NotificationTypeCommentLiked     = "commentLiked"     // 评论被点赞
+
+	NotificationTypeProposalNeedsInfo = "proposalNeedsInfo" // 提案需要补充信息
+	NotificationTypeProposalCommented = "proposalCommented" // 提案收到讨论或回复
+)
+
 // 变更记录目标类型
 const (
 	ChangeLogTargetTypeCourse   = "course"   // 课程
@@ -177,3 +412,25 @@ const (
 	ChangeLogSourceManual = "manual" // 手动操作
 	ChangeLogSourceSystem = "system" // 系统自动
 )
+
+// 选课计划相关
+const (
+	PlanMaxOfferings = 30           // 每份选课计划最多包含的开设数
+	PlanDateLayout   = "2006-01-02" // 配置中学期开始日期的格式
+	PlanICSUIDDomain = "meowpick"   // 日历事件 UID 的域名部分
+)
+
+// 课程关系相关
+const (
+	CourseRelationPrerequisite = "prerequisite" // 先修：from 是 to 的先修课
+	CourseRelationEquivalent   = "equivalent"   // 等价：不区分方向
+	CourseRelationFollowUp     = "followup"     // 后续：学完 from 后推荐学习 to
+
+	CourseRelationStatusPending  int32 = 1 // 用户提议，待审核
+	CourseRelationStatusApproved int32 = 2 // 已生效
+	CourseRelationStatusRejected int32 = 3 // 已拒绝
+
+	CourseRelationWalkLimit         = 200 // 检查先修环或展开等价课程时最多访问的课程数
+	CourseRelationSearchSeedLimit   = 20  // 搜索时用于展开等价课程的精确命中课程数上限
+	CourseRelationSearchExpandLimit = 50  // 搜索时最多追加的等价课程数
+)
@@ -28,6 +28,19 @@ const (
 	ErrCourseGetCategoriesFailed  = 101000007
 	ErrCourseGetCampusesFailed    = 101000008
 	ErrCourseCreateFailed         = 101000009
+	ErrCourseAlreadyExists        = 101000010
+	ErrCourseUpdateFailed         = 101000011
+	ErrCourseDeleteFailed         = 101000012
+	ErrCourseDeleted              = 101000013
+	ErrCourseNotDeleted           = 101000014
+	ErrCourseMerged               = 101000015
+	ErrCourseMergeInvalid         = 101000016
+	ErrCourseMergeFailed          = 101000017
+	ErrCourseImportInvalidFile    = 101000018
+	ErrCourseExportFailed         = 101000019
+	ErrCourseStatsFailed          = 101000020
+	ErrCourseRecommendFailed      = 101000021
+	ErrCourseCompareFailed        = 101000022
 )
 
 func init() {
@@ -76,4 +89,69 @@ func init() {
 		"failed to create course: {name}",
 		code.WithAffectStability(false),
 	)
+	code.Register(
+		ErrCourseAlreadyExists,
+		"course already exists: {name}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseUpdateFailed,
+		"failed to update course {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseDeleteFailed,
+		"failed to delete course {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseDeleted,
+		"course {courseId} has been deleted",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseNotDeleted,
+		"course {courseId} is not deleted",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseMerged,
+		"course {courseId} has been merged into {targetId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseMergeInvalid,
+		"invalid course merge: {reason}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseMergeFailed,
+		"failed to merge courses into {targetId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseImportInvalidFile,
+		"invalid course import file: {reason}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseExportFailed,
+		"failed to export courses",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseStatsFailed,
+		"failed to get statistics of course {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseRecommendFailed,
+		"failed to recommend courses",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrCourseCompareFailed,
+		"failed to compare courses",
+		code.WithAffectStability(false),
+	)
 }
@@ -0,0 +1,73 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// notification: 111 000 000 ~ 111 999 999
+
+const (
+	ErrNotificationInsertFailed      = 111000001
+	ErrNotificationFindFailed        = 111000002
+	ErrNotificationCountFailed       = 111000003
+	ErrNotificationUpdateFailed      = 111000004
+	ErrNotificationNotFound          = 111000005
+	ErrNotificationTypeInvalid       = 111000006
+	ErrNotificationSettingFindFailed = 111000007
+	ErrNotificationSettingSaveFailed = 111000008
+)
+
+func init() {
+	code.Register(
+		ErrNotificationInsertFailed,
+		"failed to insert notification for user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationFindFailed,
+		"failed to find notifications by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationCountFailed,
+		"failed to count unread notifications of user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationUpdateFailed,
+		"failed to mark notification as read: {notificationId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationNotFound,
+		"notification not found: {notificationId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationTypeInvalid,
+		"invalid notification type: {type}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationSettingFindFailed,
+		"failed to find notification setting of user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrNotificationSettingSaveFailed,
+		"failed to save notification setting of user {userId}",
+		code.WithAffectStability(false),
+	)
+}
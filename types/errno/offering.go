@@ -0,0 +1,73 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// offering: 114 000 000 ~ 114 999 999
+
+const (
+	ErrOfferingInvalidParam   = 114000001
+	ErrOfferingNotFound       = 114000002
+	ErrOfferingFindFailed     = 114000003
+	ErrOfferingCreateFailed   = 114000004
+	ErrOfferingAlreadyExists  = 114000005
+	ErrOfferingUpdateFailed   = 114000006
+	ErrOfferingDeleteFailed   = 114000007
+	ErrOfferingCourseMismatch = 114000008
+)
+
+func init() {
+	code.Register(
+		ErrOfferingInvalidParam,
+		"invalid parameter {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingNotFound,
+		"course offering not found by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingFindFailed,
+		"failed to find course offering by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingCreateFailed,
+		"failed to create offering of course {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingAlreadyExists,
+		"course {courseId} is already offered in {semester} at this campus",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingUpdateFailed,
+		"failed to update course offering {offeringId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingDeleteFailed,
+		"failed to delete course offering {offeringId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrOfferingCourseMismatch,
+		"course offering {offeringId} does not belong to course {courseId}",
+		code.WithAffectStability(false),
+	)
+}
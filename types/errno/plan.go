@@ -0,0 +1,61 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// plan: 115 000 000 ~ 115 999 999
+
+const (
+	ErrPlanInvalidParam     = 115000001
+	ErrPlanFindFailed       = 115000002
+	ErrPlanUpdateFailed     = 115000003
+	ErrPlanSemesterMismatch = 115000004
+	ErrPlanTooManyOfferings = 115000005
+	ErrPlanExportFailed     = 115000006
+)
+
+func init() {
+	code.Register(
+		ErrPlanInvalidParam,
+		"invalid parameter {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPlanFindFailed,
+		"failed to find plan of semester {semester}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPlanUpdateFailed,
+		"failed to update plan of semester {semester}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPlanSemesterMismatch,
+		"course offering {offeringId} is not offered in {semester}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPlanTooManyOfferings,
+		"a plan can contain at most {limit} course offerings",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPlanExportFailed,
+		"failed to export plan of semester {semester}",
+		code.WithAffectStability(false),
+	)
+}
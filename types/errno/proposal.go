@@ -45,6 +45,13 @@ const (
 	ErrProposalAlreadyPending              = 108000024
 	ErrRevokeActionTypeInvalid             = 108000025
 	ErrProposalInvalidCampus               = 108000026
+	ErrProposalCourseRequired              = 108000027
+	ErrProposalChangeEmpty                 = 108000028
+	ErrProposalChangePending               = 108000029
+	ErrProposalTeacherChangeEmpty          = 108000030
+	ErrProposalTeacherChangePending        = 108000031
+	ErrTeacherModifiedCannotRevoke         = 108000032
+	ErrProposalStatusNotPending            = 108000033
 )
 
 func init() {
@@ -178,4 +185,39 @@ func init() {
 		"invalid campus: {key}: {value}",
 		code.WithAffectStability(false),
 	)
+	code.Register(
+		ErrProposalCourseRequired,
+		"proposal course is required",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalChangeEmpty,
+		"proposal changes nothing on course: {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalChangePending,
+		"another change proposal is pending on course: {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalTeacherChangeEmpty,
+		"proposal changes nothing on teacher: {teacherId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalTeacherChangePending,
+		"another correction proposal is pending on teacher: {teacherId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherModifiedCannotRevoke,
+		"teacher has been modified cannot revoke approve",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalStatusNotPending,
+		"proposal is not pending: {proposalId}",
+		code.WithAffectStability(false),
+	)
 }
@@ -0,0 +1,61 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// proposal comment: 118 000 000 ~ 118 999 999
+
+const (
+	ErrProposalCommentCreateFailed     = 118000001
+	ErrProposalCommentFindFailed       = 118000002
+	ErrProposalCommentNotFound         = 118000003
+	ErrProposalCommentDeleteFailed     = 118000004
+	ErrProposalCommentParentInvalid    = 118000005
+	ErrProposalCommentInternalNotAdmin = 118000006
+)
+
+func init() {
+	code.Register(
+		ErrProposalCommentCreateFailed,
+		"failed to create comment on proposal: {proposalId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalCommentFindFailed,
+		"failed to find proposal comment",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalCommentNotFound,
+		"proposal comment not found: {commentId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalCommentDeleteFailed,
+		"failed to delete proposal comment: {commentId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalCommentParentInvalid,
+		"parent comment {parentId} does not belong to proposal: {proposalId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrProposalCommentInternalNotAdmin,
+		"only admins can read or write internal notes, userId: {userId}",
+		code.WithAffectStability(false),
+	)
+}
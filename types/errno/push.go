@@ -0,0 +1,55 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// push: 112 000 000 ~ 112 999 999
+
+const (
+	ErrPushTypeNotSupported  = 112000001
+	ErrPushConsentSaveFailed = 112000002
+	ErrPushConsentFindFailed = 112000003
+	ErrPushTaskInsertFailed  = 112000004
+	ErrPushAccessTokenFailed = 112000005
+)
+
+func init() {
+	code.Register(
+		ErrPushTypeNotSupported,
+		"subscribe message type not supported: {type}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPushConsentSaveFailed,
+		"failed to save subscribe consent of user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPushConsentFindFailed,
+		"failed to find subscribe consent of user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPushTaskInsertFailed,
+		"failed to enqueue push task for user {userId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrPushAccessTokenFailed,
+		"failed to get wechat access token",
+		code.WithAffectStability(false),
+	)
+}
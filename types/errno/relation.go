@@ -0,0 +1,79 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// relation: 116 000 000 ~ 116 999 999
+
+const (
+	ErrRelationInvalidParam  = 116000001
+	ErrRelationNotFound      = 116000002
+	ErrRelationFindFailed    = 116000003
+	ErrRelationCreateFailed  = 116000004
+	ErrRelationAlreadyExists = 116000005
+	ErrRelationCycle         = 116000006
+	ErrRelationUpdateFailed  = 116000007
+	ErrRelationNotPending    = 116000008
+	ErrRelationDeleteFailed  = 116000009
+)
+
+func init() {
+	code.Register(
+		ErrRelationInvalidParam,
+		"invalid parameter {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationNotFound,
+		"course relation not found by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationFindFailed,
+		"failed to find course relations by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationCreateFailed,
+		"failed to create {type} relation from course {fromId} to {toId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationAlreadyExists,
+		"{type} relation from course {fromId} to {toId} already exists or is pending review",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationCycle,
+		"course {fromId} cannot be a prerequisite of {toId}: it would create a prerequisite cycle",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationUpdateFailed,
+		"failed to update course relation {relationId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationNotPending,
+		"course relation {relationId} is not pending review",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrRelationDeleteFailed,
+		"failed to delete course relation {relationId}",
+		code.WithAffectStability(false),
+	)
+}
@@ -25,6 +25,12 @@ const (
 	ErrTeacherExistsFailed         = 104000004
 	ErrTeacherInsertFailed         = 104000005
 	ErrTeacherFindFailed           = 104000006
+	ErrTeacherProfileFailed        = 104000007
+	ErrTeacherAmbiguous            = 104000008
+	ErrTeacherUpdateFailed         = 104000009
+	ErrTeacherMerged               = 104000010
+	ErrTeacherMergeInvalid         = 104000011
+	ErrTeacherMergeFailed          = 104000012
 )
 
 func init() {
@@ -58,4 +64,34 @@ func init() {
 		"failed to find teacher: {name}",
 		code.WithAffectStability(false),
 	)
+	code.Register(
+		ErrTeacherProfileFailed,
+		"failed to get profile of teacher {teacherId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherAmbiguous,
+		"several teachers named {name} match, please specify the teacher id, department or title",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherUpdateFailed,
+		"failed to update teacher {teacherId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherMerged,
+		"teacher {teacherId} has been merged into {targetId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherMergeInvalid,
+		"invalid teacher merge: {reason}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTeacherMergeFailed,
+		"failed to merge teachers into {targetId}",
+		code.WithAffectStability(false),
+	)
 }
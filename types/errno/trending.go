@@ -0,0 +1,37 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// trending: 117 000 000 ~ 117 999 999
+
+const (
+	ErrTrendingInvalidParam = 117000001
+	ErrTrendingFindFailed   = 117000002
+)
+
+func init() {
+	code.Register(
+		ErrTrendingInvalidParam,
+		"invalid parameter {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrTrendingFindFailed,
+		"failed to get trending {kind}",
+		code.WithAffectStability(false),
+	)
+}
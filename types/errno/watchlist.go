@@ -0,0 +1,55 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// watchlist: 110 000 000 ~ 110 999 999
+
+const (
+	ErrWatchlistAddFailed    = 110000001
+	ErrWatchlistRemoveFailed = 110000002
+	ErrWatchlistFindFailed   = 110000003
+	ErrWatchlistUpdateFailed = 110000004
+	ErrWatchlistNotWatched   = 110000005
+)
+
+func init() {
+	code.Register(
+		ErrWatchlistAddFailed,
+		"failed to add course {courseId} to watchlist",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWatchlistRemoveFailed,
+		"failed to remove course {courseId} from watchlist",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWatchlistFindFailed,
+		"failed to find watchlist by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWatchlistUpdateFailed,
+		"failed to update watchlist of course {courseId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWatchlistNotWatched,
+		"course {courseId} is not in watchlist",
+		code.WithAffectStability(false),
+	)
+}
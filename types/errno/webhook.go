@@ -0,0 +1,91 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errno
+
+import "github.com/Boyuan-IT-Club/go-kit/errorx/code"
+
+// webhook: 113 000 000 ~ 113 999 999
+
+const (
+	ErrWebhookInvalidEvent       = 113000001
+	ErrWebhookInsertFailed       = 113000002
+	ErrWebhookFindFailed         = 113000003
+	ErrWebhookNotFound           = 113000004
+	ErrWebhookUpdateFailed       = 113000005
+	ErrWebhookDeleteFailed       = 113000006
+	ErrWebhookPublishFailed      = 113000007
+	ErrWebhookDeliveryFindFailed = 113000008
+	ErrWebhookDeliveryNotDead    = 113000009
+	ErrWebhookInvalidStatus      = 113000010
+	ErrWebhookInvalidURL         = 113000011
+)
+
+func init() {
+	code.Register(
+		ErrWebhookInvalidEvent,
+		"invalid webhook event: {event}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookInsertFailed,
+		"failed to create webhook {url}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookFindFailed,
+		"failed to find webhook by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookNotFound,
+		"webhook not found: {webhookId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookUpdateFailed,
+		"failed to update webhook {webhookId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookDeleteFailed,
+		"failed to delete webhook {webhookId}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookPublishFailed,
+		"failed to publish webhook event {event}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookDeliveryFindFailed,
+		"failed to find webhook deliveries by {key}: {value}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookDeliveryNotDead,
+		"webhook delivery {deliveryId} is not in dead letter list",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookInvalidStatus,
+		"invalid webhook delivery status: {status}",
+		code.WithAffectStability(false),
+	)
+	code.Register(
+		ErrWebhookInvalidURL,
+		"invalid webhook url: {url}",
+		code.WithAffectStability(false),
+	)
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 Boyuan-IT-Club
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapping
+
+import "github.com/Boyuan-IT-Club/Meowpick-Backend/types/consts"
+
+var NotificationTypeMap = map[int32]string{
+	1: consts.NotificationTypeProposalApproved,
+	2: consts.NotificationTypeProposalRejected,
+	3: consts.NotificationTypeProposalRevoked,
+	4: consts.NotificationTypeProposalLiked,
+	5: consts.NotificationTypeCommentLiked,
+	6: consts.NotificationTypeProposalNeedsInfo,
+	7: consts.NotificationTypeProposalCommented,
+}
@@ -20,4 +20,5 @@ var ProposalStatusMap = map[int32]string{
 	1: consts.ProposalStatusPending,
 	2: consts.ProposalStatusApproved,
 	3: consts.ProposalStatusRejected,
+	4: consts.ProposalStatusNeedsInfo,
 }